@@ -1,6 +1,7 @@
 package models
 
 import (
+	"errors"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -52,6 +53,33 @@ type Feedback struct {
 	DurationStart    time.Time `gorm:"not null"`
 	DurationEnd      time.Time `gorm:"not null"`
 	ExpireAt         time.Time `gorm:"not null"`
+	// The feedback is pinned to the version of the feedback form it is issued with
+	FeedbackFormVersion   FeedbackFormVersion `gorm:"association_autoupdate:false; association_autocreate:false"`
+	FeedbackFormVersionID uint                `gorm:"not null"`
+}
+
+// BeforeCreate pins the feedback to the latest version of its feedback form, if no version is given
+func (feedback *Feedback) BeforeCreate(db *gorm.DB) error {
+	if feedback.FeedbackFormVersionID != 0 {
+		return nil
+	}
+	var feedbackForm FeedbackForm
+	err := db.Model(&FeedbackForm{}).
+		Where("feedback_forms.deleted_at IS NULL").
+		Where("id = ?", feedback.FeedbackFormID).
+		First(&feedbackForm).Error
+	if err != nil {
+		return err
+	}
+	if feedbackForm.Status != PublishedFeedbackForm {
+		return errors.New("feedbacks can only be created with a published feedback form")
+	}
+	version, err := GetLatestFeedbackFormVersion(db, feedbackForm.ID)
+	if err != nil {
+		return err
+	}
+	feedback.FeedbackFormVersionID = version.ID
+	return nil
 }
 
 // RegisterFeedbackToAdmin ...
@@ -1,12 +1,16 @@
 package models
 
 import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
 	"github.com/jinzhu/gorm"
 	"github.com/qor/admin"
 	"github.com/qor/qor"
 	"github.com/qor/qor/resource"
 	"github.com/sirupsen/logrus"
-	"strconv"
 )
 
 // FeedbackFormStatus ...
@@ -16,12 +20,14 @@ type FeedbackFormStatus int8
 const (
 	DraftFeedbackForm FeedbackFormStatus = iota
 	PublishedFeedbackForm
+	ArchivedFeedbackForm
 )
 
 // FeedbackFormStatusValues ...
 var FeedbackFormStatusValues = [...]string{
 	"Draft",
 	"Published",
+	"Archived",
 }
 
 // String ...
@@ -29,14 +35,83 @@ func (status FeedbackFormStatus) String() string {
 	return FeedbackFormStatusValues[status]
 }
 
-// FeedbackForm represent template form for feedback
-// TODO Add support for versioning
+// FeedbackForm represent template form for feedback. A feedback form is drafted by editing its contents, and each
+// publish of it creates an immutable FeedbackFormVersion with a snapshot of the contents, which the new feedbacks
+// are issued with. An archived feedback form can not be used for the new feedbacks anymore.
 type FeedbackForm struct {
 	gorm.Model
 	Title       string             `gorm:"type:varchar(255); not null"`
 	Description string             `gorm:"type:text;"`
 	Status      FeedbackFormStatus `gorm:"default:0; not null"`
-	Archive     bool               `gorm:"default:false; not null"`
+}
+
+// Publish creates a new version of the feedback form from its current contents and marks the form as published
+func (feedbackForm *FeedbackForm) Publish(db *gorm.DB) (*FeedbackFormVersion, error) {
+	if feedbackForm.Status == ArchivedFeedbackForm {
+		return nil, errors.New("archived feedback form can not be published")
+	}
+
+	tx := db.Begin()
+	contents, err := GetFeedbackFormContentSnapshots(tx, feedbackForm.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if len(contents) == 0 {
+		tx.Rollback()
+		return nil, errors.New("feedback form without any contents can not be published")
+	}
+
+	versionNumber := uint(1)
+	latestVersion, err := GetLatestFeedbackFormVersion(tx, feedbackForm.ID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		tx.Rollback()
+		return nil, err
+	}
+	if latestVersion != nil {
+		latestContents, err := latestVersion.GetContents()
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if len(DiffFeedbackFormContents(latestContents, contents)) == 0 &&
+			latestVersion.Title == feedbackForm.Title && latestVersion.Description == feedbackForm.Description {
+			tx.Rollback()
+			return nil, errors.New("feedback form has no changes since the last published version")
+		}
+		versionNumber = latestVersion.Version + 1
+	}
+
+	serializedContents, err := json.Marshal(contents)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	version := FeedbackFormVersion{
+		FeedbackFormID: feedbackForm.ID,
+		Version:        versionNumber,
+		Title:          feedbackForm.Title,
+		Description:    feedbackForm.Description,
+		Contents:       serializedContents,
+		PublishedAt:    time.Now(),
+	}
+	if err = tx.Create(&version).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Model(feedbackForm).Update("status", PublishedFeedbackForm).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// Archive marks the feedback form as archived, the feedbacks already issued with its versions are not affected
+func (feedbackForm *FeedbackForm) Archive(db *gorm.DB) error {
+	return db.Model(feedbackForm).Update("status", ArchivedFeedbackForm).Error
 }
 
 // RegisterFeedbackFormToAdmin ...
@@ -44,6 +119,46 @@ func RegisterFeedbackFormToAdmin(Admin *admin.Admin, config admin.Config) {
 	feedbackForm := Admin.AddResource(&FeedbackForm{}, &config)
 	statusMeta := getFeedbackFormStatusFieldMeta()
 	feedbackForm.Meta(&statusMeta)
+	// The status is changed only by publishing and archiving the feedback form
+	feedbackForm.NewAttrs("-Status")
+	feedbackForm.EditAttrs("-Status")
+
+	feedbackForm.Action(&admin.Action{
+		Name: "Publish",
+		Handler: func(argument *admin.ActionArgument) error {
+			for _, record := range argument.FindSelectedRecords() {
+				if _, err := record.(*FeedbackForm).Publish(argument.Context.GetDB()); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Modes: []string{"show", "menu_item"},
+	})
+	feedbackForm.Action(&admin.Action{
+		Name: "Archive",
+		Handler: func(argument *admin.ActionArgument) error {
+			for _, record := range argument.FindSelectedRecords() {
+				if err := record.(*FeedbackForm).Archive(argument.Context.GetDB()); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Modes: []string{"show", "menu_item"},
+	})
+}
+
+// RegisterFeedbackFormVersionToAdmin ...
+func RegisterFeedbackFormVersionToAdmin(Admin *admin.Admin, config admin.Config) {
+	feedbackFormVersion := Admin.AddResource(&FeedbackFormVersion{}, &config)
+	feedbackFormVersion.Meta(&admin.Meta{
+		Name: "Contents",
+		Type: "text",
+		Valuer: func(value interface{}, context *qor.Context) interface{} {
+			return string(value.(*FeedbackFormVersion).Contents)
+		},
+	})
 }
 
 // getFeedbackFormStatusFieldMeta is the meta config for the feedback form status field
@@ -2,8 +2,8 @@ package models
 
 import "github.com/jinzhu/gorm"
 
-// FeedbackFormContent represent the content of the feedback form
-// TODO Add support for making it non-editable
+// FeedbackFormContent represent the content of the feedback form, its changes are a draft of the next version of the
+// feedback form till it is published
 type FeedbackFormContent struct {
 	gorm.Model
 	FeedbackForm   FeedbackForm
@@ -0,0 +1,329 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/iReflect/reflect-app/db/models/fields"
+)
+
+// Actions of the feedback form changes
+const (
+	AddedFeedbackFormChange   = "added"
+	RemovedFeedbackFormChange = "removed"
+	ChangedFeedbackFormChange = "changed"
+)
+
+// Entities of the feedback form changes
+const (
+	SkillFeedbackFormEntity    = "skill"
+	QuestionFeedbackFormEntity = "question"
+)
+
+// FeedbackFormVersion represent a published version of a feedback form, it keeps a snapshot of the contents of
+// the form at the time of publishing, so that the feedbacks issued with it are not affected by the later edits
+type FeedbackFormVersion struct {
+	gorm.Model
+	FeedbackForm   FeedbackForm
+	FeedbackFormID uint         `gorm:"not null; unique_index:idx_feedback_form_version"`
+	Version        uint         `gorm:"not null; unique_index:idx_feedback_form_version"`
+	Title          string       `gorm:"type:varchar(255); not null"`
+	Description    string       `gorm:"type:text;"`
+	Contents       fields.JSONB `gorm:"type:jsonb; not null; default:'[]'::jsonb"`
+	PublishedAt    time.Time    `gorm:"not null"`
+}
+
+// FeedbackFormContentSnapshot is the snapshot of a content of a feedback form
+type FeedbackFormContentSnapshot struct {
+	ID       uint
+	Category CategorySnapshot
+	Skill    SkillSnapshot
+}
+
+// CategorySnapshot is the snapshot of a category of a feedback form
+type CategorySnapshot struct {
+	ID          uint
+	Title       string
+	Description string
+}
+
+// SkillSnapshot is the snapshot of a skill of a feedback form
+type SkillSnapshot struct {
+	ID           uint
+	Title        string
+	DisplayTitle string
+	Description  string
+	Weight       int
+	Questions    []QuestionSnapshot
+}
+
+// QuestionSnapshot is the snapshot of a question of a feedback form
+type QuestionSnapshot struct {
+	ID      uint
+	Text    string
+	Type    QuestionType
+	Options fields.JSONB
+	Weight  int
+}
+
+// FeedbackFormChange is a change of a skill or a question between two versions of a feedback form
+type FeedbackFormChange struct {
+	Action     string
+	Entity     string
+	SkillID    uint
+	QuestionID uint
+	Field      string
+	From       interface{}
+	To         interface{}
+}
+
+// BeforeUpdate ...
+func (version *FeedbackFormVersion) BeforeUpdate(db *gorm.DB) error {
+	return errors.New("published feedback form versions can not be changed")
+}
+
+// BeforeDelete ...
+func (version *FeedbackFormVersion) BeforeDelete(db *gorm.DB) error {
+	return errors.New("published feedback form versions can not be deleted")
+}
+
+// GetContents returns the snapshot of the contents of the feedback form version
+func (version FeedbackFormVersion) GetContents() (contents []FeedbackFormContentSnapshot, err error) {
+	if err = json.Unmarshal(version.Contents, &contents); err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
+
+// GetQuestion returns the snapshot of the question of the feedback form version
+func (version FeedbackFormVersion) GetQuestion(questionID uint) (*Question, bool) {
+	contents, err := version.GetContents()
+	if err != nil {
+		return nil, false
+	}
+	for _, content := range contents {
+		for _, question := range content.Skill.Questions {
+			if question.ID == questionID {
+				return question.Question(content.Skill.ID), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Question returns the question of the snapshot, it is used for validating the responses against the snapshot
+func (question QuestionSnapshot) Question(skillID uint) *Question {
+	return &Question{
+		Model:   gorm.Model{ID: question.ID},
+		Text:    question.Text,
+		Type:    question.Type,
+		SkillID: skillID,
+		Options: question.Options,
+		Weight:  question.Weight,
+	}
+}
+
+// GetFeedbackFormContentSnapshots returns the snapshot of the current contents of the feedback form
+func GetFeedbackFormContentSnapshots(db *gorm.DB, feedbackFormID uint) ([]FeedbackFormContentSnapshot, error) {
+	var formContents []FeedbackFormContent
+	err := db.Model(&FeedbackFormContent{}).
+		Where("feedback_form_contents.deleted_at IS NULL").
+		Where("feedback_form_id = ?", feedbackFormID).
+		Preload("Category").
+		Preload("Skill").
+		Preload("Skill.Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("questions.id")
+		}).
+		Order("feedback_form_contents.id").
+		Find(&formContents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	contents := []FeedbackFormContentSnapshot{}
+	for _, formContent := range formContents {
+		skill := formContent.Skill
+		questions := []QuestionSnapshot{}
+		for _, question := range skill.Questions {
+			questions = append(questions, QuestionSnapshot{
+				ID:      question.ID,
+				Text:    question.Text,
+				Type:    question.Type,
+				Options: question.Options,
+				Weight:  question.Weight,
+			})
+		}
+		contents = append(contents, FeedbackFormContentSnapshot{
+			ID: formContent.ID,
+			Category: CategorySnapshot{
+				ID:          formContent.Category.ID,
+				Title:       formContent.Category.Title,
+				Description: formContent.Category.Description,
+			},
+			Skill: SkillSnapshot{
+				ID:           skill.ID,
+				Title:        skill.Title,
+				DisplayTitle: skill.DisplayTitle,
+				Description:  skill.Description,
+				Weight:       skill.Weight,
+				Questions:    questions,
+			},
+		})
+	}
+	return contents, nil
+}
+
+// GetLatestFeedbackFormVersion returns the last published version of the feedback form
+func GetLatestFeedbackFormVersion(db *gorm.DB, feedbackFormID uint) (*FeedbackFormVersion, error) {
+	var version FeedbackFormVersion
+	err := db.Model(&FeedbackFormVersion{}).
+		Where("feedback_form_versions.deleted_at IS NULL").
+		Where("feedback_form_id = ?", feedbackFormID).
+		Order("version DESC").
+		First(&version).Error
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// DiffFeedbackFormContents returns the changes of the skills and the questions from the "from" contents to the "to"
+// contents. The skills and the questions are matched by their IDs, the removed and the changed ones are listed in
+// the order of the "from" contents, followed by the added ones in the order of the "to" contents.
+func DiffFeedbackFormContents(from []FeedbackFormContentSnapshot,
+	to []FeedbackFormContentSnapshot) []FeedbackFormChange {
+	changes := []FeedbackFormChange{}
+
+	toContents := map[uint]FeedbackFormContentSnapshot{}
+	for _, content := range to {
+		toContents[content.Skill.ID] = content
+	}
+	fromContents := map[uint]bool{}
+
+	for _, fromContent := range from {
+		fromSkill := fromContent.Skill
+		fromContents[fromSkill.ID] = true
+		toContent, exists := toContents[fromSkill.ID]
+		if !exists {
+			changes = append(changes, FeedbackFormChange{
+				Action:  RemovedFeedbackFormChange,
+				Entity:  SkillFeedbackFormEntity,
+				SkillID: fromSkill.ID,
+				From:    fromSkill.Title,
+			})
+			continue
+		}
+		toSkill := toContent.Skill
+
+		addSkillChange := func(field string, fromValue interface{}, toValue interface{}) {
+			if fromValue != toValue {
+				changes = append(changes, FeedbackFormChange{
+					Action:  ChangedFeedbackFormChange,
+					Entity:  SkillFeedbackFormEntity,
+					SkillID: fromSkill.ID,
+					Field:   field,
+					From:    fromValue,
+					To:      toValue,
+				})
+			}
+		}
+		addSkillChange("Category", fromContent.Category.Title, toContent.Category.Title)
+		addSkillChange("Title", fromSkill.Title, toSkill.Title)
+		addSkillChange("DisplayTitle", fromSkill.DisplayTitle, toSkill.DisplayTitle)
+		addSkillChange("Description", fromSkill.Description, toSkill.Description)
+		addSkillChange("Weight", fromSkill.Weight, toSkill.Weight)
+
+		changes = append(changes, diffQuestions(fromSkill, toSkill)...)
+	}
+
+	for _, toContent := range to {
+		if !fromContents[toContent.Skill.ID] {
+			changes = append(changes, FeedbackFormChange{
+				Action:  AddedFeedbackFormChange,
+				Entity:  SkillFeedbackFormEntity,
+				SkillID: toContent.Skill.ID,
+				To:      toContent.Skill.Title,
+			})
+		}
+	}
+	return changes
+}
+
+// diffQuestions returns the changes of the questions of a skill present in both the contents
+func diffQuestions(fromSkill SkillSnapshot, toSkill SkillSnapshot) []FeedbackFormChange {
+	var changes []FeedbackFormChange
+
+	toQuestions := map[uint]QuestionSnapshot{}
+	for _, question := range toSkill.Questions {
+		toQuestions[question.ID] = question
+	}
+	fromQuestions := map[uint]bool{}
+
+	for _, fromQuestion := range fromSkill.Questions {
+		fromQuestions[fromQuestion.ID] = true
+		toQuestion, exists := toQuestions[fromQuestion.ID]
+		if !exists {
+			changes = append(changes, FeedbackFormChange{
+				Action:     RemovedFeedbackFormChange,
+				Entity:     QuestionFeedbackFormEntity,
+				SkillID:    fromSkill.ID,
+				QuestionID: fromQuestion.ID,
+				From:       fromQuestion.Text,
+			})
+			continue
+		}
+
+		addQuestionChange := func(field string, fromValue interface{}, toValue interface{}) {
+			changes = append(changes, FeedbackFormChange{
+				Action:     ChangedFeedbackFormChange,
+				Entity:     QuestionFeedbackFormEntity,
+				SkillID:    fromSkill.ID,
+				QuestionID: fromQuestion.ID,
+				Field:      field,
+				From:       fromValue,
+				To:         toValue,
+			})
+		}
+		if fromQuestion.Text != toQuestion.Text {
+			addQuestionChange("Text", fromQuestion.Text, toQuestion.Text)
+		}
+		if fromQuestion.Type != toQuestion.Type {
+			addQuestionChange("Type", fromQuestion.Type.String(), toQuestion.Type.String())
+		}
+		if !isSameJSON(fromQuestion.Options, toQuestion.Options) {
+			addQuestionChange("Options", fromQuestion.Options, toQuestion.Options)
+		}
+		if fromQuestion.Weight != toQuestion.Weight {
+			addQuestionChange("Weight", fromQuestion.Weight, toQuestion.Weight)
+		}
+	}
+
+	for _, toQuestion := range toSkill.Questions {
+		if !fromQuestions[toQuestion.ID] {
+			changes = append(changes, FeedbackFormChange{
+				Action:     AddedFeedbackFormChange,
+				Entity:     QuestionFeedbackFormEntity,
+				SkillID:    toSkill.ID,
+				QuestionID: toQuestion.ID,
+				To:         toQuestion.Text,
+			})
+		}
+	}
+	return changes
+}
+
+// isSameJSON checks if the two JSON values are the same, irrespective of their formatting and the order of the keys
+func isSameJSON(first fields.JSONB, second fields.JSONB) bool {
+	var firstValue, secondValue interface{}
+	if first.IsNull() || second.IsNull() {
+		return first.IsNull() == second.IsNull()
+	}
+	if json.Unmarshal(first, &firstValue) != nil || json.Unmarshal(second, &secondValue) != nil {
+		return string(first) == string(second)
+	}
+	return reflect.DeepEqual(firstValue, secondValue)
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/iReflect/reflect-app/db/models/fields"
+)
+
+func TestDiffFeedbackFormContents(t *testing.T) {
+	from := []FeedbackFormContentSnapshot{
+		{
+			ID:       1,
+			Category: CategorySnapshot{ID: 1, Title: "Technical"},
+			Skill: SkillSnapshot{ID: 1, Title: "Coding", Weight: 1, Questions: []QuestionSnapshot{
+				{ID: 1, Text: "Writes clean code", Type: GradingType, Options: fields.JSONB(`{"values": [{"id": 1}]}`)},
+				{ID: 2, Text: "Writes tests", Type: BooleanType, Options: fields.JSONB(`{}`)},
+			}},
+		},
+		{
+			ID:       2,
+			Category: CategorySnapshot{ID: 1, Title: "Technical"},
+			Skill:    SkillSnapshot{ID: 2, Title: "Design"},
+		},
+	}
+	to := []FeedbackFormContentSnapshot{
+		{
+			ID:       1,
+			Category: CategorySnapshot{ID: 1, Title: "Technical"},
+			Skill: SkillSnapshot{ID: 1, Title: "Coding", Weight: 2, Questions: []QuestionSnapshot{
+				{ID: 1, Text: "Writes clean code", Type: GradingType, Options: fields.JSONB(`{"values":[{"id":1}]}`)},
+				{ID: 3, Text: "Reviews code", Type: BooleanType, Options: fields.JSONB(`{}`)},
+			}},
+		},
+		{
+			ID:       3,
+			Category: CategorySnapshot{ID: 2, Title: "Communication"},
+			Skill:    SkillSnapshot{ID: 3, Title: "Writing"},
+		},
+	}
+
+	changes := DiffFeedbackFormContents(from, to)
+	expectedChanges := []FeedbackFormChange{
+		{Action: ChangedFeedbackFormChange, Entity: SkillFeedbackFormEntity, SkillID: 1, Field: "Weight", From: 1, To: 2},
+		{Action: RemovedFeedbackFormChange, Entity: QuestionFeedbackFormEntity, SkillID: 1, QuestionID: 2,
+			From: "Writes tests"},
+		{Action: AddedFeedbackFormChange, Entity: QuestionFeedbackFormEntity, SkillID: 1, QuestionID: 3,
+			To: "Reviews code"},
+		{Action: RemovedFeedbackFormChange, Entity: SkillFeedbackFormEntity, SkillID: 2, From: "Design"},
+		{Action: AddedFeedbackFormChange, Entity: SkillFeedbackFormEntity, SkillID: 3, To: "Writing"},
+	}
+	if len(changes) != len(expectedChanges) {
+		t.Fatalf("Expected %d changes, got %v", len(expectedChanges), changes)
+	}
+	for index, expected := range expectedChanges {
+		if changes[index] != expected {
+			t.Errorf("Expected change %v, got %v", expected, changes[index])
+		}
+	}
+
+	if changes = DiffFeedbackFormContents(to, to); len(changes) != 0 {
+		t.Errorf("Expected no changes between the same contents, got %v", changes)
+	}
+}
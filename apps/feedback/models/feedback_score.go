@@ -0,0 +1,158 @@
+package models
+
+import (
+	"math"
+	"strconv"
+)
+
+// FeedbackScore is the weighted scores of a feedback. The scores are normalized between 0 and 1, and are nil if
+// none of the questions under them is scored.
+//   - The score of a skill is the average of the scores of its questions, weighted by the question weights
+//   - The score of a category is the average of the scores of its skills, weighted by the skill weights
+//   - The overall score is the average of the scores of all the skills, weighted by the skill weights
+type FeedbackScore struct {
+	Overall    *float64
+	Categories map[uint]*float64
+	Skills     map[uint]*float64
+	Questions  map[uint]*float64
+}
+
+// weightedAverage accumulates the weighted average of the scores
+type weightedAverage struct {
+	total  float64
+	weight float64
+}
+
+func (average *weightedAverage) add(score *float64, weight int) {
+	// The non positive weights exclude the score from the average
+	if score == nil || weight <= 0 {
+		return
+	}
+	average.total += *score * float64(weight)
+	average.weight += float64(weight)
+}
+
+func (average weightedAverage) value() *float64 {
+	if average.weight == 0 {
+		return nil
+	}
+	value := average.total / average.weight
+	return &value
+}
+
+// GetFeedbackScore returns the weighted scores of the responses, mapped by the question ids, to the questions of the
+// feedback form contents
+func GetFeedbackScore(contents []FeedbackFormContentSnapshot, responses map[uint]string) FeedbackScore {
+	score := FeedbackScore{
+		Categories: map[uint]*float64{},
+		Skills:     map[uint]*float64{},
+		Questions:  map[uint]*float64{},
+	}
+	var overall weightedAverage
+	categories := map[uint]*weightedAverage{}
+
+	for _, content := range contents {
+		var skill weightedAverage
+		for _, questionSnapshot := range content.Skill.Questions {
+			var questionScore *float64
+			if value, isScored := questionSnapshot.Question(content.Skill.ID).GetScore(
+				responses[questionSnapshot.ID]); isScored {
+				questionScore = &value
+			}
+			score.Questions[questionSnapshot.ID] = questionScore
+			skill.add(questionScore, questionSnapshot.Weight)
+		}
+		skillScore := skill.value()
+		score.Skills[content.Skill.ID] = skillScore
+
+		if _, exists := categories[content.Category.ID]; !exists {
+			categories[content.Category.ID] = &weightedAverage{}
+		}
+		categories[content.Category.ID].add(skillScore, content.Skill.Weight)
+		overall.add(skillScore, content.Skill.Weight)
+	}
+
+	for categoryID, category := range categories {
+		score.Categories[categoryID] = category.value()
+	}
+	score.Overall = overall.value()
+	return score
+}
+
+// GetScore returns the normalized score of the response to the question, between 0 (the lowest) and 1 (the highest).
+// The grading values are scored by their "score" option, or by their order in the values, the first being the lowest,
+// if the values have no scores. The boolean and the multi choice responses are scored only if their values have
+// scores, since their order does not rank them, and the long text and the ranking responses are not scored.
+func (question *Question) GetScore(response string) (float64, bool) {
+	if response == "" || !question.ValidateQuestionResponse(response) {
+		return 0, false
+	}
+	options := question.GetOptions()
+
+	switch question.Type {
+	case GradingType, BooleanType, MultiChoiceType:
+		valueScores, hasScores := getOptionValueScores(options)
+		if question.Type != GradingType && !hasScores {
+			return 0, false
+		}
+		var total float64
+		responseList := GetQuestionResponseList(response)
+		for _, valueResponse := range responseList {
+			id, _ := strconv.ParseFloat(valueResponse, 64)
+			valueScore, exists := valueScores[id]
+			if !exists {
+				return 0, false
+			}
+			total += valueScore
+		}
+		return total / float64(len(responseList)), true
+	case NumericRangeType:
+		min, max, _, err := getNumericRange(options)
+		value, _ := strconv.ParseFloat(response, 64)
+		return (value - min) / (max - min), err == nil
+	case LikertScaleType:
+		labels, err := getLikertScaleLabels(options)
+		point, _ := strconv.Atoi(response)
+		return float64(point-1) / float64(len(labels)-1), err == nil
+	}
+	return 0, false
+}
+
+// getOptionValueScores returns the normalized scores of the values of the options, mapped by the value ids, and if
+// the scores are given by the values, the values are scored by their order unless all of them have their own scores
+func getOptionValueScores(options map[string]interface{}) (map[float64]float64, bool) {
+	values, _ := options["values"].([]interface{})
+	hasScores := len(values) > 0
+	for _, value := range values {
+		valueMap, _ := value.(map[string]interface{})
+		if _, hasScore := valueMap["score"].(float64); !hasScore {
+			hasScores = false
+		}
+	}
+
+	rawScores := map[float64]float64{}
+	min, max := math.Inf(1), math.Inf(-1)
+	for index, value := range values {
+		valueMap, _ := value.(map[string]interface{})
+		id, isNumber := valueMap["id"].(float64)
+		if !isNumber {
+			continue
+		}
+		score := float64(index)
+		if hasScores {
+			score = valueMap["score"].(float64)
+		}
+		rawScores[id] = score
+		min = math.Min(min, score)
+		max = math.Max(max, score)
+	}
+
+	scores := map[float64]float64{}
+	if len(rawScores) < 2 || min == max {
+		return scores, false
+	}
+	for id, score := range rawScores {
+		scores[id] = (score - min) / (max - min)
+	}
+	return scores, hasScores
+}
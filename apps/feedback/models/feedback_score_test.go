@@ -0,0 +1,93 @@
+package models
+
+import (
+	"math"
+	"testing"
+
+	"github.com/iReflect/reflect-app/db/models/fields"
+)
+
+func TestGetFeedbackScore(t *testing.T) {
+	contents := []FeedbackFormContentSnapshot{
+		{
+			ID:       1,
+			Category: CategorySnapshot{ID: 1, Title: "Technical"},
+			Skill: SkillSnapshot{ID: 1, Title: "Coding", Weight: 3, Questions: []QuestionSnapshot{
+				// Graded by the order of the values
+				{ID: 1, Type: GradingType, Weight: 1,
+					Options: fields.JSONB(`{"values": [{"id": 7}, {"id": 8}, {"id": 9}]}`)},
+				// Graded by the scores of the values
+				{ID: 2, Type: BooleanType, Weight: 3,
+					Options: fields.JSONB(`{"values": [{"id": 1, "score": 1}, {"id": 2, "score": 0}]}`)},
+				// Not scored
+				{ID: 3, Type: LongTextType, Weight: 1, Options: fields.JSONB(`{}`)},
+			}},
+		},
+		{
+			ID:       2,
+			Category: CategorySnapshot{ID: 1, Title: "Technical"},
+			Skill: SkillSnapshot{ID: 2, Title: "Design", Weight: 1, Questions: []QuestionSnapshot{
+				{ID: 4, Type: NumericRangeType, Weight: 1, Options: fields.JSONB(`{"min": 0, "max": 10}`)},
+			}},
+		},
+		{
+			ID:       3,
+			Category: CategorySnapshot{ID: 2, Title: "Communication"},
+			Skill: SkillSnapshot{ID: 3, Title: "Writing", Weight: 2, Questions: []QuestionSnapshot{
+				{ID: 5, Type: LikertScaleType, Weight: 1,
+					Options: fields.JSONB(`{"labels": ["Disagree", "Neutral", "Agree", "Strongly Agree"]}`)},
+			}},
+		},
+		{
+			ID:       4,
+			Category: CategorySnapshot{ID: 3, Title: "Leadership"},
+			Skill: SkillSnapshot{ID: 4, Title: "Mentoring", Weight: 1, Questions: []QuestionSnapshot{
+				{ID: 6, Type: LongTextType, Weight: 1, Options: fields.JSONB(`{}`)},
+			}},
+		},
+	}
+	responses := map[uint]string{1: "8", 2: "1", 3: "Clean code", 4: "2", 5: "3", 6: "Mentors well"}
+
+	score := GetFeedbackScore(contents, responses)
+	expectedScores := []struct {
+		name     string
+		score    *float64
+		expected float64
+	}{
+		{"grading question", score.Questions[1], 0.5},
+		{"boolean question", score.Questions[2], 1},
+		{"numeric range question", score.Questions[4], 0.2},
+		{"likert scale question", score.Questions[5], 2.0 / 3},
+		{"coding skill", score.Skills[1], (0.5*1 + 1*3) / 4},
+		{"technical category", score.Categories[1], (0.875*3 + 0.2*1) / 4},
+		{"communication category", score.Categories[2], 2.0 / 3},
+		{"overall", score.Overall, (0.875*3 + 0.2*1 + 2.0/3*2) / 6},
+	}
+	for _, expected := range expectedScores {
+		if expected.score == nil || math.Abs(*expected.score-expected.expected) > 1e-9 {
+			t.Errorf("Expected the %s score to be %v, got %v", expected.name, expected.expected, expected.score)
+		}
+	}
+	if score.Questions[3] != nil || score.Skills[4] != nil || score.Categories[3] != nil {
+		t.Errorf("Expected the long text questions not to be scored")
+	}
+
+	if score = GetFeedbackScore(contents, map[uint]string{}); score.Overall != nil {
+		t.Errorf("Expected no overall score without the responses, got %v", *score.Overall)
+	}
+}
+
+func TestGetScoreOfUnscoredValues(t *testing.T) {
+	options := fields.JSONB(`{"values": [{"id": 1}, {"id": 2}, {"id": 3}]}`)
+
+	grading := Question{Type: GradingType, Options: options}
+	if score, isScored := grading.GetScore("3"); !isScored || score != 1 {
+		t.Errorf("Expected the grading values to be scored by their order, got %v, %t", score, isScored)
+	}
+
+	// The order of the boolean values, eg. Yes before No, does not tell which of them is the better one
+	boolean := Question{Type: BooleanType, Options: options}
+	if score, isScored := boolean.GetScore("1"); isScored {
+		t.Errorf("Expected the boolean values without scores not to be scored, got %v", score)
+	}
+}
@@ -22,6 +22,10 @@ const (
 	MultiChoiceType QuestionType = iota
 	GradingType
 	BooleanType
+	LongTextType
+	NumericRangeType
+	LikertScaleType
+	RankingType
 )
 
 // QuestionTypeValues ...
@@ -29,6 +33,10 @@ var QuestionTypeValues = [...]string{
 	"Multi Choice",
 	"Grade",
 	"Boolean",
+	"Long Text",
+	"Numeric Range",
+	"Likert Scale",
+	"Ranking",
 }
 
 // String ...
@@ -36,8 +44,7 @@ func (questionType QuestionType) String() string {
 	return QuestionTypeValues[questionType]
 }
 
-// Question represent the questions asked for a skill
-// TODO Add support for versioning and making it non-editable
+// Question represent the questions asked for a skill, the published feedback forms keep a snapshot of their questions
 type Question struct {
 	gorm.Model
 	Text    string       `gorm:"type:text; not null"`
@@ -58,6 +65,17 @@ func (question Question) GetOptions() (questionOptions map[string]interface{}) {
 
 // ValidateQuestionResponse validates the question response (default also), against the question options
 func (question *Question) ValidateQuestionResponse(questionResponse string) bool {
+	// An empty response is a question not answered yet, the submitted feedbacks are checked to answer all the questions
+	switch question.Type {
+	case LongTextType:
+		return validateLongTextResponse(question.GetOptions(), questionResponse)
+	case NumericRangeType:
+		return questionResponse == "" || validateNumericRangeResponse(question.GetOptions(), questionResponse)
+	case LikertScaleType:
+		return questionResponse == "" || validateLikertScaleResponse(question.GetOptions(), questionResponse)
+	case RankingType:
+		return questionResponse == "" || validateRankingResponse(question.GetOptions(), questionResponse)
+	}
 
 	questionResponseList := GetQuestionResponseList(questionResponse)
 
@@ -102,11 +120,18 @@ func (question *Question) ValidateQuestionResponse(questionResponse string) bool
 
 // BeforeSave ...
 func (question *Question) BeforeSave(db *gorm.DB) (err error) {
+	if err = question.ValidateOptions(); err != nil {
+		return err
+	}
 
 	// Check if default question response is valid
 	defaultOptions, exists := question.GetOptions()["defaultValue"]
 	if exists && defaultOptions != "" {
-		if isValid := question.ValidateQuestionResponse(defaultOptions.(string)); !isValid {
+		defaultValue, isString := defaultOptions.(string)
+		if !isString {
+			return errors.New("default value should be a string")
+		}
+		if isValid := question.ValidateQuestionResponse(defaultValue); !isValid {
 			err = errors.New("default value can only be from valid values")
 		}
 	}
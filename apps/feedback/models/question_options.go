@@ -0,0 +1,217 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+// The options of the question types are,
+//  - Multi Choice, Grade and Boolean: {"values": [{"id": 1, ...}, ...]}, the response is the comma separated ids of
+//    the chosen values, only one value can be chosen for the Grade and the Boolean questions. The values of the
+//    Boolean questions should have a "score", eg. {"id": 1, "score": 1} for Yes, since their order does not tell
+//    which of them is the better one. The Boolean questions created before the scores were required are left
+//    unscored till the scores are added to them.
+//  - Long Text: {"maxLength": 2000}, the max length is optional and the response is the text
+//  - Numeric Range: {"min": 1, "max": 10, "step": 0.5}, the step is optional and defaults to 1, the response is a
+//    number in the range which is a whole number of steps from the min
+//  - Likert Scale: {"labels": ["Disagree", "Neutral", "Agree"]}, the response is the point of the scale, 1 being the
+//    first label
+//  - Ranking: {"values": [{"id": 1, ...}, ...]}, the response is the comma separated ids of all the values in the
+//    order of their ranks
+
+// numericRangeTolerance is the tolerance of the floating point errors while checking the steps of the numeric ranges
+const numericRangeTolerance = 1e-9
+
+// ValidateOptions validates the options of the question against the schema of the options of its type
+func (question *Question) ValidateOptions() error {
+	options := map[string]interface{}{}
+	if !question.Options.IsNull() {
+		if err := json.Unmarshal(question.Options, &options); err != nil {
+			return errors.New("question options should be a JSON object")
+		}
+	}
+
+	var err error
+	switch question.Type {
+	case MultiChoiceType, GradingType:
+		_, err = getOptionValueIDs(options, 1)
+	case BooleanType:
+		if _, err = getOptionValueIDs(options, 2); err == nil && (question.ID == 0 || hasOptionValueScore(options)) {
+			err = validateOptionValueScores(options)
+		}
+	case LongTextType:
+		_, err = getLongTextMaxLength(options)
+	case NumericRangeType:
+		_, _, _, err = getNumericRange(options)
+	case LikertScaleType:
+		_, err = getLikertScaleLabels(options)
+	case RankingType:
+		_, err = getOptionValueIDs(options, 2)
+	default:
+		err = errors.New("unknown question type")
+	}
+	return err
+}
+
+// getOptionValueIDs returns the ids of the values of the options, which should have at least minValues values
+func getOptionValueIDs(options map[string]interface{}, minValues int) (map[float64]bool, error) {
+	values, exists := options["values"].([]interface{})
+	if !exists || len(values) < minValues {
+		return nil, fmt.Errorf("question options should have at least %d values", minValues)
+	}
+	ids := map[float64]bool{}
+	for _, value := range values {
+		valueMap, isMap := value.(map[string]interface{})
+		if !isMap {
+			return nil, errors.New("question option values should be JSON objects")
+		}
+		id, isNumber := valueMap["id"].(float64)
+		if !isNumber || id != math.Trunc(id) || id < 0 {
+			return nil, errors.New("question option values should have a whole number id")
+		}
+		if ids[id] {
+			return nil, fmt.Errorf("question option value id %v is repeated", id)
+		}
+		ids[id] = true
+	}
+	return ids, nil
+}
+
+// validateOptionValueScores validates each of the values of the options to have a score, not all of them the same
+func validateOptionValueScores(options map[string]interface{}) error {
+	values, _ := options["values"].([]interface{})
+	for _, value := range values {
+		valueMap, _ := value.(map[string]interface{})
+		if _, hasScore := valueMap["score"].(float64); !hasScore {
+			return errors.New("question option values should have a numeric score")
+		}
+	}
+	if _, hasScores := getOptionValueScores(options); !hasScores {
+		return errors.New("question option values should not all have the same score")
+	}
+	return nil
+}
+
+// hasOptionValueScore checks if any of the values of the options has a score
+func hasOptionValueScore(options map[string]interface{}) bool {
+	values, _ := options["values"].([]interface{})
+	for _, value := range values {
+		valueMap, _ := value.(map[string]interface{})
+		if _, hasScore := valueMap["score"]; hasScore {
+			return true
+		}
+	}
+	return false
+}
+
+// getLongTextMaxLength returns the max length of the long text response, 0 if there is no max length
+func getLongTextMaxLength(options map[string]interface{}) (int, error) {
+	maxLength, exists := options["maxLength"]
+	if !exists {
+		return 0, nil
+	}
+	length, isNumber := maxLength.(float64)
+	if !isNumber || length != math.Trunc(length) || length <= 0 {
+		return 0, errors.New("maxLength of the question options should be a positive whole number")
+	}
+	return int(length), nil
+}
+
+// getNumericRange returns the min, the max and the step of the numeric range
+func getNumericRange(options map[string]interface{}) (min float64, max float64, step float64, err error) {
+	var isNumber bool
+	if min, isNumber = options["min"].(float64); !isNumber {
+		return 0, 0, 0, errors.New("min of the question options should be a number")
+	}
+	if max, isNumber = options["max"].(float64); !isNumber {
+		return 0, 0, 0, errors.New("max of the question options should be a number")
+	}
+	if min >= max {
+		return 0, 0, 0, errors.New("min of the question options should be less than the max")
+	}
+	step = 1
+	if stepOption, exists := options["step"]; exists {
+		if step, isNumber = stepOption.(float64); !isNumber || step <= 0 {
+			return 0, 0, 0, errors.New("step of the question options should be a positive number")
+		}
+	}
+	if step > max-min {
+		return 0, 0, 0, errors.New("step of the question options should not be more than the range")
+	}
+	return min, max, step, nil
+}
+
+// getLikertScaleLabels returns the labels of the points of the likert scale
+func getLikertScaleLabels(options map[string]interface{}) ([]string, error) {
+	labelOptions, exists := options["labels"].([]interface{})
+	if !exists || len(labelOptions) < 2 {
+		return nil, errors.New("question options should have at least 2 labels")
+	}
+	var labels []string
+	for _, labelOption := range labelOptions {
+		label, isString := labelOption.(string)
+		if !isString || label == "" {
+			return nil, errors.New("labels of the question options should be non empty strings")
+		}
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
+
+// validateLongTextResponse validates the long text response against the max length of the options
+func validateLongTextResponse(options map[string]interface{}, response string) bool {
+	maxLength, err := getLongTextMaxLength(options)
+	if err != nil {
+		return false
+	}
+	return maxLength == 0 || utf8.RuneCountInString(response) <= maxLength
+}
+
+// validateNumericRangeResponse validates the numeric response to be in the range and on a step of the range
+func validateNumericRangeResponse(options map[string]interface{}, response string) bool {
+	min, max, step, err := getNumericRange(options)
+	if err != nil {
+		return false
+	}
+	value, err := strconv.ParseFloat(response, 64)
+	if err != nil || math.IsNaN(value) || value < min || value > max {
+		return false
+	}
+	steps := (value - min) / step
+	return math.Abs(steps-math.Round(steps)) < numericRangeTolerance
+}
+
+// validateLikertScaleResponse validates the response to be a point of the likert scale
+func validateLikertScaleResponse(options map[string]interface{}, response string) bool {
+	labels, err := getLikertScaleLabels(options)
+	if err != nil {
+		return false
+	}
+	point, err := strconv.Atoi(response)
+	return err == nil && point >= 1 && point <= len(labels)
+}
+
+// validateRankingResponse validates the response to rank each of the values of the options exactly once
+func validateRankingResponse(options map[string]interface{}, response string) bool {
+	ids, err := getOptionValueIDs(options, 2)
+	if err != nil {
+		return false
+	}
+	responseList := GetQuestionResponseList(response)
+	if len(responseList) != len(ids) {
+		return false
+	}
+	rankedIDs := map[float64]bool{}
+	for _, rankedResponse := range responseList {
+		id, err := strconv.ParseFloat(rankedResponse, 64)
+		if err != nil || !ids[id] || rankedIDs[id] {
+			return false
+		}
+		rankedIDs[id] = true
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/iReflect/reflect-app/db/models/fields"
+)
+
+func TestQuestionOptions(t *testing.T) {
+	testCases := []struct {
+		questionType QuestionType
+		options      string
+		isValid      bool
+	}{
+		{MultiChoiceType, `{"values": [{"id": 1}, {"id": 2}]}`, true},
+		{MultiChoiceType, `{}`, false},
+		{GradingType, `{"values": [{"id": 1}, {"id": 1}]}`, false},
+		{BooleanType, `{"values": [{"id": 1, "score": 1}, {"id": 2, "score": 0}]}`, true},
+		{BooleanType, `{"values": [{"id": 1}, {"id": 2}]}`, false},
+		{BooleanType, `{"values": [{"id": 1, "score": 1}, {"id": 2}]}`, false},
+		{BooleanType, `{"values": [{"id": 1, "score": 1}, {"id": 2, "score": 1}]}`, false},
+		{BooleanType, `{"values": [{"id": 1, "score": 1}]}`, false},
+		{LongTextType, `{}`, true},
+		{LongTextType, `{"maxLength": 100}`, true},
+		{LongTextType, `{"maxLength": -1}`, false},
+		{NumericRangeType, `{"min": 1, "max": 5, "step": 0.5}`, true},
+		{NumericRangeType, `{"min": 1, "max": 5}`, true},
+		{NumericRangeType, `{"min": 5, "max": 1}`, false},
+		{NumericRangeType, `{"min": 1, "max": 5, "step": 0}`, false},
+		{NumericRangeType, `{"max": 5}`, false},
+		{LikertScaleType, `{"labels": ["Disagree", "Neutral", "Agree"]}`, true},
+		{LikertScaleType, `{"labels": ["Agree"]}`, false},
+		{LikertScaleType, `{"labels": ["Disagree", ""]}`, false},
+		{RankingType, `{"values": [{"id": 1}, {"id": 2}]}`, true},
+		{RankingType, `{"values": [{"id": 1}]}`, false},
+		{QuestionType(100), `{}`, false},
+	}
+	for _, testCase := range testCases {
+		question := Question{Type: testCase.questionType, Options: fields.JSONB(testCase.options)}
+		if err := question.ValidateOptions(); (err == nil) != testCase.isValid {
+			t.Errorf("Expected the validity of type %d options %s to be %t, got the error %v",
+				int(testCase.questionType), testCase.options, testCase.isValid, err)
+		}
+	}
+}
+
+func TestExistingBooleanQuestionOptions(t *testing.T) {
+	// The existing questions without any scores can still be edited, but the scores added to them are validated
+	testCases := []struct {
+		options string
+		isValid bool
+	}{
+		{`{"values": [{"id": 1}, {"id": 2}]}`, true},
+		{`{"values": [{"id": 1, "score": 1}, {"id": 2, "score": 0}]}`, true},
+		{`{"values": [{"id": 1, "score": 1}, {"id": 2}]}`, false},
+		{`{"values": [{"id": 1, "score": "high"}, {"id": 2, "score": 0}]}`, false},
+		{`{"values": [{"id": 1}]}`, false},
+	}
+	for _, testCase := range testCases {
+		question := Question{Type: BooleanType, Options: fields.JSONB(testCase.options)}
+		question.ID = 1
+		if err := question.ValidateOptions(); (err == nil) != testCase.isValid {
+			t.Errorf("Expected the validity of the existing question options %s to be %t, got the error %v",
+				testCase.options, testCase.isValid, err)
+		}
+	}
+}
+
+func TestValidateQuestionResponse(t *testing.T) {
+	testCases := []struct {
+		questionType QuestionType
+		options      string
+		response     string
+		isValid      bool
+	}{
+		{MultiChoiceType, `{"values": [{"id": 1}, {"id": 2}]}`, "1,2", true},
+		{GradingType, `{"values": [{"id": 1}, {"id": 2}]}`, "1,2", false},
+		{LongTextType, `{"maxLength": 5}`, "Great", true},
+		{LongTextType, `{"maxLength": 5}`, "Great work", false},
+		{LongTextType, `{}`, "Great work, keep it up", true},
+		{NumericRangeType, `{"min": 1, "max": 5, "step": 0.5}`, "3.5", true},
+		{NumericRangeType, `{"min": 1, "max": 5, "step": 0.5}`, "3.2", false},
+		{NumericRangeType, `{"min": 1, "max": 5, "step": 0.5}`, "5.5", false},
+		{NumericRangeType, `{"min": 0, "max": 1, "step": 0.1}`, "0.3", true},
+		{NumericRangeType, `{"min": 1, "max": 5}`, "three", false},
+		{NumericRangeType, `{"min": 1, "max": 5}`, "", true},
+		{LikertScaleType, `{"labels": ["Disagree", "Neutral", "Agree"]}`, "3", true},
+		{LikertScaleType, `{"labels": ["Disagree", "Neutral", "Agree"]}`, "4", false},
+		{LikertScaleType, `{"labels": ["Disagree", "Neutral", "Agree"]}`, "0", false},
+		{RankingType, `{"values": [{"id": 1}, {"id": 2}, {"id": 3}]}`, "3,1,2", true},
+		{RankingType, `{"values": [{"id": 1}, {"id": 2}, {"id": 3}]}`, "3,1", false},
+		{RankingType, `{"values": [{"id": 1}, {"id": 2}, {"id": 3}]}`, "3,1,1", false},
+		{RankingType, `{"values": [{"id": 1}, {"id": 2}, {"id": 3}]}`, "3,1,4", false},
+	}
+	for _, testCase := range testCases {
+		question := Question{Type: testCase.questionType, Options: fields.JSONB(testCase.options)}
+		if isValid := question.ValidateQuestionResponse(testCase.response); isValid != testCase.isValid {
+			t.Errorf("Expected the validity of type %d response %q with options %s to be %t",
+				int(testCase.questionType), testCase.response, testCase.options, testCase.isValid)
+		}
+	}
+}
@@ -46,12 +46,18 @@ func ValidateResponseRegex(questionResponse string) bool {
 
 // BeforeSave ...
 func (questionResponse *QuestionResponse) BeforeSave(db *gorm.DB) (err error) {
-	// Check if the question response is valid
-	var question Question
-	if questionResponse.Question.ID == 0 {
-		db.Where("deleted_at IS NULL").Where("id = ?", questionResponse.QuestionID).First(&question)
-	} else {
-		question = questionResponse.Question
+	// Check if the question response is valid against the question of the feedback form version of the feedback
+	var version FeedbackFormVersion
+	db.Model(&FeedbackFormVersion{}).
+		Where("feedback_form_versions.deleted_at IS NULL").
+		Where("id = (?)", db.Model(&Feedback{}).
+			Where("id = ?", questionResponse.FeedbackID).
+			Select("feedback_form_version_id").
+			QueryExpr()).
+		First(&version)
+	question, exists := version.GetQuestion(questionResponse.QuestionID)
+	if !exists {
+		return errors.New("question is not in the feedback form version of the feedback")
 	}
 	if isValid := question.ValidateQuestionResponse(questionResponse.Response); !isValid {
 		err = errors.New("invalid question response")
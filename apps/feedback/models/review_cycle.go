@@ -0,0 +1,134 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/qor/admin"
+
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
+)
+
+// ReviewRelationship ...
+type ReviewRelationship int8
+
+// ReviewRelationship ...
+const (
+	PeerReviewRelationship ReviewRelationship = iota
+	ManagerReviewRelationship
+	DirectReportReviewRelationship
+)
+
+// ReviewRelationshipValues ...
+var ReviewRelationshipValues = [...]string{
+	"Peer",
+	"Manager",
+	"Direct Report",
+}
+
+// String ...
+func (relationship ReviewRelationship) String() string {
+	return ReviewRelationshipValues[relationship]
+}
+
+// IsAnonymous checks if the reviewers of the relationship are kept anonymous, their results are shown only in
+// aggregate, after the review cycle expires and only if enough of them have responded
+func (relationship ReviewRelationship) IsAnonymous() bool {
+	return relationship != ManagerReviewRelationship
+}
+
+// GetAnonymousReviewRelationships returns the relationships whose reviewers are kept anonymous
+func GetAnonymousReviewRelationships() []ReviewRelationship {
+	var relationships []ReviewRelationship
+	for index := range ReviewRelationshipValues {
+		if relationship := ReviewRelationship(index); relationship.IsAnonymous() {
+			relationships = append(relationships, relationship)
+		}
+	}
+	return relationships
+}
+
+// GetReviewRelationship returns the relationship of the reviewer to the subject of the review from their roles in the
+// team, the reviewers who are not members of the team are taken as the peers
+func GetReviewRelationship(subjectRole userModels.TeamRole, reviewerRole *userModels.TeamRole) ReviewRelationship {
+	isManager := func(role userModels.TeamRole) bool {
+		return role == userModels.ManagerRole || role == userModels.AdminRole
+	}
+	switch {
+	case reviewerRole == nil || isManager(subjectRole) == isManager(*reviewerRole):
+		return PeerReviewRelationship
+	case isManager(*reviewerRole):
+		return ManagerReviewRelationship
+	default:
+		return DirectReportReviewRelationship
+	}
+}
+
+// ReviewNominationStatus ...
+type ReviewNominationStatus int8
+
+// ReviewNominationStatus ...
+const (
+	PendingReviewNomination ReviewNominationStatus = iota
+	ApprovedReviewNomination
+	RejectedReviewNomination
+)
+
+// ReviewNominationStatusValues ...
+var ReviewNominationStatusValues = [...]string{
+	"Pending",
+	"Approved",
+	"Rejected",
+}
+
+// String ...
+func (status ReviewNominationStatus) String() string {
+	return ReviewNominationStatusValues[status]
+}
+
+// ReviewCycle represent a 360 degree feedback cycle of a team, in which the members nominate the reviewers of their
+// feedback, the managers approve the nominations and a feedback is requested from each of the approved reviewers
+type ReviewCycle struct {
+	gorm.Model
+	Title              string `gorm:"type:varchar(255); not null"`
+	Team               userModels.Team
+	TeamID             uint `gorm:"not null"`
+	FeedbackForm       FeedbackForm
+	FeedbackFormID     uint      `gorm:"not null"`
+	DurationStart      time.Time `gorm:"not null"`
+	DurationEnd        time.Time `gorm:"not null"`
+	NominationEndAt    time.Time `gorm:"not null"`
+	ExpireAt           time.Time `gorm:"not null"`
+	AnonymityThreshold uint      `gorm:"default:3; not null"` // Min responses of an anonymous relationship to show
+}
+
+// IsResultHidden checks if the results of the reviewers of the relationship are hidden at the time. The results of an
+// anonymous relationship are hidden until the review cycle expires, since the score of each reviewer could be told
+// from the change of the aggregate as they respond, and if less than the anonymity threshold of them have responded.
+func (reviewCycle ReviewCycle) IsResultHidden(relationship ReviewRelationship, responseCount int, now time.Time) bool {
+	return relationship.IsAnonymous() &&
+		(now.Before(reviewCycle.ExpireAt) || uint(responseCount) < reviewCycle.AnonymityThreshold)
+}
+
+// ReviewNomination represent a reviewer nominated for the feedback of a member in a review cycle
+type ReviewNomination struct {
+	gorm.Model
+	ReviewCycle           ReviewCycle
+	ReviewCycleID         uint `gorm:"not null; unique_index:idx_review_nomination"`
+	ForUserProfile        userModels.UserProfile
+	ForUserProfileID      uint `gorm:"not null; unique_index:idx_review_nomination"`
+	ReviewerUserProfile   userModels.UserProfile
+	ReviewerUserProfileID uint                   `gorm:"not null; unique_index:idx_review_nomination"`
+	Relationship          ReviewRelationship     `gorm:"default:0; not null"`
+	Status                ReviewNominationStatus `gorm:"default:0; not null"`
+	ReviewedBy            *userModels.User
+	ReviewedByID          *uint
+	Feedback              *Feedback
+	FeedbackID            *uint
+}
+
+// RegisterReviewCycleToAdmin ...
+func RegisterReviewCycleToAdmin(Admin *admin.Admin, config admin.Config) {
+	Admin.AddResource(&ReviewCycle{}, &config)
+	Admin.AddResource(&ReviewNomination{}, &config)
+}
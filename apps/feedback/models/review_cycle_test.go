@@ -0,0 +1,67 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
+)
+
+func TestGetReviewRelationship(t *testing.T) {
+	member, manager, admin := userModels.MemberRole, userModels.ManagerRole, userModels.AdminRole
+	testCases := []struct {
+		subjectRole  userModels.TeamRole
+		reviewerRole *userModels.TeamRole
+		relationship ReviewRelationship
+	}{
+		{member, &member, PeerReviewRelationship},
+		{member, &manager, ManagerReviewRelationship},
+		{member, &admin, ManagerReviewRelationship},
+		{manager, &member, DirectReportReviewRelationship},
+		{manager, &admin, PeerReviewRelationship},
+		{admin, &manager, PeerReviewRelationship},
+		// The reviewers who are not members of the team are the peers
+		{member, nil, PeerReviewRelationship},
+		{manager, nil, PeerReviewRelationship},
+	}
+	for _, testCase := range testCases {
+		if relationship := GetReviewRelationship(testCase.subjectRole, testCase.reviewerRole); relationship !=
+			testCase.relationship {
+			t.Errorf("Expected the relationship of the reviewer %v of the %s to be %s, got %s",
+				testCase.reviewerRole, testCase.subjectRole, testCase.relationship, relationship)
+		}
+	}
+
+	expected := []ReviewRelationship{PeerReviewRelationship, DirectReportReviewRelationship}
+	if relationships := GetAnonymousReviewRelationships(); !reflect.DeepEqual(relationships, expected) {
+		t.Errorf("Expected the anonymous relationships %v, got %v", expected, relationships)
+	}
+}
+
+func TestReviewCycleIsResultHidden(t *testing.T) {
+	expireAt := time.Date(2018, 6, 30, 0, 0, 0, 0, time.UTC)
+	reviewCycle := ReviewCycle{ExpireAt: expireAt, AnonymityThreshold: 2}
+	beforeExpiry, afterExpiry := expireAt.Add(-time.Hour), expireAt.Add(time.Hour)
+	testCases := []struct {
+		relationship  ReviewRelationship
+		responseCount int
+		now           time.Time
+		isHidden      bool
+	}{
+		{PeerReviewRelationship, 3, beforeExpiry, true},
+		{PeerReviewRelationship, 3, afterExpiry, false},
+		{PeerReviewRelationship, 2, afterExpiry, false},
+		{PeerReviewRelationship, 1, afterExpiry, true},
+		{DirectReportReviewRelationship, 1, afterExpiry, true},
+		{ManagerReviewRelationship, 1, beforeExpiry, false},
+		{ManagerReviewRelationship, 0, afterExpiry, false},
+	}
+	for _, testCase := range testCases {
+		if isHidden := reviewCycle.IsResultHidden(testCase.relationship, testCase.responseCount,
+			testCase.now); isHidden != testCase.isHidden {
+			t.Errorf("Expected the %s results with %d responses at %s to be hidden %t", testCase.relationship,
+				testCase.responseCount, testCase.now, testCase.isHidden)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -8,6 +10,14 @@ import (
 	userModels "github.com/iReflect/reflect-app/apps/user/models"
 )
 
+// Units of the schedule period
+const (
+	DayPeriodUnit   = "day"
+	WeekPeriodUnit  = "week"
+	MonthPeriodUnit = "month"
+	YearPeriodUnit  = "year"
+)
+
 // Schedule at which feedback events would be created for a team
 type Schedule struct {
 	gorm.Model
@@ -21,3 +31,44 @@ type Schedule struct {
 	NextEventAt   time.Time `gorm:"not null"`
 	Active        bool      `gorm:"default:true; not null"`
 }
+
+// AddPeriod returns the time after the period of the schedule from the given time, the units are accepted in the
+// singular or the plural, in any case
+func (schedule Schedule) AddPeriod(from time.Time, periods int) (time.Time, error) {
+	if schedule.PeriodValue == 0 {
+		return time.Time{}, fmt.Errorf("schedule %d has no period", schedule.ID)
+	}
+	value := int(schedule.PeriodValue) * periods
+	switch strings.TrimSuffix(strings.ToLower(strings.TrimSpace(schedule.PeriodUnit)), "s") {
+	case DayPeriodUnit:
+		return from.AddDate(0, 0, value), nil
+	case WeekPeriodUnit:
+		return from.AddDate(0, 0, 7*value), nil
+	case MonthPeriodUnit:
+		return from.AddDate(0, value, 0), nil
+	case YearPeriodUnit:
+		return from.AddDate(value, 0, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("schedule %d has an unknown period unit %s", schedule.ID, schedule.PeriodUnit)
+}
+
+// GetEventDuration returns the duration covered by the event of the schedule at the given time, which is the period
+// ending the offset days before the event
+func (schedule Schedule) GetEventDuration(eventAt time.Time) (start time.Time, end time.Time, err error) {
+	end = eventAt.AddDate(0, 0, -int(schedule.PeriodOffset))
+	start, err = schedule.AddPeriod(end, -1)
+	return start, end, err
+}
+
+// GetNextEventTime returns the time of the first event of the schedule after the given time, the events missed in
+// between are skipped
+func (schedule Schedule) GetNextEventTime(after time.Time) (time.Time, error) {
+	nextEventAt := schedule.NextEventAt
+	for !nextEventAt.After(after) {
+		var err error
+		if nextEventAt, err = schedule.AddPeriod(nextEventAt, 1); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return nextEventAt, nil
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduleEvents(t *testing.T) {
+	eventAt := time.Date(2018, 4, 1, 9, 0, 0, 0, time.UTC)
+	schedule := Schedule{PeriodValue: 3, PeriodUnit: "Months", PeriodOffset: 1, NextEventAt: eventAt}
+
+	start, end, err := schedule.GetEventDuration(eventAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := time.Date(2018, 3, 31, 9, 0, 0, 0, time.UTC); !end.Equal(expected) {
+		t.Errorf("Expected the duration to end at %s, got %s", expected, end)
+	}
+	if expected := time.Date(2017, 12, 31, 9, 0, 0, 0, time.UTC); !start.Equal(expected) {
+		t.Errorf("Expected the duration to start at %s, got %s", expected, start)
+	}
+
+	// The events missed in between are skipped
+	nextEventAt, err := schedule.GetNextEventTime(time.Date(2018, 8, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := time.Date(2018, 10, 1, 9, 0, 0, 0, time.UTC); !nextEventAt.Equal(expected) {
+		t.Errorf("Expected the next event at %s, got %s", expected, nextEventAt)
+	}
+
+	schedule.PeriodUnit = "fortnight"
+	if _, err = schedule.GetNextEventTime(eventAt); err == nil {
+		t.Errorf("Unknown period unit should fail")
+	}
+	schedule.PeriodUnit, schedule.PeriodValue = "week", 0
+	if _, err = schedule.GetNextEventTime(eventAt); err == nil {
+		t.Errorf("Schedule without a period should fail")
+	}
+}
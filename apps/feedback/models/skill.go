@@ -5,8 +5,7 @@ import (
 	"github.com/qor/admin"
 )
 
-// Skill represent the skill comprised by category
-// TODO Add support for versioning and making it non-editable
+// Skill represent the skill comprised by category, the published feedback forms keep a snapshot of their skills
 type Skill struct {
 	gorm.Model
 	Title        string `gorm:"type:varchar(255); not null"`
@@ -6,4 +6,5 @@ type CategoryDetailSerializer struct {
 	Title       string
 	Description string
 	Skills      map[uint]SkillDetailSerializer
+	Score       *float64
 }
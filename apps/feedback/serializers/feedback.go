@@ -25,6 +25,11 @@ type FeedbackDetailSerializer struct {
 	Status         models.FeedbackStatus
 	FeedbackFormID uint
 	Categories     map[uint]CategoryDetailSerializer
+	// The version of the feedback form the feedback is issued with
+	FeedbackFormVersionID uint
+	FeedbackFormVersion   uint
+	// The weighted score of the submitted feedback, between 0 and 1
+	Score *float64
 }
 
 // FeedbackResponseData is the type of question response which is provided in the feedback form submit API
@@ -0,0 +1,32 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/feedback/models"
+)
+
+// FeedbackFormVersionSerializer returns a published version of a feedback form
+type FeedbackFormVersionSerializer struct {
+	ID             uint
+	FeedbackFormID uint
+	Version        uint
+	Title          string
+	Description    string
+	PublishedAt    time.Time
+}
+
+// FeedbackFormVersionDetailSerializer returns a published version of a feedback form with its contents
+type FeedbackFormVersionDetailSerializer struct {
+	FeedbackFormVersionSerializer
+	Contents []models.FeedbackFormContentSnapshot
+}
+
+// FeedbackFormDiffSerializer returns the changes between two versions of a feedback form, the ToVersion is nil
+// for the unpublished draft of the feedback form
+type FeedbackFormDiffSerializer struct {
+	FeedbackFormID uint
+	FromVersion    uint
+	ToVersion      *uint
+	Changes        []models.FeedbackFormChange
+}
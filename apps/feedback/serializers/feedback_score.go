@@ -0,0 +1,43 @@
+package serializers
+
+import (
+	"time"
+)
+
+// CategoryScoreSerializer returns the score of a category of a feedback
+type CategoryScoreSerializer struct {
+	ID    uint
+	Title string
+	Score *float64
+}
+
+// SkillScoreSerializer returns the score of a skill of a feedback
+type SkillScoreSerializer struct {
+	ID           uint
+	CategoryID   uint
+	Title        string
+	DisplayTitle string
+	Score        *float64
+}
+
+// FeedbackScoreSerializer returns the scores of a submitted feedback
+type FeedbackScoreSerializer struct {
+	FeedbackID          uint
+	Title               string
+	TeamID              uint
+	ByUserProfileID     uint
+	FeedbackFormID      uint
+	FeedbackFormVersion uint
+	DurationStart       time.Time
+	DurationEnd         time.Time
+	SubmittedAt         *time.Time
+	Score               *float64
+	Categories          []CategoryScoreSerializer
+	Skills              []SkillScoreSerializer
+}
+
+// UserScoreHistorySerializer returns the scores of the submitted feedbacks of a user, in the order of their durations
+type UserScoreHistorySerializer struct {
+	UserID    uint
+	Feedbacks []FeedbackScoreSerializer
+}
@@ -2,7 +2,8 @@ package serializers
 
 import "github.com/iReflect/reflect-app/apps/feedback/models"
 
-// QuestionResponseDetailSerializer returns the question response for a particular question
+// QuestionResponseDetailSerializer returns the question response for a particular question, the options are the values
+// of the choice based questions and all the options of the other questions
 type QuestionResponseDetailSerializer struct {
 	ID         uint
 	Text       string
@@ -12,10 +13,11 @@ type QuestionResponseDetailSerializer struct {
 	ResponseID uint
 	Response   string
 	Comment    string
+	Score      *float64
 }
 
 // QuestionResponseSerializer returns the question response
 type QuestionResponseSerializer struct {
-	Response string `json:"response" binding:"is_valid_question_response"`
+	Response string `json:"response"`
 	Comment  string `json:"comment"`
 }
@@ -0,0 +1,67 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/feedback/models"
+)
+
+// ReviewCycleSerializer returns a review cycle
+type ReviewCycleSerializer struct {
+	ID                 uint
+	Title              string
+	TeamID             uint
+	FeedbackFormID     uint
+	DurationStart      time.Time
+	DurationEnd        time.Time
+	NominationEndAt    time.Time
+	ExpireAt           time.Time
+	AnonymityThreshold uint
+}
+
+// ReviewNominationSerializer returns a nomination of a review cycle
+type ReviewNominationSerializer struct {
+	ID                    uint
+	ReviewCycleID         uint
+	ForUserID             uint
+	ForUserProfileID      uint
+	ReviewerUserID        uint
+	ReviewerUserProfileID uint
+	Relationship          models.ReviewRelationship
+	Status                models.ReviewNominationStatus
+	ReviewedByID          *uint
+	FeedbackID            *uint
+}
+
+// NominateReviewersSerializer is the list of the users nominated as the reviewers by the subject of the feedback, the
+// relationships of the reviewers are derived from their roles in the team
+type NominateReviewersSerializer struct {
+	ReviewerIDs []uint `json:"reviewerIDs" binding:"required"`
+}
+
+// ReviewNominationsSerializer is the nominations approved and rejected by the manager
+type ReviewNominationsSerializer struct {
+	ApprovedIDs []uint `json:"approvedIDs"`
+	RejectedIDs []uint `json:"rejectedIDs"`
+}
+
+// ReviewGroupResultSerializer returns the aggregated scores of the reviewers of a relationship, the scores of the
+// anonymous reviewers are hidden until the review cycle expires, and if less than the anonymity threshold of them have
+// responded
+type ReviewGroupResultSerializer struct {
+	Relationship  string
+	ReviewerCount int
+	ResponseCount int
+	IsHidden      bool
+	Score         *float64
+	Categories    []CategoryScoreSerializer
+	Skills        []SkillScoreSerializer
+}
+
+// ReviewResultSerializer returns the aggregated results of the review of a user in a review cycle
+type ReviewResultSerializer struct {
+	ReviewCycleID      uint
+	ForUserID          uint
+	AnonymityThreshold uint
+	Groups             []ReviewGroupResultSerializer
+}
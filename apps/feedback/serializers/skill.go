@@ -8,4 +8,5 @@ type SkillDetailSerializer struct {
 	Description  string
 	Weight       int
 	Questions    []QuestionResponseDetailSerializer
+	Score        *float64
 }
@@ -26,9 +26,4 @@ func (feedbackValidator FeedbackValidators) Register() {
 		IsAllQuestionPresent(feedbackValidator.DB)); err != nil {
 		fmt.Println(err.Error())
 	}
-
-	if err := validatorEngine.RegisterValidation("is_valid_question_response",
-		IsValidQuestionResponse); err != nil {
-		fmt.Println(err.Error())
-	}
 }
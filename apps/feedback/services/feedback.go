@@ -10,6 +10,7 @@ import (
 	feedbackModels "github.com/iReflect/reflect-app/apps/feedback/models"
 	feedbackSerializers "github.com/iReflect/reflect-app/apps/feedback/serializers"
 	userModels "github.com/iReflect/reflect-app/apps/user/models"
+	"github.com/iReflect/reflect-app/libs/utils"
 )
 
 //FeedbackService ...
@@ -28,7 +29,8 @@ func (service FeedbackService) Get(feedbackID string, userID uint) (feedback *fe
 		Where("deleted_at IS NULL").
 		Where("by_user_profile_id in (?)",
 			db.Model(&userModels.UserProfile{}).Where("user_id = ?", userID).Select("id").QueryExpr()).
-		Select("id, title, duration_start,duration_end, submitted_at, expire_at, status, feedback_form_id").
+		Select("id, title, duration_start,duration_end, submitted_at, expire_at, status, feedback_form_id, " +
+			"feedback_form_version_id").
 		Scan(&feedback).Error; err != nil {
 		return nil, err
 	}
@@ -48,7 +50,8 @@ func (service FeedbackService) TeamGet(feedbackID string, userID uint) (
 		Where("id = ?", feedbackID).
 		Where("deleted_at IS NULL").
 		Where("id in (?)", feedbackIds).
-		Select("id, title, duration_start,duration_end, submitted_at, expire_at, status, feedback_form_id").
+		Select("id, title, duration_start,duration_end, submitted_at, expire_at, status, feedback_form_id, " +
+			"feedback_form_version_id").
 		Scan(&feedback).Error; err != nil {
 		return nil, err
 	}
@@ -114,20 +117,23 @@ func (service FeedbackService) getFeedbackDetail(feedback *feedbackSerializers.F
 	*feedbackSerializers.FeedbackDetailSerializer,
 	error) {
 	db := service.DB
-	var feedBackFormContents []feedbackModels.FeedbackFormContent
+	var feedbackFormVersion feedbackModels.FeedbackFormVersion
 
-	if err := db.Model(&feedbackModels.FeedbackFormContent{}).
+	// The feedback is shown with the contents of the feedback form version it is issued with
+	if err := db.Model(&feedbackModels.FeedbackFormVersion{}).
 		Where("deleted_at IS NULL").
-		Preload("Skill").
-		Preload("Skill.Questions").
-		Preload("Category").
-		Group("id, category_id").
-		Where("feedback_form_id in (?)", feedback.FeedbackFormID).
-		Find(&feedBackFormContents).Error; err != nil {
+		Where("id = ?", feedback.FeedbackFormVersionID).
+		First(&feedbackFormVersion).Error; err != nil {
 		return nil, err
 	}
+	feedBackFormContents, err := feedbackFormVersion.GetContents()
+	if err != nil {
+		return nil, err
+	}
+	feedback.FeedbackFormVersion = feedbackFormVersion.Version
 
 	categories := make(map[uint]feedbackSerializers.CategoryDetailSerializer)
+	responses := map[uint]string{}
 
 	for _, feedBackFormContent := range feedBackFormContents {
 		var questionResponses []feedbackSerializers.QuestionResponseDetailSerializer
@@ -140,18 +146,24 @@ func (service FeedbackService) getFeedbackDetail(feedback *feedbackSerializers.F
 					FeedbackFormContentID: feedBackFormContent.ID,
 				}).
 				FirstOrCreate(&questionResponse)
-			questionOptions := question.GetOptions()
+			responses[question.ID] = questionResponse.Response
+			questionOptions := question.Question(feedBackFormContent.Skill.ID).GetOptions()
 			response := questionResponse.Response
 			defaultValue, exists := questionOptions["defaultValue"].(string)
 			if feedback.Status != feedbackModels.SubmittedFeedback && exists && response == "" {
 				response = defaultValue
 			}
+			// The choice based questions are answered from their values, the others need all their options
+			options, hasValues := questionOptions["values"]
+			if !hasValues {
+				options = questionOptions
+			}
 			questionResponses = append(questionResponses,
 				feedbackSerializers.QuestionResponseDetailSerializer{
 					ID:         question.ID,
 					Type:       question.Type,
 					Text:       question.Text,
-					Options:    questionOptions["values"],
+					Options:    options,
 					Weight:     question.Weight,
 					ResponseID: questionResponse.ID,
 					Response:   response,
@@ -160,7 +172,7 @@ func (service FeedbackService) getFeedbackDetail(feedback *feedbackSerializers.F
 		}
 
 		skill := feedbackSerializers.SkillDetailSerializer{
-			ID:           feedBackFormContent.SkillID,
+			ID:           feedBackFormContent.Skill.ID,
 			Title:        feedBackFormContent.Skill.Title,
 			DisplayTitle: feedBackFormContent.Skill.DisplayTitle,
 			Description:  feedBackFormContent.Skill.Description,
@@ -168,11 +180,11 @@ func (service FeedbackService) getFeedbackDetail(feedback *feedbackSerializers.F
 			Questions:    questionResponses,
 		}
 
-		categoryID := feedBackFormContent.CategoryID
+		categoryID := feedBackFormContent.Category.ID
 		_, exists := categories[categoryID]
 		if exists == false {
 			skills := make(map[uint]feedbackSerializers.SkillDetailSerializer)
-			skills[feedBackFormContent.SkillID] = skill
+			skills[feedBackFormContent.Skill.ID] = skill
 
 			categories[categoryID] = feedbackSerializers.CategoryDetailSerializer{
 				ID:          feedBackFormContent.Category.ID,
@@ -181,13 +193,142 @@ func (service FeedbackService) getFeedbackDetail(feedback *feedbackSerializers.F
 				Skills:      skills,
 			}
 		} else {
-			categories[categoryID].Skills[feedBackFormContent.SkillID] = skill
+			categories[categoryID].Skills[feedBackFormContent.Skill.ID] = skill
 		}
 	}
 	feedback.Categories = categories
+
+	if feedback.Status == feedbackModels.SubmittedFeedback {
+		setFeedbackDetailScores(feedback, feedbackModels.GetFeedbackScore(feedBackFormContents, responses))
+	}
 	return feedback, nil
 }
 
+// setFeedbackDetailScores sets the scores of the feedback, its categories, skills and questions
+func setFeedbackDetailScores(feedback *feedbackSerializers.FeedbackDetailSerializer, score feedbackModels.FeedbackScore) {
+	feedback.Score = score.Overall
+	for categoryID, category := range feedback.Categories {
+		category.Score = score.Categories[categoryID]
+		for skillID, skill := range category.Skills {
+			skill.Score = score.Skills[skillID]
+			for index := range skill.Questions {
+				skill.Questions[index].Score = score.Questions[skill.Questions[index].ID]
+			}
+			category.Skills[skillID] = skill
+		}
+		feedback.Categories[categoryID] = category
+	}
+}
+
+// getFeedbackResponses returns the responses of the feedbacks, mapped by the feedback ids and the question ids
+func getFeedbackResponses(db *gorm.DB, feedbackIDs []uint) (map[uint]map[uint]string, error) {
+	var questionResponses []feedbackModels.QuestionResponse
+	if err := db.Model(&feedbackModels.QuestionResponse{}).
+		Where("question_responses.deleted_at IS NULL").
+		Where("feedback_id in (?)", feedbackIDs).
+		Find(&questionResponses).Error; err != nil {
+		return nil, err
+	}
+	feedbackResponses := map[uint]map[uint]string{}
+	for _, questionResponse := range questionResponses {
+		if _, exists := feedbackResponses[questionResponse.FeedbackID]; !exists {
+			feedbackResponses[questionResponse.FeedbackID] = map[uint]string{}
+		}
+		feedbackResponses[questionResponse.FeedbackID][questionResponse.QuestionID] = questionResponse.Response
+	}
+	return feedbackResponses, nil
+}
+
+// TeamScoreHistory returns the scores of the submitted feedbacks of the user, which are visible to the requesting user,
+// so that the growth of the user can be followed across the feedback cycles
+func (service FeedbackService) TeamScoreHistory(forUserID string, userID uint) (
+	*feedbackSerializers.UserScoreHistorySerializer, int, error) {
+	db := service.DB
+	var forUser userModels.User
+	if err := db.Model(&userModels.User{}).
+		Where("users.deleted_at IS NULL").
+		Where("id = ?", forUserID).
+		First(&forUser).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("user not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the score history")
+	}
+
+	var feedbacks []feedbackModels.Feedback
+	if err := db.Model(&feedbackModels.Feedback{}).
+		Where("feedbacks.deleted_at IS NULL").
+		Where("feedbacks.id in (?)", service.getTeamFeedbackIDs(userID)).
+		Where("status = ?", feedbackModels.SubmittedFeedback).
+		Where("for_user_profile_id in (?)",
+			db.Model(&userModels.UserProfile{}).Where("user_id = ?", forUser.ID).Select("id").QueryExpr()).
+		Preload("FeedbackFormVersion").
+		Order("duration_end, submitted_at, id").
+		Find(&feedbacks).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the score history")
+	}
+
+	var feedbackIDs []uint
+	for _, feedback := range feedbacks {
+		feedbackIDs = append(feedbackIDs, feedback.ID)
+	}
+	feedbackResponses, err := getFeedbackResponses(db, feedbackIDs)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the score history")
+	}
+
+	history := feedbackSerializers.UserScoreHistorySerializer{
+		UserID:    forUser.ID,
+		Feedbacks: []feedbackSerializers.FeedbackScoreSerializer{},
+	}
+	for _, feedback := range feedbacks {
+		contents, err := feedback.FeedbackFormVersion.GetContents()
+		if err != nil {
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to get the score history")
+		}
+		score := feedbackModels.GetFeedbackScore(contents, feedbackResponses[feedback.ID])
+
+		feedbackScore := feedbackSerializers.FeedbackScoreSerializer{
+			FeedbackID:          feedback.ID,
+			Title:               feedback.Title,
+			TeamID:              feedback.TeamID,
+			ByUserProfileID:     feedback.ByUserProfileID,
+			FeedbackFormID:      feedback.FeedbackFormID,
+			FeedbackFormVersion: feedback.FeedbackFormVersion.Version,
+			DurationStart:       feedback.DurationStart,
+			DurationEnd:         feedback.DurationEnd,
+			SubmittedAt:         feedback.SubmittedAt,
+			Score:               score.Overall,
+			Categories:          []feedbackSerializers.CategoryScoreSerializer{},
+			Skills:              []feedbackSerializers.SkillScoreSerializer{},
+		}
+		addedCategories := map[uint]bool{}
+		for _, content := range contents {
+			if !addedCategories[content.Category.ID] {
+				addedCategories[content.Category.ID] = true
+				feedbackScore.Categories = append(feedbackScore.Categories, feedbackSerializers.CategoryScoreSerializer{
+					ID:    content.Category.ID,
+					Title: content.Category.Title,
+					Score: score.Categories[content.Category.ID],
+				})
+			}
+			feedbackScore.Skills = append(feedbackScore.Skills, feedbackSerializers.SkillScoreSerializer{
+				ID:           content.Skill.ID,
+				CategoryID:   content.Category.ID,
+				Title:        content.Skill.Title,
+				DisplayTitle: content.Skill.DisplayTitle,
+				Score:        score.Skills[content.Skill.ID],
+			})
+		}
+		history.Feedbacks = append(history.Feedbacks, feedbackScore)
+	}
+	return &history, http.StatusOK, nil
+}
+
 // Put feedback data
 func (service FeedbackService) Put(feedbackID string, userID uint,
 	feedBackResponseData feedbackSerializers.FeedbackResponseSerializer) (code int, err error) {
@@ -247,9 +388,12 @@ func (service FeedbackService) Put(feedbackID string, userID uint,
 	return http.StatusNoContent, nil
 }
 
+// getTeamFeedbackIDs returns the ids of the feedbacks visible to the user, the feedbacks of the members of the teams
+// managed by the user and the feedbacks given by the user. The feedbacks of the anonymous reviewers of the review
+// cycles are visible only to the reviewers, the others see them only in the aggregated results of the review cycles.
 func (service FeedbackService) getTeamFeedbackIDs(userID uint) []uint {
 	db := service.DB
-	filterQuery := `
+	managedQuery := `
         SELECT id
         FROM feedbacks
         WHERE (team_id, for_user_profile_id) IN (SELECT
@@ -260,7 +404,7 @@ func (service FeedbackService) getTeamFeedbackIDs(userID uint) []uint {
                                                         ON ut.user_id = up.user_id
                                                 WHERE ut.role = 0 AND ut.team_id IN (SELECT team_id
                                                                                     FROM user_teams
-                                                                                    WHERE user_id = 1 AND role = 1)
+                                                                                    WHERE user_id = ? AND role = 1)
 												AND ut.deleted_at IS NULL AND up.deleted_at IS NULL)
 		AND feedbacks.deleted_at IS NULL
         UNION
@@ -274,23 +418,56 @@ func (service FeedbackService) getTeamFeedbackIDs(userID uint) []uint {
                                                         ON ut.user_id = up.user_id
                                                 WHERE ut.team_id IN (SELECT team_id
                                                                      FROM user_teams
-                                                                     WHERE user_id = 1 AND role = 2)
+                                                                     WHERE user_id = ? AND role = 2)
 												AND ut.deleted_at IS NULL AND up.deleted_at IS NULL)
 		AND feedbacks.deleted_at IS NULL
-        UNION
+    `
+	givenQuery := `
         SELECT id
         FROM feedbacks
-        WHERE by_user_profile_id IN (SELECT id FROM user_profiles WHERE user_id = 1) AND feedbacks.deleted_at IS NULL
+        WHERE by_user_profile_id IN (SELECT id FROM user_profiles WHERE user_id = ?) AND feedbacks.deleted_at IS NULL
     `
+	managedFeedbackIDs := getIDs(db, managedQuery, userID, userID)
+
+	var anonymousFeedbackIDs []uint
+	if len(managedFeedbackIDs) != 0 {
+		err := db.Model(&feedbackModels.ReviewNomination{}).
+			Where("review_nominations.deleted_at IS NULL").
+			Where("feedback_id IN (?)", managedFeedbackIDs).
+			Where("relationship IN (?)", feedbackModels.GetAnonymousReviewRelationships()).
+			Pluck("feedback_id", &anonymousFeedbackIDs).Error
+		if err != nil {
+			utils.LogToSentry(err)
+			return nil
+		}
+	}
+	isAnonymous := make(map[uint]bool)
+	for _, feedbackID := range anonymousFeedbackIDs {
+		isAnonymous[feedbackID] = true
+	}
+
 	var feedbackIds []uint
+	for _, feedbackID := range managedFeedbackIDs {
+		if !isAnonymous[feedbackID] {
+			feedbackIds = append(feedbackIds, feedbackID)
+		}
+	}
+	return append(feedbackIds, getIDs(db, givenQuery, userID)...)
+}
 
-	rows, _ := db.Raw(filterQuery, userID, userID, userID).Select("id").Rows()
+// getIDs returns the ids selected by the raw query
+func getIDs(db *gorm.DB, query string, values ...interface{}) []uint {
+	var ids []uint
+	rows, err := db.Raw(query, values...).Rows()
+	if err != nil {
+		utils.LogToSentry(err)
+		return ids
+	}
 	defer rows.Close()
 	for rows.Next() {
-		var feedbackID uint
-		rows.Scan(&feedbackID)
-		feedbackIds = append(feedbackIds, feedbackID)
+		var id uint
+		rows.Scan(&id)
+		ids = append(ids, id)
 	}
-	return feedbackIds
-
+	return ids
 }
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/jinzhu/gorm"
+
+	feedbackModels "github.com/iReflect/reflect-app/apps/feedback/models"
+	feedbackSerializers "github.com/iReflect/reflect-app/apps/feedback/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// FeedbackFormService ...
+type FeedbackFormService struct {
+	DB *gorm.DB
+}
+
+// ListVersions returns the published versions of the feedback form
+func (service FeedbackFormService) ListVersions(feedbackFormID string) (
+	[]feedbackSerializers.FeedbackFormVersionSerializer, int, error) {
+	db := service.DB
+	feedbackForm, status, err := service.getFeedbackForm(feedbackFormID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	versions := []feedbackSerializers.FeedbackFormVersionSerializer{}
+	err = db.Model(&feedbackModels.FeedbackFormVersion{}).
+		Where("feedback_form_versions.deleted_at IS NULL").
+		Where("feedback_form_id = ?", feedbackForm.ID).
+		Order("version DESC").
+		Scan(&versions).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the feedback form versions")
+	}
+	return versions, http.StatusOK, nil
+}
+
+// GetVersion returns the published version of the feedback form with its contents
+func (service FeedbackFormService) GetVersion(feedbackFormID string, versionNumber string) (
+	*feedbackSerializers.FeedbackFormVersionDetailSerializer, int, error) {
+	version, status, err := service.getVersion(feedbackFormID, versionNumber)
+	if err != nil {
+		return nil, status, err
+	}
+	contents, err := version.GetContents()
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the feedback form version")
+	}
+	return &feedbackSerializers.FeedbackFormVersionDetailSerializer{
+		FeedbackFormVersionSerializer: serializeFeedbackFormVersion(version),
+		Contents:                      contents,
+	}, http.StatusOK, nil
+}
+
+// Diff returns the changes from the "from" version to the "to" version of the feedback form. The "to" version
+// defaults to the unpublished draft of the feedback form, and the "from" version defaults to the version before
+// the "to" version, or the latest version in case of the draft.
+func (service FeedbackFormService) Diff(feedbackFormID string, fromVersion string, toVersion string) (
+	*feedbackSerializers.FeedbackFormDiffSerializer, int, error) {
+	db := service.DB
+	feedbackForm, status, err := service.getFeedbackForm(feedbackFormID)
+	if err != nil {
+		return nil, status, err
+	}
+	diff := feedbackSerializers.FeedbackFormDiffSerializer{FeedbackFormID: feedbackForm.ID}
+
+	var toContents []feedbackModels.FeedbackFormContentSnapshot
+	if toVersion == "" {
+		toContents, err = feedbackModels.GetFeedbackFormContentSnapshots(db, feedbackForm.ID)
+	} else {
+		var version *feedbackModels.FeedbackFormVersion
+		if version, status, err = service.getVersion(feedbackFormID, toVersion); err != nil {
+			return nil, status, err
+		}
+		diff.ToVersion = &version.Version
+		toContents, err = version.GetContents()
+	}
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the feedback form changes")
+	}
+
+	if fromVersion == "" {
+		if diff.ToVersion != nil {
+			fromVersion = strconv.Itoa(int(*diff.ToVersion) - 1)
+		} else if latestVersion, err := feedbackModels.GetLatestFeedbackFormVersion(db, feedbackForm.ID); err == nil {
+			fromVersion = strconv.Itoa(int(latestVersion.Version))
+		}
+	}
+
+	// The contents are compared with an empty form, if there is no earlier version
+	fromContents := []feedbackModels.FeedbackFormContentSnapshot{}
+	if fromVersion != "" && fromVersion != "0" {
+		var version *feedbackModels.FeedbackFormVersion
+		if version, status, err = service.getVersion(feedbackFormID, fromVersion); err != nil {
+			return nil, status, err
+		}
+		diff.FromVersion = version.Version
+		if fromContents, err = version.GetContents(); err != nil {
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to get the feedback form changes")
+		}
+	}
+
+	diff.Changes = feedbackModels.DiffFeedbackFormContents(fromContents, toContents)
+	return &diff, http.StatusOK, nil
+}
+
+// Publish publishes the current contents of the feedback form as its new version
+func (service FeedbackFormService) Publish(feedbackFormID string) (
+	*feedbackSerializers.FeedbackFormVersionSerializer, int, error) {
+	feedbackForm, status, err := service.getFeedbackForm(feedbackFormID)
+	if err != nil {
+		return nil, status, err
+	}
+	version, err := feedbackForm.Publish(service.DB)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	serializedVersion := serializeFeedbackFormVersion(version)
+	return &serializedVersion, http.StatusCreated, nil
+}
+
+// Archive archives the feedback form
+func (service FeedbackFormService) Archive(feedbackFormID string) (int, error) {
+	feedbackForm, status, err := service.getFeedbackForm(feedbackFormID)
+	if err != nil {
+		return status, err
+	}
+	if err = feedbackForm.Archive(service.DB); err != nil {
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to archive the feedback form")
+	}
+	return http.StatusNoContent, nil
+}
+
+func (service FeedbackFormService) getFeedbackForm(feedbackFormID string) (*feedbackModels.FeedbackForm, int, error) {
+	db := service.DB
+	var feedbackForm feedbackModels.FeedbackForm
+	err := db.Model(&feedbackModels.FeedbackForm{}).
+		Where("feedback_forms.deleted_at IS NULL").
+		Where("id = ?", feedbackFormID).
+		First(&feedbackForm).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("feedback form not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the feedback form")
+	}
+	return &feedbackForm, http.StatusOK, nil
+}
+
+func (service FeedbackFormService) getVersion(feedbackFormID string, versionNumber string) (
+	*feedbackModels.FeedbackFormVersion, int, error) {
+	db := service.DB
+	var version feedbackModels.FeedbackFormVersion
+	err := db.Model(&feedbackModels.FeedbackFormVersion{}).
+		Where("feedback_form_versions.deleted_at IS NULL").
+		Where("feedback_form_id = ?", feedbackFormID).
+		Where("version = ?", versionNumber).
+		First(&version).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("feedback form version not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the feedback form version")
+	}
+	return &version, http.StatusOK, nil
+}
+
+func serializeFeedbackFormVersion(version *feedbackModels.FeedbackFormVersion) feedbackSerializers.FeedbackFormVersionSerializer {
+	return feedbackSerializers.FeedbackFormVersionSerializer{
+		ID:             version.ID,
+		FeedbackFormID: version.FeedbackFormID,
+		Version:        version.Version,
+		Title:          version.Title,
+		Description:    version.Description,
+		PublishedAt:    version.PublishedAt,
+	}
+}
@@ -0,0 +1,503 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	feedbackModels "github.com/iReflect/reflect-app/apps/feedback/models"
+	feedbackSerializers "github.com/iReflect/reflect-app/apps/feedback/serializers"
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// ReviewCycleService ...
+type ReviewCycleService struct {
+	DB *gorm.DB
+}
+
+// List returns the review cycles of the teams of the user
+func (service ReviewCycleService) List(userID uint) ([]feedbackSerializers.ReviewCycleSerializer, int, error) {
+	db := service.DB
+	reviewCycles := []feedbackSerializers.ReviewCycleSerializer{}
+
+	err := db.Model(&feedbackModels.ReviewCycle{}).
+		Where("review_cycles.deleted_at IS NULL").
+		Where("review_cycles.team_id IN (?)", service.activeMembershipQuery(userID).Select("team_id").QueryExpr()).
+		Order("review_cycles.duration_end DESC, review_cycles.id DESC").
+		Scan(&reviewCycles).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the review cycles")
+	}
+	return reviewCycles, http.StatusOK, nil
+}
+
+// ListNominations returns the nominations of the review cycle, the managers of the team get all the nominations and
+// the members get their own nominations. The feedbacks of the anonymous reviewers are left out for all but the
+// reviewers, so that their feedbacks can not be told apart.
+func (service ReviewCycleService) ListNominations(reviewCycleID string, userID uint, isAdmin bool) (
+	[]feedbackSerializers.ReviewNominationSerializer, int, error) {
+	reviewCycle, status, err := service.getReviewCycle(reviewCycleID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	query := service.nominationsQuery(reviewCycle.ID)
+	if !isAdmin && !service.isTeamManager(reviewCycle.TeamID, userID) {
+		if !service.isTeamMember(reviewCycle.TeamID, userID) {
+			return nil, http.StatusForbidden, errors.New("user is not a member of the team of the review cycle")
+		}
+		query = query.Where("subjects.user_id = ?", userID)
+	}
+	return service.getNominations(query, userID)
+}
+
+// Nominate nominates the reviewers of the feedback of the user in the review cycle. The relationship of each reviewer
+// is derived from the roles of the user and the reviewer in the team, so that the user can not pick the relationship
+// which is not kept anonymous.
+func (service ReviewCycleService) Nominate(reviewCycleID string, userID uint,
+	nominationData feedbackSerializers.NominateReviewersSerializer) (
+	[]feedbackSerializers.ReviewNominationSerializer, int, error) {
+	db := service.DB
+	reviewCycle, status, err := service.getReviewCycle(reviewCycleID)
+	if err != nil {
+		return nil, status, err
+	}
+	subjectRole, err := service.getTeamRole(reviewCycle.TeamID, userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusForbidden, errors.New("user is not a member of the team of the review cycle")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to nominate the reviewers")
+	}
+	if time.Now().After(reviewCycle.NominationEndAt) {
+		return nil, http.StatusBadRequest, errors.New("nominations of the review cycle are closed")
+	}
+
+	forUserProfileID, err := service.getActiveUserProfileID(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("user has no active profile")
+	}
+
+	tx := db.Begin()
+	for _, reviewerID := range nominationData.ReviewerIDs {
+		if reviewerID == userID {
+			tx.Rollback()
+			return nil, http.StatusBadRequest, errors.New("users can not nominate themselves")
+		}
+		reviewerUserProfileID, err := service.getActiveUserProfileID(reviewerID)
+		if err != nil {
+			tx.Rollback()
+			return nil, http.StatusBadRequest, errors.New("reviewer has no active profile")
+		}
+		var reviewerRole *userModels.TeamRole
+		role, err := service.getTeamRole(reviewCycle.TeamID, reviewerID)
+		switch err {
+		case nil:
+			reviewerRole = &role
+		case gorm.ErrRecordNotFound:
+		default:
+			tx.Rollback()
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to nominate the reviewers")
+		}
+
+		// A reviewer nominated again is left as is
+		nomination := feedbackModels.ReviewNomination{}
+		err = tx.Where("review_nominations.deleted_at IS NULL").
+			Where(feedbackModels.ReviewNomination{
+				ReviewCycleID:         reviewCycle.ID,
+				ForUserProfileID:      forUserProfileID,
+				ReviewerUserProfileID: reviewerUserProfileID,
+			}).
+			Attrs(feedbackModels.ReviewNomination{
+				Relationship: feedbackModels.GetReviewRelationship(subjectRole, reviewerRole),
+				Status:       feedbackModels.PendingReviewNomination,
+			}).
+			FirstOrCreate(&nomination).Error
+		if err != nil {
+			tx.Rollback()
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to nominate the reviewers")
+		}
+	}
+	if err = tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to nominate the reviewers")
+	}
+
+	nominations, _, err := service.getNominations(
+		service.nominationsQuery(reviewCycle.ID).Where("subjects.user_id = ?", userID), userID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return nominations, http.StatusCreated, nil
+}
+
+// ReviewNominations approves and rejects the pending nominations of the user in the review cycle, a feedback is
+// requested from each of the approved reviewers
+func (service ReviewCycleService) ReviewNominations(reviewCycleID string, forUserID string, userID uint,
+	isAdmin bool, reviewData feedbackSerializers.ReviewNominationsSerializer) (
+	[]feedbackSerializers.ReviewNominationSerializer, int, error) {
+	db := service.DB
+	reviewCycle, status, err := service.getReviewCycle(reviewCycleID)
+	if err != nil {
+		return nil, status, err
+	}
+	if !isAdmin && !service.isTeamManager(reviewCycle.TeamID, userID) {
+		return nil, http.StatusForbidden, errors.New("only the managers of the team can review the nominations")
+	}
+	var forUser userModels.User
+	if err = db.Model(&userModels.User{}).
+		Where("users.deleted_at IS NULL").
+		Where("id = ?", forUserID).
+		First(&forUser).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("user not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to review the nominations")
+	}
+	if !isAdmin && forUser.ID == userID {
+		return nil, http.StatusForbidden, errors.New("users can not review their own nominations")
+	}
+	if time.Now().After(reviewCycle.ExpireAt) {
+		return nil, http.StatusBadRequest, errors.New("review cycle has expired")
+	}
+
+	tx := db.Begin()
+	reviews := []struct {
+		nominationIDs []uint
+		status        feedbackModels.ReviewNominationStatus
+	}{
+		{reviewData.ApprovedIDs, feedbackModels.ApprovedReviewNomination},
+		{reviewData.RejectedIDs, feedbackModels.RejectedReviewNomination},
+	}
+	for _, review := range reviews {
+		for _, nominationID := range review.nominationIDs {
+			var nomination feedbackModels.ReviewNomination
+			err = tx.Set("gorm:query_option", "FOR UPDATE").
+				Model(&feedbackModels.ReviewNomination{}).
+				Where("review_nominations.deleted_at IS NULL").
+				Where("id = ?", nominationID).
+				Where("review_cycle_id = ?", reviewCycle.ID).
+				Where("for_user_profile_id IN (?)", tx.Model(&userModels.UserProfile{}).
+					Where("user_id = ?", forUser.ID).
+					Select("id").
+					QueryExpr()).
+				First(&nomination).Error
+			if err != nil {
+				tx.Rollback()
+				if err == gorm.ErrRecordNotFound {
+					return nil, http.StatusNotFound, errors.New("nomination not found")
+				}
+				utils.LogToSentry(err)
+				return nil, http.StatusInternalServerError, errors.New("failed to review the nominations")
+			}
+			if nomination.Status != feedbackModels.PendingReviewNomination {
+				tx.Rollback()
+				return nil, http.StatusBadRequest, errors.New("only the pending nominations can be reviewed")
+			}
+
+			updates := map[string]interface{}{
+				"status":         review.status,
+				"reviewed_by_id": userID,
+			}
+			if review.status == feedbackModels.ApprovedReviewNomination {
+				feedback := feedbackModels.Feedback{
+					Title:            reviewCycle.Title,
+					FeedbackFormID:   reviewCycle.FeedbackFormID,
+					ForUserProfileID: nomination.ForUserProfileID,
+					ByUserProfileID:  nomination.ReviewerUserProfileID,
+					TeamID:           reviewCycle.TeamID,
+					Status:           feedbackModels.NewFeedback,
+					DurationStart:    reviewCycle.DurationStart,
+					DurationEnd:      reviewCycle.DurationEnd,
+					ExpireAt:         reviewCycle.ExpireAt,
+				}
+				if err = createFeedback(tx, &feedback); err != nil {
+					tx.Rollback()
+					utils.LogToSentry(err)
+					return nil, http.StatusInternalServerError, errors.New("failed to request the feedback of the reviewer")
+				}
+				updates["feedback_id"] = feedback.ID
+			}
+			if err = tx.Model(&nomination).Updates(updates).Error; err != nil {
+				tx.Rollback()
+				utils.LogToSentry(err)
+				return nil, http.StatusInternalServerError, errors.New("failed to review the nominations")
+			}
+		}
+	}
+	if err = tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to review the nominations")
+	}
+
+	return service.getNominations(
+		service.nominationsQuery(reviewCycle.ID).Where("subjects.user_id = ?", forUser.ID), userID)
+}
+
+// GetResults returns the scores of the submitted feedbacks of the reviewers of the user in the review cycle,
+// aggregated by the relationships of the reviewers
+func (service ReviewCycleService) GetResults(reviewCycleID string, forUserID string, userID uint, isAdmin bool) (
+	*feedbackSerializers.ReviewResultSerializer, int, error) {
+	db := service.DB
+	reviewCycle, status, err := service.getReviewCycle(reviewCycleID)
+	if err != nil {
+		return nil, status, err
+	}
+	var forUser userModels.User
+	if err = db.Model(&userModels.User{}).
+		Where("users.deleted_at IS NULL").
+		Where("id = ?", forUserID).
+		First(&forUser).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("user not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the review results")
+	}
+	if !isAdmin && forUser.ID != userID && !service.isTeamManager(reviewCycle.TeamID, userID) {
+		return nil, http.StatusForbidden, errors.New("user can not access the review results")
+	}
+
+	var nominations []feedbackModels.ReviewNomination
+	err = db.Model(&feedbackModels.ReviewNomination{}).
+		Where("review_nominations.deleted_at IS NULL").
+		Where("review_cycle_id = ?", reviewCycle.ID).
+		Where("status = ?", feedbackModels.ApprovedReviewNomination).
+		Where("for_user_profile_id IN (?)", db.Model(&userModels.UserProfile{}).
+			Where("user_id = ?", forUser.ID).
+			Select("id").
+			QueryExpr()).
+		Preload("Feedback", "feedbacks.deleted_at IS NULL").
+		Preload("Feedback.FeedbackFormVersion").
+		Find(&nominations).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the review results")
+	}
+
+	var feedbackIDs []uint
+	for _, nomination := range nominations {
+		if nomination.Feedback != nil && nomination.Feedback.Status == feedbackModels.SubmittedFeedback {
+			feedbackIDs = append(feedbackIDs, nomination.Feedback.ID)
+		}
+	}
+	feedbackResponses, err := getFeedbackResponses(db, feedbackIDs)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the review results")
+	}
+
+	groups, err := getReviewGroupResults(*reviewCycle, nominations, feedbackResponses, time.Now())
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the review results")
+	}
+	return &feedbackSerializers.ReviewResultSerializer{
+		ReviewCycleID:      reviewCycle.ID,
+		ForUserID:          forUser.ID,
+		AnonymityThreshold: reviewCycle.AnonymityThreshold,
+		Groups:             groups,
+	}, http.StatusOK, nil
+}
+
+// getReviewGroupResults aggregates the scores of the submitted feedbacks of the nominations by the relationships of
+// the reviewers, the scores of the groups hidden at the time are left out
+func getReviewGroupResults(reviewCycle feedbackModels.ReviewCycle, nominations []feedbackModels.ReviewNomination,
+	feedbackResponses map[uint]map[uint]string, now time.Time) (
+	[]feedbackSerializers.ReviewGroupResultSerializer, error) {
+	var err error
+	groups := []feedbackSerializers.ReviewGroupResultSerializer{}
+	for index := range feedbackModels.ReviewRelationshipValues {
+		relationship := feedbackModels.ReviewRelationship(index)
+		var reviewerCount int
+		var scores []feedbackModels.FeedbackScore
+		var contents []feedbackModels.FeedbackFormContentSnapshot
+		for _, nomination := range nominations {
+			if nomination.Relationship != relationship {
+				continue
+			}
+			reviewerCount++
+			feedback := nomination.Feedback
+			if feedback == nil || feedback.Status != feedbackModels.SubmittedFeedback {
+				continue
+			}
+			if contents, err = feedback.FeedbackFormVersion.GetContents(); err != nil {
+				return nil, err
+			}
+			scores = append(scores, feedbackModels.GetFeedbackScore(contents, feedbackResponses[feedback.ID]))
+		}
+		if reviewerCount == 0 {
+			continue
+		}
+
+		group := feedbackSerializers.ReviewGroupResultSerializer{
+			Relationship:  relationship.String(),
+			ReviewerCount: reviewerCount,
+			ResponseCount: len(scores),
+			Categories:    []feedbackSerializers.CategoryScoreSerializer{},
+			Skills:        []feedbackSerializers.SkillScoreSerializer{},
+		}
+		if reviewCycle.IsResultHidden(relationship, len(scores), now) {
+			group.IsHidden = true
+		} else if len(scores) > 0 {
+			setReviewGroupScores(&group, contents, scores)
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// setReviewGroupScores sets the averages of the scores of the reviewers of the group, the categories and the skills
+// are listed from the contents of the feedback form
+func setReviewGroupScores(group *feedbackSerializers.ReviewGroupResultSerializer,
+	contents []feedbackModels.FeedbackFormContentSnapshot, scores []feedbackModels.FeedbackScore) {
+	average := func(getScore func(score feedbackModels.FeedbackScore) *float64) *float64 {
+		var total float64
+		var count int
+		for _, score := range scores {
+			if value := getScore(score); value != nil {
+				total += *value
+				count++
+			}
+		}
+		if count == 0 {
+			return nil
+		}
+		value := total / float64(count)
+		return &value
+	}
+
+	group.Score = average(func(score feedbackModels.FeedbackScore) *float64 { return score.Overall })
+	addedCategories := map[uint]bool{}
+	for _, content := range contents {
+		category := content.Category
+		if !addedCategories[category.ID] {
+			addedCategories[category.ID] = true
+			group.Categories = append(group.Categories, feedbackSerializers.CategoryScoreSerializer{
+				ID:    category.ID,
+				Title: category.Title,
+				Score: average(func(score feedbackModels.FeedbackScore) *float64 {
+					return score.Categories[category.ID]
+				}),
+			})
+		}
+		skill := content.Skill
+		group.Skills = append(group.Skills, feedbackSerializers.SkillScoreSerializer{
+			ID:           skill.ID,
+			CategoryID:   category.ID,
+			Title:        skill.Title,
+			DisplayTitle: skill.DisplayTitle,
+			Score: average(func(score feedbackModels.FeedbackScore) *float64 {
+				return score.Skills[skill.ID]
+			}),
+		})
+	}
+}
+
+func (service ReviewCycleService) getReviewCycle(reviewCycleID string) (*feedbackModels.ReviewCycle, int, error) {
+	db := service.DB
+	var reviewCycle feedbackModels.ReviewCycle
+	err := db.Model(&feedbackModels.ReviewCycle{}).
+		Where("review_cycles.deleted_at IS NULL").
+		Where("id = ?", reviewCycleID).
+		First(&reviewCycle).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("review cycle not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the review cycle")
+	}
+	return &reviewCycle, http.StatusOK, nil
+}
+
+func (service ReviewCycleService) nominationsQuery(reviewCycleID uint) *gorm.DB {
+	db := service.DB
+	return db.Model(&feedbackModels.ReviewNomination{}).
+		Joins("JOIN user_profiles subjects ON subjects.id = review_nominations.for_user_profile_id").
+		Joins("JOIN user_profiles reviewers ON reviewers.id = review_nominations.reviewer_user_profile_id").
+		Where("review_nominations.deleted_at IS NULL").
+		Where("review_nominations.review_cycle_id = ?", reviewCycleID)
+}
+
+// getNominations returns the nominations of the query as seen by the user
+func (service ReviewCycleService) getNominations(query *gorm.DB, userID uint) (
+	[]feedbackSerializers.ReviewNominationSerializer, int, error) {
+	nominations := []feedbackSerializers.ReviewNominationSerializer{}
+	err := query.
+		Select(`review_nominations.id, review_nominations.review_cycle_id,
+            subjects.user_id AS for_user_id, review_nominations.for_user_profile_id,
+            reviewers.user_id AS reviewer_user_id, review_nominations.reviewer_user_profile_id,
+            review_nominations.relationship, review_nominations.status,
+            review_nominations.reviewed_by_id, review_nominations.feedback_id`).
+		Order("subjects.user_id, review_nominations.id").
+		Scan(&nominations).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the nominations")
+	}
+	hideAnonymousFeedbackIDs(nominations, userID)
+	return nominations, http.StatusOK, nil
+}
+
+// hideAnonymousFeedbackIDs leaves out the feedbacks of the anonymous reviewers other than the user from the
+// nominations
+func hideAnonymousFeedbackIDs(nominations []feedbackSerializers.ReviewNominationSerializer, userID uint) {
+	for index := range nominations {
+		nomination := &nominations[index]
+		if nomination.Relationship.IsAnonymous() && nomination.ReviewerUserID != userID {
+			nomination.FeedbackID = nil
+		}
+	}
+}
+
+func (service ReviewCycleService) getActiveUserProfileID(userID uint) (uint, error) {
+	db := service.DB
+	var userProfile userModels.UserProfile
+	err := db.Model(&userModels.UserProfile{}).
+		Where("user_profiles.deleted_at IS NULL").
+		Where("user_id = ?", userID).
+		Where("active = true").
+		First(&userProfile).Error
+	return userProfile.ID, err
+}
+
+func (service ReviewCycleService) activeMembershipQuery(userID uint) *gorm.DB {
+	db := service.DB
+	return db.Model(&userModels.UserTeam{}).
+		Where("user_teams.deleted_at IS NULL").
+		Where("user_teams.user_id = ?", userID).
+		Where("user_teams.leaved_at IS NULL OR user_teams.leaved_at > ?", time.Now())
+}
+
+// getTeamRole returns the role of the active member in the team
+func (service ReviewCycleService) getTeamRole(teamID uint, userID uint) (userModels.TeamRole, error) {
+	var userTeam userModels.UserTeam
+	err := service.activeMembershipQuery(userID).
+		Where("user_teams.team_id = ?", teamID).
+		First(&userTeam).Error
+	return userTeam.Role, err
+}
+
+func (service ReviewCycleService) isTeamMember(teamID uint, userID uint) bool {
+	return !service.activeMembershipQuery(userID).
+		Where("user_teams.team_id = ?", teamID).
+		First(&userModels.UserTeam{}).
+		RecordNotFound()
+}
+
+func (service ReviewCycleService) isTeamManager(teamID uint, userID uint) bool {
+	return !service.activeMembershipQuery(userID).
+		Where("user_teams.team_id = ?", teamID).
+		Where("user_teams.role IN (?)", []userModels.TeamRole{userModels.ManagerRole, userModels.AdminRole}).
+		First(&userModels.UserTeam{}).
+		RecordNotFound()
+}
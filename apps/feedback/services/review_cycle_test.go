@@ -0,0 +1,146 @@
+package services
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	feedbackModels "github.com/iReflect/reflect-app/apps/feedback/models"
+	feedbackSerializers "github.com/iReflect/reflect-app/apps/feedback/serializers"
+	"github.com/iReflect/reflect-app/db/models/fields"
+)
+
+func TestGetReviewGroupResults(t *testing.T) {
+	contents, _ := json.Marshal([]feedbackModels.FeedbackFormContentSnapshot{{
+		ID:       1,
+		Category: feedbackModels.CategorySnapshot{ID: 1, Title: "Delivery"},
+		Skill: feedbackModels.SkillSnapshot{ID: 1, Title: "Quality", Weight: 1, Questions: []feedbackModels.QuestionSnapshot{
+			{ID: 1, Type: feedbackModels.NumericRangeType, Weight: 1, Options: fields.JSONB(`{"min": 0, "max": 10}`)},
+		}},
+	}})
+	version := feedbackModels.FeedbackFormVersion{Contents: fields.JSONB(contents)}
+	nomination := func(relationship feedbackModels.ReviewRelationship, feedbackID uint,
+		status feedbackModels.FeedbackStatus) feedbackModels.ReviewNomination {
+		return feedbackModels.ReviewNomination{
+			Relationship: relationship,
+			Status:       feedbackModels.ApprovedReviewNomination,
+			Feedback: &feedbackModels.Feedback{
+				Model:               gorm.Model{ID: feedbackID},
+				Status:              status,
+				FeedbackFormVersion: version,
+			},
+		}
+	}
+	submitted := feedbackModels.SubmittedFeedback
+	nominations := []feedbackModels.ReviewNomination{
+		nomination(feedbackModels.PeerReviewRelationship, 11, submitted),
+		nomination(feedbackModels.PeerReviewRelationship, 12, submitted),
+		nomination(feedbackModels.PeerReviewRelationship, 13, feedbackModels.InProgressFeedback),
+		nomination(feedbackModels.DirectReportReviewRelationship, 14, submitted),
+		nomination(feedbackModels.ManagerReviewRelationship, 15, submitted),
+	}
+	feedbackResponses := map[uint]map[uint]string{
+		11: {1: "8"},
+		12: {1: "4"},
+		13: {1: "10"},
+		14: {1: "6"},
+		15: {1: "2"},
+	}
+	expireAt := time.Date(2018, 6, 30, 0, 0, 0, 0, time.UTC)
+	reviewCycle := feedbackModels.ReviewCycle{ExpireAt: expireAt, AnonymityThreshold: 2}
+
+	type expectedGroup struct {
+		reviewerCount int
+		responseCount int
+		isHidden      bool
+		score         float64
+	}
+	testCases := []struct {
+		name           string
+		now            time.Time
+		expectedGroups map[string]expectedGroup
+	}{
+		{
+			// The anonymous groups are hidden until the review cycle expires
+			"before expiry", expireAt.Add(-time.Hour), map[string]expectedGroup{
+				"Peer":          {reviewerCount: 3, responseCount: 2, isHidden: true},
+				"Manager":       {reviewerCount: 1, responseCount: 1, score: 0.2},
+				"Direct Report": {reviewerCount: 1, responseCount: 1, isHidden: true},
+			},
+		},
+		{
+			// After it expires, only the anonymous groups with less responses than the threshold are hidden
+			"after expiry", expireAt.Add(time.Hour), map[string]expectedGroup{
+				"Peer":          {reviewerCount: 3, responseCount: 2, score: 0.6},
+				"Manager":       {reviewerCount: 1, responseCount: 1, score: 0.2},
+				"Direct Report": {reviewerCount: 1, responseCount: 1, isHidden: true},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		groups, err := getReviewGroupResults(reviewCycle, nominations, feedbackResponses, testCase.now)
+		if err != nil {
+			t.Fatalf("%s: error in getting the review results - %s", testCase.name, err)
+		}
+		if len(groups) != len(testCase.expectedGroups) {
+			t.Fatalf("%s: expected %d groups, got %+v", testCase.name, len(testCase.expectedGroups), groups)
+		}
+		for _, group := range groups {
+			expected := testCase.expectedGroups[group.Relationship]
+			if group.ReviewerCount != expected.reviewerCount || group.ResponseCount != expected.responseCount {
+				t.Errorf("%s: expected %d %s responses of %d reviewers, got %d of %d", testCase.name,
+					expected.responseCount, group.Relationship, expected.reviewerCount, group.ResponseCount,
+					group.ReviewerCount)
+			}
+			if group.IsHidden != expected.isHidden {
+				t.Errorf("%s: expected the %s group hidden %t, got %t", testCase.name, group.Relationship,
+					expected.isHidden, group.IsHidden)
+			}
+			if expected.isHidden {
+				if group.Score != nil || len(group.Skills) != 0 {
+					t.Errorf("%s: the scores of the hidden %s group should not be returned - %+v", testCase.name,
+						group.Relationship, group)
+				}
+				continue
+			}
+			if group.Score == nil || math.Abs(*group.Score-expected.score) > 1e-9 {
+				t.Errorf("%s: expected the %s score %f, got %v", testCase.name, group.Relationship, expected.score,
+					group.Score)
+			}
+			if len(group.Categories) != 1 || len(group.Skills) != 1 || group.Skills[0].Score == nil ||
+				math.Abs(*group.Skills[0].Score-expected.score) > 1e-9 {
+				t.Errorf("%s: expected the %s category and skill scores %f, got %+v", testCase.name,
+					group.Relationship, expected.score, group)
+			}
+		}
+	}
+}
+
+func TestHideAnonymousFeedbackIDs(t *testing.T) {
+	feedbackID := uint(20)
+	testCases := []struct {
+		relationship   feedbackModels.ReviewRelationship
+		reviewerUserID uint
+		isHidden       bool
+	}{
+		{feedbackModels.PeerReviewRelationship, 2, true},
+		{feedbackModels.DirectReportReviewRelationship, 2, true},
+		{feedbackModels.PeerReviewRelationship, 1, false},
+		{feedbackModels.ManagerReviewRelationship, 2, false},
+	}
+	for _, testCase := range testCases {
+		nominations := []feedbackSerializers.ReviewNominationSerializer{{
+			Relationship:   testCase.relationship,
+			ReviewerUserID: testCase.reviewerUserID,
+			FeedbackID:     &feedbackID,
+		}}
+		hideAnonymousFeedbackIDs(nominations, 1)
+		if isHidden := nominations[0].FeedbackID == nil; isHidden != testCase.isHidden {
+			t.Errorf("Expected the feedback of the %s reviewer %d hidden from the user 1 %t, got %t",
+				testCase.relationship, testCase.reviewerUserID, testCase.isHidden, isHidden)
+		}
+	}
+}
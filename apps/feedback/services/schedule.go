@@ -0,0 +1,183 @@
+package services
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	feedbackModels "github.com/iReflect/reflect-app/apps/feedback/models"
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// ScheduleService ...
+type ScheduleService struct {
+	DB *gorm.DB
+}
+
+// CreateDueFeedbacks creates the feedbacks of the active schedules whose next event is due, and advances the schedules
+// to their next events. The events missed in between, e.g. while the workers were down, are skipped.
+func (service ScheduleService) CreateDueFeedbacks() error {
+	db := service.DB
+	var scheduleIDs []uint
+
+	now := time.Now()
+	err := db.Model(&feedbackModels.Schedule{}).
+		Where("schedules.deleted_at IS NULL").
+		Where("active = true").
+		Where("next_event_at <= ?", now).
+		Pluck("id", &scheduleIDs).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return err
+	}
+
+	for _, scheduleID := range scheduleIDs {
+		// A failed schedule is retried on the next run, without holding up the other schedules
+		if err = service.createScheduledFeedbacks(scheduleID, now); err != nil {
+			utils.LogToSentry(err)
+		}
+	}
+	return nil
+}
+
+// createScheduledFeedbacks creates a self feedback of each of the members of the team of the schedule, from the active
+// feedback form of the team for the role of the member, and advances the schedule to its next event
+func (service ScheduleService) createScheduledFeedbacks(scheduleID uint, now time.Time) error {
+	tx := service.DB.Begin()
+	var schedule feedbackModels.Schedule
+
+	// The schedule is locked, so that the feedbacks of an event are not created twice by the concurrent runs
+	err := tx.Set("gorm:query_option", "FOR UPDATE").
+		Model(&feedbackModels.Schedule{}).
+		Where("schedules.deleted_at IS NULL").
+		Where("active = true").
+		Where("next_event_at <= ?", now).
+		Where("id = ?", scheduleID).
+		First(&schedule).Error
+	if err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+
+	eventAt := schedule.NextEventAt
+	durationStart, durationEnd, err := schedule.GetEventDuration(eventAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	nextEventAt, err := schedule.GetNextEventTime(now)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	var memberProfiles []struct {
+		UserProfileID  uint
+		FeedbackFormID uint
+	}
+	err = tx.Model(&userModels.UserTeam{}).
+		Where("user_teams.deleted_at IS NULL").
+		Where("user_teams.team_id = ?", schedule.TeamID).
+		Where("user_teams.joined_at <= ?", eventAt).
+		Where("user_teams.leaved_at IS NULL OR user_teams.leaved_at > ?", durationEnd).
+		Joins("JOIN user_profiles ON user_profiles.user_id = user_teams.user_id").
+		Where("user_profiles.deleted_at IS NULL").
+		Where("user_profiles.active = true").
+		Joins(`JOIN team_feedback_forms ON team_feedback_forms.team_id = user_teams.team_id
+            AND team_feedback_forms.for_role_id = user_profiles.role_id`).
+		Where("team_feedback_forms.deleted_at IS NULL").
+		Where("team_feedback_forms.active = true").
+		Joins("JOIN feedback_forms ON feedback_forms.id = team_feedback_forms.feedback_form_id").
+		Where("feedback_forms.deleted_at IS NULL").
+		Where("feedback_forms.status = ?", feedbackModels.PublishedFeedbackForm).
+		Group("user_profiles.id, team_feedback_forms.feedback_form_id").
+		Select("user_profiles.id AS user_profile_id, team_feedback_forms.feedback_form_id").
+		Scan(&memberProfiles).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, memberProfile := range memberProfiles {
+		feedback := feedbackModels.Feedback{
+			Title:            schedule.FeedbackTitle,
+			FeedbackFormID:   memberProfile.FeedbackFormID,
+			ForUserProfileID: memberProfile.UserProfileID,
+			ByUserProfileID:  memberProfile.UserProfileID,
+			TeamID:           schedule.TeamID,
+			Status:           feedbackModels.NewFeedback,
+			DurationStart:    durationStart,
+			DurationEnd:      durationEnd,
+			ExpireAt:         eventAt.AddDate(0, 0, int(schedule.ExpireInDays)),
+		}
+		if err = createFeedback(tx, &feedback); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = tx.Model(&schedule).Update("next_event_at", nextEventAt).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// createFeedback creates the feedback with a blank response to each of the questions of its feedback form version, a
+// feedback already created for the same form and duration is left as is, and is loaded into the given feedback
+func createFeedback(tx *gorm.DB, feedback *feedbackModels.Feedback) error {
+	var existingFeedback feedbackModels.Feedback
+	err := tx.Model(&feedbackModels.Feedback{}).
+		Where("feedbacks.deleted_at IS NULL").
+		Where(feedbackModels.Feedback{
+			FeedbackFormID:   feedback.FeedbackFormID,
+			ForUserProfileID: feedback.ForUserProfileID,
+			ByUserProfileID:  feedback.ByUserProfileID,
+			TeamID:           feedback.TeamID,
+		}).
+		Where("duration_start = ? AND duration_end = ?", feedback.DurationStart, feedback.DurationEnd).
+		First(&existingFeedback).Error
+	if err == nil {
+		*feedback = existingFeedback
+		return nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return err
+	}
+
+	if err := tx.Create(feedback).Error; err != nil {
+		return err
+	}
+
+	// The responses are created for the questions of the feedback form version the feedback is pinned to
+	var version feedbackModels.FeedbackFormVersion
+	err = tx.Model(&feedbackModels.FeedbackFormVersion{}).
+		Where("feedback_form_versions.deleted_at IS NULL").
+		Where("id = ?", feedback.FeedbackFormVersionID).
+		First(&version).Error
+	if err != nil {
+		return err
+	}
+	formContents, err := version.GetContents()
+	if err != nil {
+		return err
+	}
+	for _, formContent := range formContents {
+		for _, question := range formContent.Skill.Questions {
+			err = tx.Create(&feedbackModels.QuestionResponse{
+				FeedbackID:            feedback.ID,
+				FeedbackFormContentID: formContent.ID,
+				QuestionID:            question.ID,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
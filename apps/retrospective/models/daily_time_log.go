@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// DailyTimeLog represents the time logged by a sprint member on a task on a day. The time logged after the end of
+// the sprint is stored as well, but is not counted in the time spent on the sprint member task.
+type DailyTimeLog struct {
+	gorm.Model
+	SprintMemberTask   SprintMemberTask
+	SprintMemberTaskID uint      `gorm:"not null; index:idx_daily_time_log_smt_date"`
+	Date               time.Time `gorm:"type:date; not null; index:idx_daily_time_log_smt_date"`
+	Minutes            uint      `gorm:"not null"`
+}
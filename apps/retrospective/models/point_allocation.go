@@ -0,0 +1,155 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/iReflect/reflect-app/db/models/fields"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// Point allocation strategies of a retrospective, which decide how the remaining points of a task are split among
+// the sprint members who worked on it in a sprint
+const (
+	// TimeProportionalAllocation splits the points in the ratio of the time spent on the task
+	TimeProportionalAllocation = "time-proportional"
+	// EqualSplitAllocation splits the points equally among the members of the task
+	EqualSplitAllocation = "equal-split"
+	// RoleWeightedAllocation splits the points in the ratio of the weights of the roles of the members in the task
+	RoleWeightedAllocation = "role-weighted"
+	// ManualAllocation leaves the points to be assigned manually
+	ManualAllocation = "manual"
+)
+
+// PointAllocationStrategies ...
+var PointAllocationStrategies = []string{
+	TimeProportionalAllocation,
+	EqualSplitAllocation,
+	RoleWeightedAllocation,
+	ManualAllocation,
+}
+
+// DefaultRoleWeights are the weights of the roles used by the role weighted strategy of the retrospectives which have
+// not set their own, the roles which are not weighed get no points
+var DefaultRoleWeights = map[MemberTaskRole]float64{
+	Developer: 1,
+	Reviewer:  0.5,
+	Validator: 0.25,
+}
+
+// ValidatePointAllocationStrategy checks the point allocation strategy, an empty
+// strategy is allowed for which the default (time-proportional) strategy is used
+func ValidatePointAllocationStrategy(strategy string) error {
+	if strategy != "" && !utils.StringInSlice(strategy, PointAllocationStrategies) {
+		return errors.New("point allocation strategy should be one of time-proportional, equal-split, role-weighted or manual")
+	}
+	return nil
+}
+
+// ParseRoleWeights parses the role weights of a retrospective, which map the names of the member task roles (eg.
+// 'Developer') to their weights. The default role weights are returned if none are set.
+func ParseRoleWeights(roleWeights fields.JSONB) (map[MemberTaskRole]float64, error) {
+	var namedWeights map[string]float64
+	if !roleWeights.IsNull() {
+		if err := json.Unmarshal(roleWeights, &namedWeights); err != nil {
+			return nil, errors.New("role weights should map the member task roles to their weights")
+		}
+	}
+	if len(namedWeights) == 0 {
+		return DefaultRoleWeights, nil
+	}
+
+	weights := make(map[MemberTaskRole]float64)
+	var totalWeight float64
+	for name, weight := range namedWeights {
+		role, err := getMemberTaskRole(name)
+		if err != nil {
+			return nil, err
+		}
+		if weight < 0 {
+			return nil, errors.New("role weights cannot be negative")
+		}
+		weights[role] = weight
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return nil, errors.New("at least one of the role weights should be positive")
+	}
+	return weights, nil
+}
+
+// getMemberTaskRole returns the member task role of the name
+func getMemberTaskRole(name string) (MemberTaskRole, error) {
+	for role, roleName := range MemberTaskRoleValues {
+		if roleName == name {
+			return MemberTaskRole(role), nil
+		}
+	}
+	return 0, fmt.Errorf("role weights should be of the roles %s", strings.Join(MemberTaskRoleValues[:], ", "))
+}
+
+// PointAllocationStrategy decides the share of the sprint member tasks in the points of their task. The remaining
+// points of a task in a sprint are split among its sprint member tasks in the ratio of their weights.
+type PointAllocationStrategy interface {
+	// WeightExpr returns the SQL expression of the weight of a sprint member task
+	WeightExpr() string
+}
+
+// TimeProportionalStrategy weighs the sprint member tasks by the time spent on them
+type TimeProportionalStrategy struct{}
+
+// WeightExpr ...
+func (strategy TimeProportionalStrategy) WeightExpr() string {
+	return "sprint_member_tasks.time_spent_minutes"
+}
+
+// EqualSplitStrategy weighs all the sprint member tasks of a task equally
+type EqualSplitStrategy struct{}
+
+// WeightExpr ...
+func (strategy EqualSplitStrategy) WeightExpr() string {
+	return "1"
+}
+
+// RoleWeightedStrategy weighs the sprint member tasks by the role of the member in the task
+type RoleWeightedStrategy struct {
+	RoleWeights map[MemberTaskRole]float64
+}
+
+// WeightExpr ...
+func (strategy RoleWeightedStrategy) WeightExpr() string {
+	var roles []int
+	for role := range strategy.RoleWeights {
+		roles = append(roles, int(role))
+	}
+	// Sorted to keep the query stable
+	sort.Ints(roles)
+
+	var cases []string
+	for _, role := range roles {
+		cases = append(cases, fmt.Sprintf("WHEN %d THEN %v", role, strategy.RoleWeights[MemberTaskRole(role)]))
+	}
+	return fmt.Sprintf("(CASE sprint_member_tasks.role %s ELSE 0 END)", strings.Join(cases, " "))
+}
+
+// GetPointAllocationStrategy returns the implementation of the point allocation strategy of the retrospective, nil is
+// returned for the manual allocation since the points are not assigned automatically. The time proportional strategy
+// is used for the unknown strategies.
+func (retrospective Retrospective) GetPointAllocationStrategy() (PointAllocationStrategy, error) {
+	switch retrospective.PointAllocationStrategy {
+	case EqualSplitAllocation:
+		return EqualSplitStrategy{}, nil
+	case RoleWeightedAllocation:
+		roleWeights, err := ParseRoleWeights(retrospective.RoleWeights)
+		if err != nil {
+			return nil, err
+		}
+		return RoleWeightedStrategy{RoleWeights: roleWeights}, nil
+	case ManualAllocation:
+		return nil, nil
+	}
+	return TimeProportionalStrategy{}, nil
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/iReflect/reflect-app/db/models/fields"
+)
+
+func TestGetPointAllocationStrategy(t *testing.T) {
+	testCases := []struct {
+		strategy    string
+		roleWeights string
+		weightExpr  string
+	}{
+		{TimeProportionalAllocation, `{}`, "sprint_member_tasks.time_spent_minutes"},
+		{"", `{}`, "sprint_member_tasks.time_spent_minutes"},
+		{"unknown", `{}`, "sprint_member_tasks.time_spent_minutes"},
+		{EqualSplitAllocation, `{}`, "1"},
+		{RoleWeightedAllocation, `{}`,
+			"(CASE sprint_member_tasks.role WHEN 0 THEN 1 WHEN 1 THEN 0.5 WHEN 2 THEN 0.25 ELSE 0 END)"},
+		{RoleWeightedAllocation, ``,
+			"(CASE sprint_member_tasks.role WHEN 0 THEN 1 WHEN 1 THEN 0.5 WHEN 2 THEN 0.25 ELSE 0 END)"},
+		{RoleWeightedAllocation, `{"Validator": 0.5, "Developer": 2}`,
+			"(CASE sprint_member_tasks.role WHEN 0 THEN 2 WHEN 2 THEN 0.5 ELSE 0 END)"},
+	}
+	for _, testCase := range testCases {
+		retrospective := Retrospective{
+			PointAllocationStrategy: testCase.strategy,
+			RoleWeights:             fields.JSONB(testCase.roleWeights),
+		}
+		strategy, err := retrospective.GetPointAllocationStrategy()
+		if err != nil {
+			t.Fatalf("%q: error in getting the point allocation strategy - %s", testCase.strategy, err)
+		}
+		if weightExpr := strategy.WeightExpr(); weightExpr != testCase.weightExpr {
+			t.Errorf("Expected the %q weight expression %q, got %q", testCase.strategy, testCase.weightExpr,
+				weightExpr)
+		}
+	}
+
+	// The points are not assigned automatically for the manual allocation
+	strategy, err := Retrospective{PointAllocationStrategy: ManualAllocation}.GetPointAllocationStrategy()
+	if strategy != nil || err != nil {
+		t.Errorf("Expected no strategy for the manual allocation, got %v - %v", strategy, err)
+	}
+
+	// The invalid role weights saved from the admin fail the role weighted strategy
+	retrospective := Retrospective{PointAllocationStrategy: RoleWeightedAllocation, RoleWeights: fields.JSONB(`[]`)}
+	if _, err = retrospective.GetPointAllocationStrategy(); err == nil {
+		t.Errorf("Expected the invalid role weights to fail the role weighted strategy")
+	}
+}
+
+func TestParseRoleWeights(t *testing.T) {
+	testCases := []struct {
+		roleWeights string
+		weights     map[MemberTaskRole]float64
+		isValid     bool
+	}{
+		{`{}`, DefaultRoleWeights, true},
+		{`null`, DefaultRoleWeights, true},
+		{`{"Developer": 1, "Reviewer": 0}`, map[MemberTaskRole]float64{Developer: 1, Reviewer: 0}, true},
+		{`{"Developer": 0}`, nil, false},
+		{`{"Developer": 1, "Reviewer": -1}`, nil, false},
+		{`{"Tester": 1}`, nil, false},
+		{`{"Developer": "high"}`, nil, false},
+	}
+	for _, testCase := range testCases {
+		weights, err := ParseRoleWeights(fields.JSONB(testCase.roleWeights))
+		if (err == nil) != testCase.isValid {
+			t.Errorf("Expected the validity of the role weights %s to be %t, got the error %v", testCase.roleWeights,
+				testCase.isValid, err)
+			continue
+		}
+		if testCase.isValid && !reflect.DeepEqual(weights, testCase.weights) {
+			t.Errorf("Expected the role weights %s to be parsed as %v, got %v", testCase.roleWeights,
+				testCase.weights, weights)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -27,6 +28,14 @@ type Retrospective struct {
 	StoryPointPerWeek  float64 `gorm:"not null"`
 	CreatedBy          userModels.User
 	CreatedByID        uint `gorm:"not null"`
+	// WebhookSecret is used to authenticate the task tracker webhooks of the retrospective
+	WebhookSecret string `gorm:"type:varchar(64); not null; default:''"`
+	// SyncSchedule is the policy or the cron expression for the automatic sync of the active sprints
+	SyncSchedule string `gorm:"type:varchar(100); not null; default:''"`
+	// PointAllocationStrategy decides how the points of a task are split among the sprint members who worked on it
+	PointAllocationStrategy string `gorm:"type:varchar(30); not null; default:'time-proportional'"`
+	// RoleWeights are the weights of the member task roles used by the role weighted strategy, mapped by the role names
+	RoleWeights fields.JSONB `gorm:"type:jsonb; not null; default:'{}'::jsonb"`
 }
 
 // Validate ...
@@ -35,12 +44,42 @@ func (retrospective *Retrospective) Validate(db *gorm.DB) (err error) {
 		err = errors.New("story points per week cannot be negative")
 		return err
 	}
-	return
+	if err = ValidatePointAllocationStrategy(retrospective.PointAllocationStrategy); err != nil {
+		return err
+	}
+	if _, err = ParseRoleWeights(retrospective.RoleWeights); err != nil {
+		return err
+	}
+	return ValidateSyncSchedule(retrospective.SyncSchedule)
 }
 
 // BeforeSave ...
 func (retrospective *Retrospective) BeforeSave(db *gorm.DB) (err error) {
-	return retrospective.Validate(db)
+	if err = retrospective.Validate(db); err != nil {
+		return err
+	}
+	return retrospective.assignProviderKeys()
+}
+
+// assignProviderKeys sets the keys of the task provider configs which do not have one, on every save of the
+// retrospective, so that the configs edited from the admin also keep their tasks and sync marks
+func (retrospective *Retrospective) assignProviderKeys() error {
+	if retrospective.TaskProviderConfig.IsNull() {
+		return nil
+	}
+	var configList []map[string]interface{}
+	if err := json.Unmarshal(retrospective.TaskProviderConfig, &configList); err != nil {
+		return errors.New("task provider config should be a list of the task provider configs")
+	}
+	if err := tasktracker.AssignProviderKeys(configList); err != nil {
+		return err
+	}
+	taskProviderConfig, err := json.Marshal(configList)
+	if err != nil {
+		return err
+	}
+	retrospective.TaskProviderConfig = taskProviderConfig
+	return nil
 }
 
 // BeforeUpdate ...
@@ -56,8 +95,23 @@ func RegisterRetrospectiveToAdmin(Admin *admin.Admin, config admin.Config) {
 
 	retrospective.Meta(&taskProviderConfigMeta)
 	retrospective.Meta(&createdByMeta)
+	retrospective.Meta(&admin.Meta{
+		Name:       "PointAllocationStrategy",
+		Type:       "select_one",
+		Collection: PointAllocationStrategies,
+	})
+	retrospective.Meta(&admin.Meta{
+		Name: "RoleWeights",
+		Type: "text",
+		Valuer: func(value interface{}, context *qor.Context) interface{} {
+			return string(value.(*Retrospective).RoleWeights)
+		},
+		Setter: func(resource interface{}, metaValue *resource.MetaValue, context *qor.Context) {
+			resource.(*Retrospective).RoleWeights = fields.JSONB(metaValue.Value.([]string)[0])
+		},
+	})
 
-	retrospective.IndexAttrs("-Sprints")
+	retrospective.IndexAttrs("-Sprints", "-WebhookSecret")
 	retrospective.NewAttrs("-Sprints")
 	retrospective.EditAttrs("-Sprints")
 	retrospective.ShowAttrs("-Sprints")
@@ -94,8 +148,8 @@ func RetroJoinUserTeams(db *gorm.DB) *gorm.DB {
 	return db.Joins("JOIN user_teams ON retrospectives.team_id = user_teams.team_id AND user_teams.deleted_at IS NULL")
 }
 
-// GetTaskTrackerConnectionFromRetro ...
-func GetTaskTrackerConnectionFromRetro(db *gorm.DB, retroID string) (tasktracker.Connection, error) {
+// GetTaskTrackerConnectionsFromRetro ...
+func GetTaskTrackerConnectionsFromRetro(db *gorm.DB, retroID string) (tasktracker.ProviderConnections, error) {
 	var retro Retrospective
 	if err := db.Model(Retrospective{}).
 		Where("retrospectives.deleted_at IS NULL").
@@ -111,11 +165,10 @@ func GetTaskTrackerConnectionFromRetro(db *gorm.DB, retroID string) (tasktracker
 		return nil, err
 	}
 
-	connection := tasktracker.GetConnection(taskProviderConfig)
-	if connection == nil {
-		err = errors.New("no valid connection found")
+	connections, err := tasktracker.GetConnections(taskProviderConfig)
+	if err != nil {
 		utils.LogToSentry(err)
-		return nil, err
+		return nil, errors.New("no valid connection found")
 	}
-	return connection, nil
+	return connections, nil
 }
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/iReflect/reflect-app/config"
 	customErrors "github.com/iReflect/reflect-app/libs"
@@ -12,6 +13,7 @@ import (
 	"github.com/jinzhu/gorm"
 
 	userModels "github.com/iReflect/reflect-app/apps/user/models"
+	"github.com/iReflect/reflect-app/db/models/fields"
 	"github.com/qor/admin"
 	"github.com/qor/qor"
 	"github.com/qor/qor/resource"
@@ -58,6 +60,20 @@ type Sprint struct {
 	SyncStatus      []SprintSyncStatus
 	CreatedBy       userModels.User
 	CreatedByID     uint `gorm:"not null"`
+	// ProviderSprintIDs maps the keys of the task provider configs to the IDs of the sprint in the task providers
+	ProviderSprintIDs fields.JSONB `gorm:"type:jsonb; not null; default:'{}'::jsonb"`
+}
+
+// GetProviderSprintIDs returns the IDs of the sprint in the task providers, mapped by the task provider keys
+func (sprint Sprint) GetProviderSprintIDs() (map[string]string, error) {
+	sprintIDs := make(map[string]string)
+	if sprint.ProviderSprintIDs.IsNull() {
+		return sprintIDs, nil
+	}
+	if err := json.Unmarshal(sprint.ProviderSprintIDs, &sprintIDs); err != nil {
+		return nil, err
+	}
+	return sprintIDs, nil
 }
 
 // Validate ...
@@ -144,6 +160,121 @@ func (sprint *Sprint) validateDateContinuity(baseQuery *gorm.DB, statuses []Spri
 	return
 }
 
+// WorkCalendar is the working calendar of a sprint, as per the work week and the holidays of the team of the
+// retrospective
+type WorkCalendar struct {
+	WorkWeek utils.WorkWeek
+	// WorkingDays is the number of the working days of the sprint, excluding the holidays
+	WorkingDays float64
+	// Holidays is the number of the holidays of the team which fall on the working days of the sprint
+	Holidays float64
+	// holidayDates is the set of the dates (in the 'YYYY-MM-DD' format) of the holidays of the team in the sprint
+	holidayDates map[string]bool
+}
+
+// IsWorkingDay checks whether the date (in the server time zone) is a working day as per the work week, and is not
+// a holiday of the team
+func (calendar WorkCalendar) IsWorkingDay(date time.Time) bool {
+	date = utils.InServerTimeZone(date)
+	return calendar.WorkWeek.IsWorkingDay(date) && !calendar.holidayDates[date.Format("2006-01-02")]
+}
+
+// GetWorkCalendar returns the working calendar of the sprint
+func (sprint *Sprint) GetWorkCalendar(db *gorm.DB) (calendar WorkCalendar, err error) {
+	calendars, err := GetWorkCalendars(db, []Sprint{*sprint})
+	if err != nil {
+		return calendar, err
+	}
+	return calendars[sprint.ID], nil
+}
+
+// GetWorkCalendars returns the working calendars of the sprints mapped by the sprint ids, the teams are fetched with
+// a query for all the sprints, and the holidays with a query per team for all of its sprints
+func GetWorkCalendars(db *gorm.DB, sprints []Sprint) (map[uint]WorkCalendar, error) {
+	calendars := make(map[uint]WorkCalendar)
+	var retroIDs []uint
+	for _, sprint := range sprints {
+		retroIDs = append(retroIDs, sprint.RetrospectiveID)
+	}
+	if len(retroIDs) == 0 {
+		return calendars, nil
+	}
+
+	var teams []struct {
+		userModels.Team
+		RetrospectiveID uint
+	}
+	err := db.Model(&userModels.Team{}).
+		Joins("JOIN retrospectives ON retrospectives.team_id = teams.id").
+		Where("retrospectives.id IN (?)", retroIDs).
+		Select("teams.*, retrospectives.id AS retrospective_id").
+		Scan(&teams).Error
+	if err != nil {
+		return nil, err
+	}
+	retroTeams := make(map[uint]userModels.Team)
+	for _, team := range teams {
+		retroTeams[team.RetrospectiveID] = team.Team
+	}
+
+	// The holidays of a team are fetched once for the date range of all of its sprints
+	teamStartDates := make(map[uint]time.Time)
+	teamEndDates := make(map[uint]time.Time)
+	for _, sprint := range sprints {
+		if sprint.StartDate == nil || sprint.EndDate == nil {
+			continue
+		}
+		teamID := retroTeams[sprint.RetrospectiveID].ID
+		startDate, endDate := utils.InServerTimeZone(*sprint.StartDate), utils.InServerTimeZone(*sprint.EndDate)
+		if teamStartDate, ok := teamStartDates[teamID]; !ok || startDate.Before(teamStartDate) {
+			teamStartDates[teamID] = startDate
+		}
+		if teamEndDate, ok := teamEndDates[teamID]; !ok || endDate.After(teamEndDate) {
+			teamEndDates[teamID] = endDate
+		}
+	}
+	teamHolidayDates := make(map[uint][]time.Time)
+	for teamID, startDate := range teamStartDates {
+		teamHolidayDates[teamID], err = userModels.GetTeamHolidayDates(db, teamID, startDate, teamEndDates[teamID])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, sprint := range sprints {
+		team := retroTeams[sprint.RetrospectiveID]
+		calendars[sprint.ID] = newWorkCalendar(sprint, team.GetWorkWeek(), teamHolidayDates[team.ID])
+	}
+	return calendars, nil
+}
+
+// newWorkCalendar builds the working calendar of the sprint from the work week and the holiday dates of its team,
+// the holidays outside the sprint are left out
+func newWorkCalendar(sprint Sprint, workWeek utils.WorkWeek, holidayDates []time.Time) (calendar WorkCalendar) {
+	calendar.WorkWeek = workWeek
+	if sprint.StartDate == nil || sprint.EndDate == nil {
+		return calendar
+	}
+
+	startKey := utils.InServerTimeZone(*sprint.StartDate).Format("2006-01-02")
+	endKey := utils.InServerTimeZone(*sprint.EndDate).Format("2006-01-02")
+	calendar.holidayDates = make(map[string]bool)
+	for _, date := range holidayDates {
+		key := date.Format("2006-01-02")
+		if key < startKey || key > endKey {
+			continue
+		}
+		calendar.holidayDates[key] = true
+		if calendar.WorkWeek.IsWorkingDay(date) {
+			calendar.Holidays++
+		}
+	}
+
+	workingDays := utils.GetWorkingDaysBetweenTwoDates(*sprint.StartDate, *sprint.EndDate, calendar.WorkWeek)
+	calendar.WorkingDays = float64(workingDays) - calendar.Holidays
+	return calendar
+}
+
 // BeforeSave ...
 func (sprint *Sprint) BeforeSave(db *gorm.DB) (err error) {
 	return sprint.Validate(db)
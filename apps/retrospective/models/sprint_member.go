@@ -3,6 +3,7 @@ package models
 import (
 	"errors"
 	"strconv"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/qor/admin"
@@ -12,7 +13,6 @@ import (
 
 	"github.com/iReflect/reflect-app/apps/retrospective"
 	userModels "github.com/iReflect/reflect-app/apps/user/models"
-	"github.com/iReflect/reflect-app/libs/utils"
 )
 
 // SprintMember represents a member of a particular sprint
@@ -28,6 +28,9 @@ type SprintMember struct {
 	Vacations          float64              `gorm:"not null;default:0"`
 	Rating             retrospective.Rating `gorm:"default:2; not null"`
 	Comment            string               `gorm:"type:text"`
+	// TimeLogsSyncedAt is the mark of the last sync of the time logs of the member, the time logs dated before the
+	// mark (less the late time log days) are not fetched again in the next sync. It is reset to fetch all of them.
+	TimeLogsSyncedAt *time.Time
 }
 
 // Validate ...
@@ -42,8 +45,11 @@ func (sprintMember *SprintMember) Validate(db *gorm.DB) (err error) {
 	}
 	// Vacations should not be longer than sprint duration
 	if sprint.StartDate != nil && sprint.EndDate != nil {
-		sprintWorkingDays := utils.GetWorkingDaysBetweenTwoDates(*sprint.StartDate, *sprint.EndDate)
-		if sprintMember.Vacations > float64(sprintWorkingDays) {
+		calendar, err := sprint.GetWorkCalendar(db)
+		if err != nil {
+			return errors.New("cannot get the working days of the sprint")
+		}
+		if sprintMember.Vacations > calendar.WorkingDays {
 			err = errors.New("vacations cannot be longer than sprint duration")
 			return err
 		}
@@ -19,6 +19,7 @@ import (
 var MemberTaskRoleValues = [...]string{
 	"Developer",
 	"Reviewer",
+	"Validator",
 }
 
 // MemberTaskRole ...
@@ -33,6 +34,7 @@ func (role MemberTaskRole) GetStringValue() string {
 const (
 	Developer MemberTaskRole = iota
 	Reviewer
+	Validator
 )
 
 // SprintMemberTask represents a task for a member for a particular sprint
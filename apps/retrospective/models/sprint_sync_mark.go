@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// SprintSyncMark is the high-water mark of the incremental sync of a sprint for a task provider, the tasks
+// updated in the task provider after the mark are fetched in the next sync of the sprint
+type SprintSyncMark struct {
+	gorm.Model
+	Sprint      Sprint
+	SprintID    uint      `gorm:"not null; unique_index:idx_sprint_sync_mark_sprint_provider"`
+	ProviderKey string    `gorm:"type:varchar(50); not null; unique_index:idx_sprint_sync_mark_sprint_provider"`
+	SyncedAt    time.Time `gorm:"not null"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/iReflect/reflect-app/db/models/fields"
+)
+
+// SyncTrigger is what triggered a sprint sync run
+type SyncTrigger string
+
+// SyncTrigger
+const (
+	// ManualSyncTrigger is a sync triggered by a user action, eg. refreshing or activating the sprint
+	ManualSyncTrigger SyncTrigger = "manual"
+	// JobSyncTrigger is a sync queued by the scheduler as per the sync schedule of the retrospective
+	JobSyncTrigger SyncTrigger = "job"
+	// WebhookSyncTrigger is a sync of a task changed in the task tracker, received through a webhook
+	WebhookSyncTrigger SyncTrigger = "webhook"
+)
+
+// SprintSyncRun records a sync run of a sprint, along with the counts of the processed tasks
+// and time logs, and the errors of the steps of the run
+type SprintSyncRun struct {
+	gorm.Model
+	Sprint            Sprint
+	SprintID          uint        `gorm:"not null"`
+	Trigger           SyncTrigger `gorm:"type:varchar(20); not null"`
+	FullResync        bool        `gorm:"not null; default:false"`
+	Status            SyncStatus  `gorm:"default:0; not null"`
+	StartedAt         time.Time   `gorm:"not null"`
+	EndedAt           *time.Time
+	TasksFetched      uint         `gorm:"not null; default:0"`
+	TasksInserted     uint         `gorm:"not null; default:0"`
+	TasksUpdated      uint         `gorm:"not null; default:0"`
+	TimeLogsProcessed uint         `gorm:"not null; default:0"`
+	Errors            fields.JSONB `gorm:"type:jsonb; not null; default:'[]'::jsonb"`
+}
+
+// SyncRunError is an error in a step of a sprint sync run
+type SyncRunError struct {
+	Step    string `json:"step"`
+	Message string `json:"message"`
+}
+
+// Steps of a sprint sync run
+const (
+	SyncStepConfig            = "config"
+	SyncStepTaskTracker       = "task_tracker"
+	SyncStepTimeTracker       = "time_tracker"
+	SyncStepTimeTrackerTasks  = "time_tracker_tasks"
+	SyncStepSprintMemberTasks = "sprint_member_tasks"
+)
@@ -37,12 +37,16 @@ const (
 	Queued
 )
 
-// SprintSyncStatus stores the sync history of a sprint
+// SprintSyncStatus stores the sync history of a sprint. The queued syncs of the sprint also store their options,
+// since the syncs queued while one is pending are run as one.
 type SprintSyncStatus struct {
 	gorm.Model
-	SprintID uint `gorm:"not null"`
-	Sprint   Sprint
-	Status   SyncStatus `gorm:"default:0; not null"`
+	SprintID     uint `gorm:"not null"`
+	Sprint       Sprint
+	Status       SyncStatus  `gorm:"default:0; not null"`
+	Trigger      SyncTrigger `gorm:"type:varchar(20); not null; default:''"`
+	FullResync   bool        `gorm:"not null; default:false"`
+	AssignPoints bool        `gorm:"not null; default:false"`
 }
 
 // Validate ...
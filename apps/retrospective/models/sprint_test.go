@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+func TestNewWorkCalendar(t *testing.T) {
+	workWeek, _ := utils.ParseWorkWeek(utils.DefaultWorkWeek)
+	// Mon 4 June to Fri 15 June 2018, the holidays of the team span all of its sprints
+	startDate := time.Date(2018, 6, 4, 12, 0, 0, 0, time.UTC)
+	endDate := time.Date(2018, 6, 15, 12, 0, 0, 0, time.UTC)
+	sprint := Sprint{StartDate: &startDate, EndDate: &endDate}
+	holidayDates := []time.Time{
+		time.Date(2018, 5, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, 6, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, 6, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, 6, 20, 0, 0, 0, 0, time.UTC),
+	}
+
+	calendar := newWorkCalendar(sprint, workWeek, holidayDates)
+	if calendar.Holidays != 1 || calendar.WorkingDays != 9 {
+		t.Errorf("Expected 1 holiday and 9 working days, got %v holidays and %v working days",
+			calendar.Holidays, calendar.WorkingDays)
+	}
+	if calendar.IsWorkingDay(time.Date(2018, 6, 6, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the holiday not to be a working day")
+	}
+	if !calendar.IsWorkingDay(time.Date(2018, 6, 7, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the day after the holiday to be a working day")
+	}
+
+	calendar = newWorkCalendar(Sprint{}, workWeek, holidayDates)
+	if calendar.Holidays != 0 || calendar.WorkingDays != 0 {
+		t.Errorf("Expected no working days for a sprint without the dates, got %v", calendar.WorkingDays)
+	}
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
+// Sync schedule policies of a retrospective, any other value is treated as a standard cron expression
+// (eg. '30 18 * * 1-5'). The schedules are evaluated in the server time zone.
+const (
+	// ManualSync disables the scheduled sync, the sprints are synced only when triggered
+	ManualSync = ""
+	// HourlySync syncs the active sprints at the start of every hour
+	HourlySync = "hourly"
+	// NightlySync syncs the active sprints every midnight
+	NightlySync = "nightly"
+	// LastDayHourlySync syncs the active sprints every midnight, and every hour on the last day of the sprint
+	LastDayHourlySync = "last-day-hourly"
+)
+
+var (
+	hourlySchedule, _  = cron.ParseStandard("@hourly")
+	nightlySchedule, _ = cron.ParseStandard("@midnight")
+)
+
+// ValidateSyncSchedule checks that the sync schedule is either a known policy or a valid cron expression
+func ValidateSyncSchedule(syncSchedule string) error {
+	switch syncSchedule {
+	case ManualSync, HourlySync, NightlySync, LastDayHourlySync:
+		return nil
+	}
+	if _, err := cron.ParseStandard(syncSchedule); err != nil {
+		return errors.New("sync schedule should be one of hourly, nightly, last-day-hourly or a valid cron expression")
+	}
+	return nil
+}
+
+// NextSyncTime returns the time of the first scheduled sync of the sprint after the given time, in the location of
+// the given time. The zero time is returned if the sync is not scheduled.
+func NextSyncTime(syncSchedule string, sprintEndDate *time.Time, after time.Time) (time.Time, error) {
+	switch syncSchedule {
+	case ManualSync:
+		return time.Time{}, nil
+	case HourlySync:
+		return hourlySchedule.Next(after), nil
+	case NightlySync:
+		return nightlySchedule.Next(after), nil
+	case LastDayHourlySync:
+		nextSync := hourlySchedule.Next(after)
+		if sprintEndDate != nil && isSameDate(nextSync, sprintEndDate.In(after.Location())) {
+			return nextSync, nil
+		}
+		return nightlySchedule.Next(after), nil
+	}
+
+	schedule, err := cron.ParseStandard(syncSchedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(after), nil
+}
+
+func isSameDate(first time.Time, second time.Time) bool {
+	firstYear, firstMonth, firstDay := first.Date()
+	secondYear, secondMonth, secondDay := second.Date()
+	return firstYear == secondYear && firstMonth == secondMonth && firstDay == secondDay
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextSyncTime(t *testing.T) {
+	location := time.FixedZone("IST", 19800)
+	endDate := time.Date(2018, 6, 8, 0, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name         string
+		syncSchedule string
+		after        time.Time
+		nextSync     time.Time
+	}{
+		{"manual", ManualSync, time.Date(2018, 6, 4, 10, 30, 0, 0, location), time.Time{}},
+		{"hourly", HourlySync, time.Date(2018, 6, 4, 10, 30, 0, 0, location), time.Date(2018, 6, 4, 11, 0, 0, 0, location)},
+		{"nightly", NightlySync, time.Date(2018, 6, 4, 10, 30, 0, 0, location), time.Date(2018, 6, 5, 0, 0, 0, 0, location)},
+		{"last day hourly before the last day", LastDayHourlySync, time.Date(2018, 6, 4, 10, 30, 0, 0, location), time.Date(2018, 6, 5, 0, 0, 0, 0, location)},
+		{"last day hourly on the last day", LastDayHourlySync, time.Date(2018, 6, 8, 10, 30, 0, 0, location), time.Date(2018, 6, 8, 11, 0, 0, 0, location)},
+		{"last day hourly at the end of the last day", LastDayHourlySync, time.Date(2018, 6, 8, 23, 30, 0, 0, location), time.Date(2018, 6, 9, 0, 0, 0, 0, location)},
+		{"cron expression", "30 18 * * 1-5", time.Date(2018, 6, 8, 19, 0, 0, 0, location), time.Date(2018, 6, 11, 18, 30, 0, 0, location)},
+	}
+
+	for _, testCase := range testCases {
+		nextSync, err := NextSyncTime(testCase.syncSchedule, &endDate, testCase.after)
+		if err != nil {
+			t.Fatalf("%s: error in getting the next sync time - %s", testCase.name, err)
+		}
+		if !nextSync.Equal(testCase.nextSync) {
+			t.Fatalf("%s: next sync should be at %v, got %v", testCase.name, testCase.nextSync, nextSync)
+		}
+	}
+
+	if err := ValidateSyncSchedule("every night"); err == nil {
+		t.Fatalf("Invalid sync schedule should fail")
+	}
+}
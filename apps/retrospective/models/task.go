@@ -19,6 +19,7 @@ type Task struct {
 	gorm.Model
 	Key               string `gorm:"type:varchar(30); not null"`
 	TrackerUniqueID   string `gorm:"type:varchar(255); not null"`
+	ProviderKey       string `gorm:"type:varchar(50); not null; default:''"`
 	Retrospective     Retrospective
 	RetrospectiveID   uint                 `gorm:"not null"`
 	Summary           string               `gorm:"type:text; not null"`
@@ -33,6 +34,13 @@ type Task struct {
 	DoneAt            *time.Time
 	IsTrackerTask     bool `gorm:"not null;default: false"`
 	SprintMemberTasks []SprintMemberTask
+	// TrackerCreatedAt is the time the task was created in the task tracker, if the task provider reports it
+	TrackerCreatedAt *time.Time
+	// ParentKey is the key of the parent task in the task tracker, it is set for the sub-tasks
+	ParentKey string `gorm:"type:varchar(30); not null; default:''"`
+	// EpicKey and EpicName are of the epic of the task in the task tracker, if the task provider supports the epics
+	EpicKey  string `gorm:"type:varchar(30); not null; default:''"`
+	EpicName string `gorm:"type:varchar(255); not null; default:''"`
 }
 
 // Stringify ...
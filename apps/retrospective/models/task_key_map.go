@@ -10,4 +10,6 @@ type TaskKeyMap struct {
 	TaskID uint `gorm:"not null"`
 	Task   Task
 	Key    string `gorm:"type:varchar(30); not null"`
+	// ProviderKey is the key of the task provider of the task, the keys of the different task providers can collide
+	ProviderKey string `gorm:"type:varchar(50); not null; default:''"`
 }
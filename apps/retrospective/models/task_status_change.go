@@ -0,0 +1,195 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	taskTrackerSerializers "github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+)
+
+// TaskStatusChange represents a change of the status of a task. The changes are either fetched from the changelog of
+// the task tracker, or observed during the syncs and the webhooks when the task tracker has no changelog, in which
+// case the change is recorded at the time it was observed. The first observed change of a task has no from status.
+type TaskStatusChange struct {
+	gorm.Model
+	Task            Task
+	TaskID          uint      `gorm:"not null; index"`
+	FromStatus      string    `gorm:"type:varchar(50); not null; default:''"`
+	ToStatus        string    `gorm:"type:varchar(50); not null"`
+	ChangedAt       time.Time `gorm:"not null"`
+	IsFromChangelog bool      `gorm:"not null; default:false"`
+}
+
+// StatusDuration is the time spent by a task in a status
+type StatusDuration struct {
+	Status   string
+	Duration time.Duration
+}
+
+// FlowMetrics are the lead time, the cycle time and the time in status of a task
+type FlowMetrics struct {
+	// CompletedAt is the time the task last moved to a done status, if it is done
+	CompletedAt *time.Time
+	// LeadTime is the time from the creation of the task till its completion
+	LeadTime *time.Duration
+	// CycleTime is the time from the task first moving out of its initial status till its completion
+	CycleTime *time.Duration
+	// TimeInStatus is the time spent in each of the statuses (in the order of their first occurrence), till the
+	// completion of the task, or till now if it is not done
+	TimeInStatus []StatusDuration
+}
+
+// RecordTaskStatusChanges records the status changes of the task. The changelog of the task tracker, if any, replaces
+// the observed changes of the task. Otherwise, the change from the previous status is recorded as observed now, and
+// a new task is recorded with its current status.
+func RecordTaskStatusChanges(
+	db *gorm.DB,
+	taskID uint,
+	isNewTask bool,
+	previousStatus string,
+	status string,
+	changelog []taskTrackerSerializers.StatusChange) error {
+	if len(changelog) == 0 {
+		if !isNewTask && strings.EqualFold(previousStatus, status) {
+			return nil
+		}
+		change := TaskStatusChange{TaskID: taskID, ToStatus: status, ChangedAt: time.Now()}
+		if !isNewTask {
+			change.FromStatus = previousStatus
+		}
+		return db.Create(&change).Error
+	}
+
+	err := db.Where("task_status_changes.deleted_at IS NULL").
+		Where("task_id = ?", taskID).
+		Where("is_from_changelog = false").
+		Delete(&TaskStatusChange{}).Error
+	if err != nil {
+		return err
+	}
+
+	var recordedChanges []TaskStatusChange
+	err = db.Model(&TaskStatusChange{}).
+		Where("task_status_changes.deleted_at IS NULL").
+		Where("task_id = ?", taskID).
+		Find(&recordedChanges).Error
+	if err != nil {
+		return err
+	}
+	recorded := make(map[string]bool)
+	for _, change := range recordedChanges {
+		recorded[change.ToStatus+"|"+change.ChangedAt.UTC().Format(time.RFC3339)] = true
+	}
+
+	for _, change := range changelog {
+		key := change.ToStatus + "|" + change.ChangedAt.UTC().Format(time.RFC3339)
+		if recorded[key] {
+			continue
+		}
+		err = db.Create(&TaskStatusChange{
+			TaskID:          taskID,
+			FromStatus:      change.FromStatus,
+			ToStatus:        change.ToStatus,
+			ChangedAt:       change.ChangedAt,
+			IsFromChangelog: true,
+		}).Error
+		if err != nil {
+			return err
+		}
+		recorded[key] = true
+	}
+	return nil
+}
+
+// GetFlowMetrics computes the flow metrics of a task from its status changes, the done statuses are in lower case.
+// The time before the first observed status of a task is not known, so it is not counted in any status.
+func GetFlowMetrics(createdAt time.Time, changes []taskTrackerSerializers.StatusChange, doneStatuses []string, now time.Time) FlowMetrics {
+	var metrics FlowMetrics
+	if len(changes) == 0 {
+		return metrics
+	}
+	changes = append([]taskTrackerSerializers.StatusChange(nil), changes...)
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].ChangedAt.Before(changes[j].ChangedAt)
+	})
+
+	isDone := func(status string) bool {
+		for _, doneStatus := range doneStatuses {
+			if strings.ToLower(strings.TrimSpace(status)) == doneStatus {
+				return true
+			}
+		}
+		return false
+	}
+
+	// A task is completed when it moved to a done status after which it stayed done
+	completedIndex := len(changes)
+	for completedIndex > 0 && isDone(changes[completedIndex-1].ToStatus) {
+		completedIndex--
+	}
+	endedAt := now
+	if completedIndex < len(changes) {
+		completedAt := changes[completedIndex].ChangedAt
+		metrics.CompletedAt = &completedAt
+		endedAt = completedAt
+	}
+
+	if createdAt.IsZero() || changes[0].ChangedAt.Before(createdAt) {
+		createdAt = changes[0].ChangedAt
+	}
+	if metrics.CompletedAt != nil {
+		leadTime := metrics.CompletedAt.Sub(createdAt)
+		metrics.LeadTime = &leadTime
+	}
+
+	type statusSpan struct {
+		status string
+		from   time.Time
+		to     time.Time
+	}
+	var spans []statusSpan
+	initialStatus := changes[0].FromStatus
+	if initialStatus == "" {
+		initialStatus = changes[0].ToStatus
+	} else {
+		spans = append(spans, statusSpan{status: initialStatus, from: createdAt, to: changes[0].ChangedAt})
+	}
+	for index, change := range changes {
+		to := now
+		if index+1 < len(changes) {
+			to = changes[index+1].ChangedAt
+		}
+		spans = append(spans, statusSpan{status: change.ToStatus, from: change.ChangedAt, to: to})
+	}
+
+	for _, change := range changes {
+		if change.FromStatus != "" && !strings.EqualFold(change.ToStatus, initialStatus) {
+			if metrics.CompletedAt != nil && !change.ChangedAt.After(*metrics.CompletedAt) {
+				cycleTime := metrics.CompletedAt.Sub(change.ChangedAt)
+				metrics.CycleTime = &cycleTime
+			}
+			break
+		}
+	}
+
+	durations := make(map[string]time.Duration)
+	for _, span := range spans {
+		if span.to.After(endedAt) {
+			span.to = endedAt
+		}
+		if !span.from.Before(span.to) {
+			continue
+		}
+		if _, exists := durations[span.status]; !exists {
+			metrics.TimeInStatus = append(metrics.TimeInStatus, StatusDuration{Status: span.status})
+		}
+		durations[span.status] += span.to.Sub(span.from)
+	}
+	for index := range metrics.TimeInStatus {
+		metrics.TimeInStatus[index].Duration = durations[metrics.TimeInStatus[index].Status]
+	}
+	return metrics
+}
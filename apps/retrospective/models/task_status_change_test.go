@@ -0,0 +1,71 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	taskTrackerSerializers "github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+)
+
+func TestGetFlowMetrics(t *testing.T) {
+	day := func(day int, hour int) time.Time {
+		return time.Date(2018, 6, day, hour, 0, 0, 0, time.UTC)
+	}
+	doneStatuses := []string{"done", "accepted"}
+	changes := []taskTrackerSerializers.StatusChange{
+		{FromStatus: "In Progress", ToStatus: "In Review", ChangedAt: day(5, 10)},
+		{FromStatus: "To Do", ToStatus: "In Progress", ChangedAt: day(4, 10)},
+		{FromStatus: "In Review", ToStatus: "In Progress", ChangedAt: day(6, 10)},
+		{FromStatus: "In Progress", ToStatus: "Done", ChangedAt: day(7, 10)},
+		{FromStatus: "Done", ToStatus: "Accepted", ChangedAt: day(7, 12)},
+	}
+
+	metrics := GetFlowMetrics(day(1, 10), changes, doneStatuses, day(10, 10))
+	if metrics.CompletedAt == nil || !metrics.CompletedAt.Equal(day(7, 10)) {
+		t.Fatalf("Expected the task to be completed at %s, got %v", day(7, 10), metrics.CompletedAt)
+	}
+	if metrics.LeadTime == nil || *metrics.LeadTime != 6*24*time.Hour {
+		t.Errorf("Expected a lead time of 6 days, got %v", metrics.LeadTime)
+	}
+	if metrics.CycleTime == nil || *metrics.CycleTime != 3*24*time.Hour {
+		t.Errorf("Expected a cycle time of 3 days, got %v", metrics.CycleTime)
+	}
+	expectedTimeInStatus := []StatusDuration{
+		{"To Do", 3 * 24 * time.Hour},
+		{"In Progress", 2 * 24 * time.Hour},
+		{"In Review", 24 * time.Hour},
+	}
+	if len(metrics.TimeInStatus) != len(expectedTimeInStatus) {
+		t.Fatalf("Expected the time in %d statuses, got %v", len(expectedTimeInStatus), metrics.TimeInStatus)
+	}
+	for index, expected := range expectedTimeInStatus {
+		if metrics.TimeInStatus[index] != expected {
+			t.Errorf("Expected %v in status, got %v", expected, metrics.TimeInStatus[index])
+		}
+	}
+
+	// A reopened task is not completed, and the observed changes have no time before the first observation
+	observedChanges := []taskTrackerSerializers.StatusChange{
+		{FromStatus: "", ToStatus: "In Progress", ChangedAt: day(4, 10)},
+		{FromStatus: "In Progress", ToStatus: "Done", ChangedAt: day(5, 10)},
+		{FromStatus: "Done", ToStatus: "Reopened", ChangedAt: day(6, 10)},
+	}
+	metrics = GetFlowMetrics(day(1, 10), observedChanges, doneStatuses, day(8, 10))
+	if metrics.CompletedAt != nil || metrics.LeadTime != nil || metrics.CycleTime != nil {
+		t.Errorf("Reopened task should not be completed, got %v", metrics.CompletedAt)
+	}
+	expectedTimeInStatus = []StatusDuration{
+		{"In Progress", 24 * time.Hour},
+		{"Done", 24 * time.Hour},
+		{"Reopened", 2 * 24 * time.Hour},
+	}
+	for index, expected := range expectedTimeInStatus {
+		if index >= len(metrics.TimeInStatus) || metrics.TimeInStatus[index] != expected {
+			t.Errorf("Expected %v in status, got %v", expected, metrics.TimeInStatus)
+		}
+	}
+
+	if metrics = GetFlowMetrics(day(1, 10), nil, doneStatuses, day(8, 10)); len(metrics.TimeInStatus) != 0 {
+		t.Errorf("Expected no metrics without the status changes, got %v", metrics)
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
+)
+
+// UploadedTimeLog represents a time log of a sprint member imported from an uploaded file,
+// these are used along with the time tracker logs while syncing the sprint
+type UploadedTimeLog struct {
+	gorm.Model
+	SprintMember   SprintMember
+	SprintMemberID uint      `gorm:"not null"`
+	TaskKey        string    `gorm:"type:varchar(30); not null"`
+	Minutes        uint      `gorm:"not null"`
+	Date           time.Time `gorm:"type:date; not null"`
+	UploadedBy     userModels.User
+	UploadedByID   uint `gorm:"not null"`
+}
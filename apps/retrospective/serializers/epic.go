@@ -0,0 +1,40 @@
+package serializers
+
+import "time"
+
+// EpicSprintRollup is the effort on the tasks of an epic in a sprint
+type EpicSprintRollup struct {
+	SprintID     uint
+	Title        string
+	StartDate    *time.Time
+	EndDate      *time.Time
+	TaskCount    int
+	PointsEarned float64
+	HoursSpent   float64
+}
+
+// EpicRollup is the progress of the tasks of an epic across the sprints of a retrospective
+type EpicRollup struct {
+	EpicKey string
+	// ProviderKey is the key of the task provider of the epic, the epic keys of the task providers can collide
+	ProviderKey   string
+	EpicName      string
+	TaskCount     int
+	DoneTaskCount int
+	// SubTaskCount is the number of the tasks which are counted in the epic through their parent tasks
+	SubTaskCount int
+	Estimate     float64
+	DonePoints   float64
+	PointsEarned float64
+	HoursSpent   float64
+	// Completion is the ratio of the done points to the estimate, or of the done tasks if the tasks are not estimated
+	Completion float64
+	Sprints    []EpicSprintRollup
+}
+
+// EpicRollupReport is the progress of the epics of a retrospective
+type EpicRollupReport struct {
+	Epics []EpicRollup
+	// UnassignedTaskCount is the number of the tasks which are not in any epic
+	UnassignedTaskCount int
+}
@@ -0,0 +1,48 @@
+package serializers
+
+// EstimationAccuracy is the estimated story points against the hours actually spent on a set of the completed tasks
+type EstimationAccuracy struct {
+	TaskCount        int
+	StoryPoints      float64
+	HoursSpent       float64
+	HrsPerStoryPoint float64
+	// Accuracy is the hours spent per story point relative to the hours per story point of the retrospective,
+	// above 1 if the tasks took longer than estimated
+	Accuracy float64
+}
+
+// TaskTypeEstimationAccuracy ...
+type TaskTypeEstimationAccuracy struct {
+	TaskType string
+	EstimationAccuracy
+	// Flag is set to 'under-estimated' or 'over-estimated' for the task types which are consistently mis-estimated
+	Flag string
+}
+
+// MemberEstimationAccuracy is the estimation accuracy of the points earned by a member
+type MemberEstimationAccuracy struct {
+	MemberID  uint
+	FirstName string
+	LastName  string
+	EstimationAccuracy
+}
+
+// SprintEstimationAccuracy is the estimation accuracy of the points earned in a sprint
+type SprintEstimationAccuracy struct {
+	SprintID uint
+	Title    string
+	EstimationAccuracy
+}
+
+// EstimationAccuracyReport ...
+type EstimationAccuracyReport struct {
+	StoryPointPerWeek float64
+	HrsPerStoryPoint  float64
+	// The suggestions are nil if there are no completed tasks with the time spent on them
+	SuggestedStoryPointPerWeek *float64
+	SuggestedHrsPerStoryPoint  *float64
+	Overall                    EstimationAccuracy
+	TaskTypes                  []TaskTypeEstimationAccuracy
+	Members                    []MemberEstimationAccuracy
+	Sprints                    []SprintEstimationAccuracy
+}
@@ -0,0 +1,49 @@
+package serializers
+
+import "time"
+
+// TaskStatusChange is a change of the status of a task
+type TaskStatusChange struct {
+	FromStatus string
+	ToStatus   string
+	ChangedAt  time.Time
+	// IsFromChangelog is set if the change is from the changelog of the task tracker, else it is observed in a sync
+	IsFromChangelog bool
+}
+
+// StatusTime is the time spent by a task in a status
+type StatusTime struct {
+	Status string
+	Hours  float64
+}
+
+// TaskFlowMetrics are the lead time, the cycle time and the time in status of a task
+type TaskFlowMetrics struct {
+	SprintTaskID   uint
+	Key            string
+	Summary        string
+	Status         string
+	CompletedAt    *time.Time
+	LeadTimeHours  *float64
+	CycleTimeHours *float64
+	TimeInStatus   []StatusTime
+}
+
+// TaskStatusHistory is the status history of a task with its flow metrics
+type TaskStatusHistory struct {
+	TaskFlowMetrics
+	StatusChanges []TaskStatusChange
+}
+
+// SprintFlowMetrics are the flow metrics of the tasks of a sprint, the lead and the cycle times are summarised over
+// the completed tasks
+type SprintFlowMetrics struct {
+	Tasks                 []TaskFlowMetrics
+	CompletedTaskCount    int
+	AverageLeadTimeHours  *float64
+	MedianLeadTimeHours   *float64
+	AverageCycleTimeHours *float64
+	MedianCycleTimeHours  *float64
+	// TimeInStatus is the total time spent by the tasks in each of the statuses
+	TimeInStatus []StatusTime
+}
@@ -9,30 +9,52 @@ import (
 
 // Retrospective ...
 type Retrospective struct {
-	ID                 uint
-	Title              string
-	ProjectName        string
-	Team               userSerializer.Team
-	TeamID             uint
-	UpdatedAt          time.Time
-	CreatedBy          userSerializer.User
-	CreatedByID        uint
-	CreatedAt          time.Time
-	TaskProviderConfig fields.JSONB
-	StoryPointPerWeek  float64
+	ID                      uint
+	Title                   string
+	ProjectName             string
+	Team                    userSerializer.Team
+	TeamID                  uint
+	UpdatedAt               time.Time
+	CreatedBy               userSerializer.User
+	CreatedByID             uint
+	CreatedAt               time.Time
+	TaskProviderConfig      fields.JSONB
+	StoryPointPerWeek       float64
+	SyncSchedule            string
+	PointAllocationStrategy string
+	RoleWeights             fields.JSONB
 }
 
 // RetrospectiveCreateSerializer ...
 type RetrospectiveCreateSerializer struct {
-	Title              string                   `json:"title" binding:"required"`
-	ProjectName        string                   `json:"projectName" binding:"required"`
-	TaskProviderConfig []map[string]interface{} `json:"taskProvider" binding:"required,is_valid_task_provider_config"`
-	TeamID             uint                     `json:"team" binding:"required,is_valid_team"`
-	StoryPointPerWeek  float64                  `json:"storyPointPerWeek" binding:"required"`
-	CreatedByID        uint
+	Title                   string                   `json:"title" binding:"required"`
+	ProjectName             string                   `json:"projectName" binding:"required"`
+	TaskProviderConfig      []map[string]interface{} `json:"taskProvider" binding:"required,is_valid_task_provider_config"`
+	TeamID                  uint                     `json:"team" binding:"required,is_valid_team"`
+	StoryPointPerWeek       float64                  `json:"storyPointPerWeek" binding:"required"`
+	SyncSchedule            string                   `json:"syncSchedule"`
+	PointAllocationStrategy string                   `json:"pointAllocationStrategy"`
+	RoleWeights             map[string]float64       `json:"roleWeights"`
+	CreatedByID             uint
+}
+
+// RetrospectivePointAllocationSerializer ...
+type RetrospectivePointAllocationSerializer struct {
+	PointAllocationStrategy string             `json:"pointAllocationStrategy" binding:"required"`
+	RoleWeights             map[string]float64 `json:"roleWeights"`
+}
+
+// RetrospectiveSyncScheduleSerializer ...
+type RetrospectiveSyncScheduleSerializer struct {
+	SyncSchedule string `json:"syncSchedule"`
 }
 
 // RetrospectiveListSerializer ...
 type RetrospectiveListSerializer struct {
 	Retrospectives []Retrospective
 }
+
+// WebhookSecret is the secret used to authenticate the task tracker webhooks of a retrospective
+type WebhookSecret struct {
+	Secret string
+}
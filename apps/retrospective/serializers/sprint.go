@@ -5,6 +5,7 @@ import (
 	"time"
 
 	userSerializer "github.com/iReflect/reflect-app/apps/user/serializers"
+	"github.com/iReflect/reflect-app/db/models/fields"
 )
 
 // Sprint is a serializer used in the Get sprint APIs
@@ -23,6 +24,8 @@ type Sprint struct {
 	Summary         SprintSummary
 	Editable        *bool
 	Deletable       bool
+	// ProviderSprintIDs maps the keys of the task provider configs to the IDs of the sprint in the task providers
+	ProviderSprintIDs fields.JSONB
 }
 
 // SetEditable ...
@@ -64,13 +67,15 @@ type AddMemberSerializer struct {
 	MemberID uint `json:"memberID" binding:"required"`
 }
 
-// CreateSprintSerializer is used in sprint create API
+// CreateSprintSerializer is used in sprint create API, the sprint ID is of the first task provider and the provider
+// sprint IDs are of the task providers mapped by their provider keys
 type CreateSprintSerializer struct {
-	Title       string     `json:"title" binding:"required"`
-	SprintID    string     `json:"sprintID" binding:"is_valid_sprint"`
-	StartDate   *time.Time `json:"startDate"`
-	EndDate     *time.Time `json:"endDate"`
-	CreatedByID uint
+	Title             string            `json:"title" binding:"required"`
+	SprintID          string            `json:"sprintID" binding:"is_valid_sprint"`
+	ProviderSprintIDs map[string]string `json:"providerSprintIDs"`
+	StartDate         *time.Time        `json:"startDate"`
+	EndDate           *time.Time        `json:"endDate"`
+	CreatedByID       uint
 }
 
 // UpdateSprintSerializer is used in sprint create API
@@ -0,0 +1,45 @@
+package serializers
+
+import (
+	"time"
+)
+
+// BurndownDay is the burndown of a sprint on a day, the actual values are nil for the days yet to come
+type BurndownDay struct {
+	Date         time.Time
+	IsWorkingDay bool
+	// IdealRemainingPoints burns the initial scope of the sprint evenly over its working days
+	IdealRemainingPoints float64
+	RemainingPoints      *float64
+	// AddedPoints is the estimate of the tasks added to the sprint on the day
+	AddedPoints *float64
+	// RemovedPoints is the estimate of the tasks removed from the sprint on the day
+	RemovedPoints *float64
+	LoggedHours   *float64
+}
+
+// SprintBurndownSerializer ...
+type SprintBurndownSerializer struct {
+	WorkingDays  float64
+	InitialScope float64
+	Days         []BurndownDay
+}
+
+// BurnupDay is the burnup of a sprint on a day, the actual values are nil for the days yet to come
+type BurnupDay struct {
+	Date         time.Time
+	IsWorkingDay bool
+	// IdealCompletedPoints completes the initial scope of the sprint evenly over its working days
+	IdealCompletedPoints float64
+	ScopePoints          *float64
+	CompletedPoints      *float64
+	// LoggedHours is the total time logged in the sprint till the day
+	LoggedHours *float64
+}
+
+// SprintBurnupSerializer ...
+type SprintBurnupSerializer struct {
+	WorkingDays  float64
+	InitialScope float64
+	Days         []BurnupDay
+}
@@ -20,9 +20,9 @@ type SprintMemberSummary struct {
 	ExpectedStoryPoint  float64
 }
 
-// SetExpectedStoryPoint ...
-func (member *SprintMemberSummary) SetExpectedStoryPoint(sprint models.Sprint, retro models.Retrospective) {
-	member.ExpectedStoryPoint = utils.CalculateExpectedSP(*sprint.StartDate, *sprint.EndDate,
+// SetExpectedStoryPoint sets the expected story points of the member as per the working calendar of the sprint
+func (member *SprintMemberSummary) SetExpectedStoryPoint(calendar models.WorkCalendar, retro models.Retrospective) {
+	member.ExpectedStoryPoint = utils.CalculateExpectedSP(calendar.WorkingDays, calendar.WorkWeek.DaysPerWeek(),
 		member.Vacations, member.ExpectationPercent, member.AllocationPercent, retro.StoryPointPerWeek)
 }
 
@@ -0,0 +1,27 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/iReflect/reflect-app/db/models/fields"
+)
+
+// SprintSyncRun ...
+type SprintSyncRun struct {
+	ID                uint
+	Trigger           string
+	FullResync        bool
+	Status            int8
+	StartedAt         time.Time
+	EndedAt           *time.Time
+	TasksFetched      uint
+	TasksInserted     uint
+	TasksUpdated      uint
+	TimeLogsProcessed uint
+	Errors            fields.JSONB
+}
+
+// SprintSyncRunsSerializer ...
+type SprintSyncRunsSerializer struct {
+	SyncRuns []SprintSyncRun
+}
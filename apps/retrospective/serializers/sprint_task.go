@@ -6,12 +6,16 @@ import "time"
 type SprintTask struct {
 	ID                   uint
 	Key                  string
+	ProviderKey          string
 	URL                  string
 	Summary              string
 	Description          string
 	Type                 string
 	Status               string
 	Priority             string
+	ParentKey            string // Key of the parent task of a sub-task
+	EpicKey              string
+	EpicName             string
 	IsTrackerTask        bool
 	IsInvalid            bool
 	Rating               int8
@@ -28,6 +32,45 @@ type SprintTask struct {
 	SprintTime           uint   // Time spent on the task in the sprint
 	TotalTime            uint   // Total time spent on the task across the sprints
 	DoneAt               *time.Time
+	IsCarriedOver        bool               `gorm:"-"` // Whether the task was in the previous sprint and was not done by its end
+	SprintCount          int                `gorm:"-"` // Number of the sprints the task has spanned till the sprint
+	SprintEfforts        []TaskSprintEffort `gorm:"-"` // Effort on the task in each of the sprints it has spanned
+}
+
+// TaskSprintEffort is the effort on a task in a sprint
+type TaskSprintEffort struct {
+	SprintID         uint
+	Title            string
+	StartDate        *time.Time
+	EndDate          *time.Time
+	TimeSpentMinutes uint
+	PointsEarned     float64
+}
+
+// SpilloverTask ...
+type SpilloverTask struct {
+	SprintTaskID  uint
+	Key           string
+	Summary       string
+	Type          string
+	Status        string
+	Estimate      float64
+	DoneAt        *time.Time
+	SprintCount   int                `gorm:"-"`
+	SprintEfforts []TaskSprintEffort `gorm:"-"`
+}
+
+// SpilloverSummary is the carry-over of the tasks into a sprint and their spillover out of it
+type SpilloverSummary struct {
+	TaskCount int
+	// CarriedOverTasks were in the previous sprint and were not done by its end
+	CarriedOverTasks  []SpilloverTask
+	CarriedOverPoints float64
+	// SpilledOverTasks were not done by the end of the sprint
+	SpilledOverTasks  []SpilloverTask
+	SpilledOverPoints float64
+	// ChronicTasks are the spilled over tasks which have spanned three or more sprints
+	ChronicTasks []SpilloverTask
 }
 
 // SprintTasksSerializer ...
@@ -0,0 +1,39 @@
+package serializers
+
+import (
+	"time"
+
+	timeTrackerSerializers "github.com/iReflect/reflect-app/apps/timetracker/serializers"
+)
+
+// TimeLogImportReport is the validation report of an uploaded time log file
+type TimeLogImportReport struct {
+	DryRun       bool
+	TotalRows    int
+	ImportedRows int
+	// Errors are the rows which are rejected, no rows are imported if there are any errors
+	Errors []timeTrackerSerializers.TimeLogRowError
+	// UnknownMemberEmails are the loggers which are not members of the sprint
+	UnknownMemberEmails []string
+	// UnknownTaskKeys are neither present in the retrospective nor in the task trackers,
+	// they are imported as non task tracker tasks, same as the unknown time tracker task keys
+	UnknownTaskKeys []string
+}
+
+// DailyTimeLog is the time logged by a sprint member on a task of the sprint on a day
+type DailyTimeLog struct {
+	Date           time.Time
+	SprintMemberID uint
+	FirstName      string
+	LastName       string
+	SprintTaskID   uint
+	TaskKey        string
+	Minutes        uint
+}
+
+// SprintDailyTimeLogsSerializer is the time logged per day on the tasks of a sprint
+type SprintDailyTimeLogsSerializer struct {
+	TimeLogs []DailyTimeLog
+	// LateTimeLogs are logged after the end of the sprint, they are not counted in the time spent in the sprint
+	LateTimeLogs []DailyTimeLog
+}
@@ -18,6 +18,7 @@ func IsValidSprint(
 	param string,
 ) bool {
 	sprintID := currentStruct.Interface().(*retroSerializers.CreateSprintSerializer).SprintID
+	providerSprintIDs := currentStruct.Interface().(*retroSerializers.CreateSprintSerializer).ProviderSprintIDs
 	startDate := currentStruct.Interface().(*retroSerializers.CreateSprintSerializer).StartDate
 	endDate := currentStruct.Interface().(*retroSerializers.CreateSprintSerializer).EndDate
 
@@ -25,7 +26,7 @@ func IsValidSprint(
 		return startDate.Before(*endDate)
 	}
 
-	if sprintID != "" && (startDate == nil && endDate == nil) {
+	if (sprintID != "" || len(providerSprintIDs) != 0) && (startDate == nil && endDate == nil) {
 		return true
 	}
 
@@ -0,0 +1,52 @@
+package serializers
+
+import (
+	"time"
+)
+
+// SprintVelocity is the committed and the completed story points of a completed sprint
+type SprintVelocity struct {
+	SprintID  uint
+	Title     string
+	StartDate *time.Time
+	EndDate   *time.Time
+	// CommittedPoints is the estimate of the tasks in the sprint at its start, nil if the sprint was first synced
+	// after its start, since the tasks it started with are not known then
+	CommittedPoints *float64
+	// CompletedPoints is the estimate of the tasks completed in the sprint, including the ones added after the start
+	CompletedPoints float64
+	TargetSP        float64
+	TotalOutput     float64
+	// RollingVelocity is the average of the completed points of the sprint and its previous sprints in the window
+	RollingVelocity float64
+}
+
+// VelocityForecast is the range of the total story points completed till a future sprint
+type VelocityForecast struct {
+	Sprint int
+	// ConfidentPoints are completed in 85% of the simulations
+	ConfidentPoints float64
+	// LikelyPoints are completed in 50% of the simulations
+	LikelyPoints float64
+	// OptimisticPoints are completed in 15% of the simulations
+	OptimisticPoints float64
+}
+
+// BacklogForecast is the range of the number of the sprints needed to complete a backlog, the sprints are nil
+// if the backlog is not completed within the simulated sprints
+type BacklogForecast struct {
+	Backlog float64
+	// LikelySprints are enough in 50% of the simulations
+	LikelySprints *float64
+	// ConfidentSprints are enough in 85% of the simulations
+	ConfidentSprints *float64
+}
+
+// RetrospectiveVelocitySerializer ...
+type RetrospectiveVelocitySerializer struct {
+	Sprints         []SprintVelocity
+	AverageVelocity float64
+	RollingWindow   int
+	Forecast        []VelocityForecast
+	BacklogForecast *BacklogForecast
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	timeTrackerSerializers "github.com/iReflect/reflect-app/apps/timetracker/serializers"
+	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// lateTimeLogDays is the number of the days after the end of a sprint for which the time logged on the sprint tasks
+// is fetched, to warn about the time logged after the sprint end
+const lateTimeLogDays = 7
+
+// sprintMemberTaskLog is the time spent by a sprint member on a task, with one of the keys of the task
+type sprintMemberTaskLog struct {
+	ID               uint
+	Key              string
+	ProviderKey      string
+	TimeSpentMinutes uint
+}
+
+// isLoggedAfterSprintEnd checks whether the time log is dated after the end date of the sprint
+func isLoggedAfterSprintEnd(timeLog timeTrackerSerializers.TimeLog, sprint retroModels.Sprint) bool {
+	if timeLog.Date.IsZero() || sprint.EndDate == nil {
+		return false
+	}
+	// The dates are compared as YYYY-MM-DD strings, which sort chronologically
+	sprintEndDate := utils.InServerTimeZone(*sprint.EndDate).Format(constants.CustomDateFormat)
+	return timeLog.Date.Format(constants.CustomDateFormat) > sprintEndDate
+}
+
+// getSprintMemberTaskLogs returns the time spent by the sprint member on the tasks, a task is repeated for each of
+// its keys
+func (service SprintService) getSprintMemberTaskLogs(sprintMemberID uint) ([]sprintMemberTaskLog, error) {
+	db := service.DB
+	var sprintMemberTaskLogs []sprintMemberTaskLog
+	err := db.Model(&retroModels.SprintMemberTask{}).
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Where("sprint_member_id = ?", sprintMemberID).
+		Scopes(retroModels.SMTJoinST, retroModels.STJoinTask, retroModels.TaskJoinTaskKeyMaps).
+		Select("sprint_member_tasks.id, task_key_maps.key, task_key_maps.provider_key, " +
+			"sprint_member_tasks.time_spent_minutes").
+		Scan(&sprintMemberTaskLogs).Error
+	return sprintMemberTaskLogs, err
+}
+
+// updateDailyTimeLogs updates the daily time logs of the sprint member as per the dated time logs. Only the changed
+// daily logs are written, and the ones which are no longer in the time logs are removed. The time logs of the tasks
+// which the member has not worked on in the sprint are ignored.
+func (service SprintService) updateDailyTimeLogs(
+	sprintMemberID uint,
+	timeLogs []timeTrackerSerializers.TimeLog,
+	keyProviders *taskKeyProviders) error {
+	db := service.DB
+
+	sprintMemberTaskLogs, err := service.getSprintMemberTaskLogs(sprintMemberID)
+	if err != nil {
+		utils.LogToSentry(err)
+		return err
+	}
+	smtIDs := make(map[providerTaskKey]uint)
+	for _, smtLog := range sprintMemberTaskLogs {
+		smtIDs[providerTaskKey{Key: smtLog.Key, ProviderKey: smtLog.ProviderKey}] = smtLog.ID
+	}
+
+	type smtDay struct {
+		smtID uint
+		date  string
+	}
+	loggedMinutes := make(map[smtDay]uint)
+	for _, timeLog := range timeLogs {
+		smtID, exists := smtIDs[keyProviders.get(timeLog.TaskKey)]
+		if timeLog.Date.IsZero() || !exists {
+			continue
+		}
+		loggedMinutes[smtDay{smtID: smtID, date: timeLog.Date.Format(constants.CustomDateFormat)}] += timeLog.Minutes
+	}
+
+	var dailyTimeLogs []retroModels.DailyTimeLog
+	err = db.Model(&retroModels.DailyTimeLog{}).
+		Where("daily_time_logs.deleted_at IS NULL").
+		Joins("JOIN sprint_member_tasks ON daily_time_logs.sprint_member_task_id = sprint_member_tasks.id").
+		Where("sprint_member_tasks.sprint_member_id = ?", sprintMemberID).
+		Select("daily_time_logs.*").
+		Scan(&dailyTimeLogs).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return err
+	}
+
+	tx := db.Begin()
+	for _, dailyTimeLog := range dailyTimeLogs {
+		key := smtDay{smtID: dailyTimeLog.SprintMemberTaskID, date: dailyTimeLog.Date.Format(constants.CustomDateFormat)}
+		minutes, isLogged := loggedMinutes[key]
+		switch {
+		case !isLogged:
+			err = tx.Delete(&dailyTimeLog).Error
+		case minutes != dailyTimeLog.Minutes:
+			err = tx.Model(&dailyTimeLog).UpdateColumn("minutes", minutes).Error
+		}
+		if err != nil {
+			tx.Rollback()
+			utils.LogToSentry(err)
+			return err
+		}
+		delete(loggedMinutes, key)
+	}
+
+	for key, minutes := range loggedMinutes {
+		date, _ := time.Parse(constants.CustomDateFormat, key.date)
+		err = tx.Create(&retroModels.DailyTimeLog{SprintMemberTaskID: key.smtID, Date: date, Minutes: minutes}).Error
+		if err != nil {
+			tx.Rollback()
+			utils.LogToSentry(err)
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// GetDailyTimeLogs returns the time logged per day by the members of the sprint on the tasks of the sprint, along with
+// the time logged after the end of the sprint
+func (service SprintService) GetDailyTimeLogs(
+	sprintID string,
+	retroID string) (*retroSerializers.SprintDailyTimeLogsSerializer, int, error) {
+	db := service.DB
+	var sprint retroModels.Sprint
+
+	err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Scopes(retroModels.NotDeletedSprint).
+		Where("id = ?", sprintID).
+		Where("retrospective_id = ?", retroID).
+		Find(&sprint).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get daily time logs")
+	}
+
+	var dailyTimeLogs []retroSerializers.DailyTimeLog
+	err = db.Model(&retroModels.DailyTimeLog{}).
+		Where("daily_time_logs.deleted_at IS NULL").
+		Joins("JOIN sprint_member_tasks ON daily_time_logs.sprint_member_task_id = sprint_member_tasks.id").
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Scopes(retroModels.SMTJoinSM, retroModels.SMJoinMember, retroModels.SMTJoinST, retroModels.STJoinTask).
+		Where("sprint_members.sprint_id = ?", sprint.ID).
+		Order("daily_time_logs.date, users.first_name, users.last_name, tasks.key").
+		Select(`
+            daily_time_logs.date,
+            daily_time_logs.minutes,
+            sprint_members.id AS sprint_member_id,
+            users.first_name,
+            users.last_name,
+            sprint_tasks.id AS sprint_task_id,
+            tasks.key AS task_key`).
+		Scan(&dailyTimeLogs).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get daily time logs")
+	}
+
+	response := &retroSerializers.SprintDailyTimeLogsSerializer{
+		TimeLogs:     []retroSerializers.DailyTimeLog{},
+		LateTimeLogs: []retroSerializers.DailyTimeLog{},
+	}
+	for _, dailyTimeLog := range dailyTimeLogs {
+		timeLog := timeTrackerSerializers.TimeLog{Date: dailyTimeLog.Date}
+		if isLoggedAfterSprintEnd(timeLog, sprint) {
+			response.LateTimeLogs = append(response.LateTimeLogs, dailyTimeLog)
+		} else {
+			response.TimeLogs = append(response.TimeLogs, dailyTimeLog)
+		}
+	}
+	return response, http.StatusOK, nil
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// epicTask is a task of a retrospective with its epic and parent links, the links are to the tasks of the same task
+// provider
+type epicTask struct {
+	ID          uint
+	Key         string
+	ProviderKey string
+	Summary     string
+	Estimate    float64
+	DoneAt      *time.Time
+	ParentKey   string
+	EpicKey     string
+	EpicName    string
+}
+
+// GetEpicRollups returns the points, the time spent and the completion of the tasks of each of the epics of the
+// retrospective across its sprints. The sub-tasks without an epic are counted in the epic of their parent task, and
+// the epics themselves, if synced as tasks, only name their epics.
+func (service RetrospectiveService) GetEpicRollups(retroID string) (*retroSerializers.EpicRollupReport, int, error) {
+	db := service.DB
+
+	err := db.Model(&retroModels.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		First(&retroModels.Retrospective{}).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("retrospective not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get epic rollups")
+	}
+
+	var tasks []epicTask
+	err = db.Model(&retroModels.Task{}).
+		Where("tasks.deleted_at IS NULL").
+		Where("tasks.retrospective_id = ?", retroID).
+		Select("id, key, provider_key, summary, estimate, done_at, parent_key, epic_key, epic_name").
+		Scan(&tasks).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get epic rollups")
+	}
+
+	var sprintEfforts []struct {
+		TaskID           uint
+		SprintID         uint
+		Title            string
+		StartDate        *time.Time
+		EndDate          *time.Time
+		TimeSpentMinutes uint
+		PointsEarned     float64
+	}
+	err = db.Model(&retroModels.SprintMemberTask{}).
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Scopes(retroModels.SMTJoinSM, retroModels.SMJoinSprint, retroModels.NotDeletedSprint,
+			retroModels.SMTJoinST, retroModels.STJoinTask).
+		Where("tasks.retrospective_id = ?", retroID).
+		Group("tasks.id, sprints.id").
+		Select(`
+            tasks.id AS task_id,
+            sprints.id AS sprint_id,
+            sprints.title,
+            sprints.start_date,
+            sprints.end_date,
+            COALESCE(SUM(sprint_member_tasks.time_spent_minutes), 0) AS time_spent_minutes,
+            COALESCE(SUM(sprint_member_tasks.points_earned), 0) AS points_earned`).
+		Scan(&sprintEfforts).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get epic rollups")
+	}
+
+	keyTasks := make(map[providerTaskKey]epicTask)
+	for _, task := range tasks {
+		keyTasks[providerTaskKey{Key: task.Key, ProviderKey: task.ProviderKey}] = task
+	}
+	getEpicKey := func(task epicTask) providerTaskKey {
+		epicKey := task.EpicKey
+		if epicKey == "" && task.ParentKey != "" {
+			epicKey = keyTasks[providerTaskKey{Key: task.ParentKey, ProviderKey: task.ProviderKey}].EpicKey
+		}
+		return providerTaskKey{Key: epicKey, ProviderKey: task.ProviderKey}
+	}
+
+	taskEpicKeys := make(map[uint]providerTaskKey)
+	epics := make(map[providerTaskKey]*retroSerializers.EpicRollup)
+	epicSprints := make(map[providerTaskKey]map[uint]*retroSerializers.EpicSprintRollup)
+	for _, task := range tasks {
+		if epicKey := getEpicKey(task); epicKey.Key != "" && epics[epicKey] == nil {
+			epics[epicKey] = &retroSerializers.EpicRollup{
+				EpicKey:     epicKey.Key,
+				ProviderKey: epicKey.ProviderKey,
+				Sprints:     []retroSerializers.EpicSprintRollup{},
+			}
+			epicSprints[epicKey] = make(map[uint]*retroSerializers.EpicSprintRollup)
+		}
+	}
+
+	report := &retroSerializers.EpicRollupReport{Epics: []retroSerializers.EpicRollup{}}
+	for _, task := range tasks {
+		epicKey := getEpicKey(task)
+		if epicKey.Key == "" {
+			// The epics synced as tasks only give their summary as the name of the epic
+			if epic, isEpic := epics[providerTaskKey{Key: task.Key, ProviderKey: task.ProviderKey}]; isEpic {
+				if epic.EpicName == "" {
+					epic.EpicName = task.Summary
+				}
+			} else {
+				report.UnassignedTaskCount++
+			}
+			continue
+		}
+
+		epic := epics[epicKey]
+		if task.EpicName != "" {
+			epic.EpicName = task.EpicName
+		}
+		taskEpicKeys[task.ID] = epicKey
+		epic.TaskCount++
+		if task.EpicKey == "" {
+			epic.SubTaskCount++
+		}
+		epic.Estimate += task.Estimate
+		if task.DoneAt != nil {
+			epic.DoneTaskCount++
+			epic.DonePoints += task.Estimate
+		}
+	}
+
+	for _, sprintEffort := range sprintEfforts {
+		epicKey, exists := taskEpicKeys[sprintEffort.TaskID]
+		if !exists {
+			continue
+		}
+		epic := epics[epicKey]
+		epic.PointsEarned += sprintEffort.PointsEarned
+		epic.HoursSpent += float64(sprintEffort.TimeSpentMinutes) / 60
+
+		sprint := epicSprints[epicKey][sprintEffort.SprintID]
+		if sprint == nil {
+			sprint = &retroSerializers.EpicSprintRollup{
+				SprintID:  sprintEffort.SprintID,
+				Title:     sprintEffort.Title,
+				StartDate: sprintEffort.StartDate,
+				EndDate:   sprintEffort.EndDate,
+			}
+			epicSprints[epicKey][sprintEffort.SprintID] = sprint
+		}
+		sprint.TaskCount++
+		sprint.PointsEarned += sprintEffort.PointsEarned
+		sprint.HoursSpent += float64(sprintEffort.TimeSpentMinutes) / 60
+	}
+
+	for epicKey, epic := range epics {
+		switch {
+		case epic.Estimate > 0:
+			epic.Completion = epic.DonePoints / epic.Estimate
+		case epic.TaskCount > 0:
+			epic.Completion = float64(epic.DoneTaskCount) / float64(epic.TaskCount)
+		}
+		for _, sprint := range epicSprints[epicKey] {
+			epic.Sprints = append(epic.Sprints, *sprint)
+		}
+		sort.Slice(epic.Sprints, func(i, j int) bool {
+			first, second := epic.Sprints[i].StartDate, epic.Sprints[j].StartDate
+			if first != nil && second != nil && !first.Equal(*second) {
+				return first.Before(*second)
+			}
+			return epic.Sprints[i].SprintID < epic.Sprints[j].SprintID
+		})
+		report.Epics = append(report.Epics, *epic)
+	}
+	sort.Slice(report.Epics, func(i, j int) bool {
+		if report.Epics[i].EpicKey != report.Epics[j].EpicKey {
+			return report.Epics[i].EpicKey < report.Epics[j].EpicKey
+		}
+		return report.Epics[i].ProviderKey < report.Epics[j].ProviderKey
+	})
+	return report, http.StatusOK, nil
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+const (
+	// hoursPerWorkingDay converts the story points per week of a retrospective to the hours per story point
+	hoursPerWorkingDay       = 8
+	defaultEstimationSprints = 10
+	maxEstimationSprints     = 50
+	// minFlaggedTypeTasks is the number of the tasks of a type needed to flag the type as mis-estimated
+	minFlaggedTypeTasks    = 5
+	underEstimatedAccuracy = 1.25
+	overEstimatedAccuracy  = 0.8
+)
+
+// estimationAccuracy accumulates the story points and the time spent for an estimation accuracy
+type estimationAccuracy struct {
+	taskIDs     map[uint]bool
+	storyPoints float64
+	minutes     uint
+}
+
+func (accuracy *estimationAccuracy) add(taskID uint, storyPoints float64, minutes uint) {
+	if accuracy.taskIDs == nil {
+		accuracy.taskIDs = make(map[uint]bool)
+	}
+	accuracy.taskIDs[taskID] = true
+	accuracy.storyPoints += storyPoints
+	accuracy.minutes += minutes
+}
+
+func (accuracy estimationAccuracy) serialize(hrsPerStoryPoint float64) retroSerializers.EstimationAccuracy {
+	serializedAccuracy := retroSerializers.EstimationAccuracy{
+		TaskCount:   len(accuracy.taskIDs),
+		StoryPoints: accuracy.storyPoints,
+		HoursSpent:  float64(accuracy.minutes) / 60,
+	}
+	if accuracy.storyPoints > 0 {
+		serializedAccuracy.HrsPerStoryPoint = serializedAccuracy.HoursSpent / accuracy.storyPoints
+		if hrsPerStoryPoint > 0 {
+			serializedAccuracy.Accuracy = serializedAccuracy.HrsPerStoryPoint / hrsPerStoryPoint
+		}
+	}
+	return serializedAccuracy
+}
+
+// GetEstimationAccuracy compares the estimates of the tasks completed in the last sprints of the retrospective with
+// the time spent on them by the sprint members. The tasks without any estimate or time spent are left out. The hours
+// per story point of the retrospective are derived from its story points per week, taking 8 hours per working day.
+func (service RetrospectiveService) GetEstimationAccuracy(
+	retroID string,
+	sprintsString string) (*retroSerializers.EstimationAccuracyReport, int, error) {
+	db := service.DB
+	var retro retroModels.Retrospective
+
+	sprintCount, err := parseCount(sprintsString, defaultEstimationSprints, maxEstimationSprints)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("invalid number of sprints")
+	}
+
+	err = db.Model(&retroModels.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		Preload("Team").
+		First(&retro).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("retrospective not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get estimation accuracy")
+	}
+
+	var sprintIDs []uint
+	err = db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("retrospective_id = ?", retro.ID).
+		Where("status = ?", retroModels.CompletedSprint).
+		Order("end_date DESC, id DESC").
+		Limit(sprintCount).
+		Pluck("id", &sprintIDs).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get estimation accuracy")
+	}
+
+	var memberTaskLogs []struct {
+		TaskID           uint
+		Type             string
+		Estimate         float64
+		SprintID         uint
+		Title            string
+		EndDate          *time.Time
+		MemberID         uint
+		FirstName        string
+		LastName         string
+		TimeSpentMinutes uint
+		PointsEarned     float64
+	}
+	err = db.Model(&retroModels.SprintMemberTask{}).
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Scopes(retroModels.SMTJoinSM, retroModels.SMJoinSprint, retroModels.SMJoinMember,
+			retroModels.SMTJoinST, retroModels.STJoinTask).
+		Where("sprints.id IN (?)", append(sprintIDs, 0)).
+		Where("tasks.done_at IS NOT NULL").
+		Where("tasks.estimate > 0").
+		Select(`
+            tasks.id AS task_id,
+            tasks.type,
+            tasks.estimate,
+            sprints.id AS sprint_id,
+            sprints.title,
+            sprints.end_date,
+            users.id AS member_id,
+            users.first_name,
+            users.last_name,
+            sprint_member_tasks.time_spent_minutes,
+            sprint_member_tasks.points_earned`).
+		Scan(&memberTaskLogs).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get estimation accuracy")
+	}
+
+	taskMinutes := make(map[uint]uint)
+	for _, memberTaskLog := range memberTaskLogs {
+		taskMinutes[memberTaskLog.TaskID] += memberTaskLog.TimeSpentMinutes
+	}
+
+	var overall estimationAccuracy
+	taskTypes := make(map[string]*estimationAccuracy)
+	members := make(map[uint]*estimationAccuracy)
+	sprints := make(map[uint]*estimationAccuracy)
+	memberReports := make(map[uint]retroSerializers.MemberEstimationAccuracy)
+	sprintReports := make(map[uint]retroSerializers.SprintEstimationAccuracy)
+	sprintEndDates := make(map[uint]*time.Time)
+	countedTasks := make(map[uint]bool)
+	for _, memberTaskLog := range memberTaskLogs {
+		if taskMinutes[memberTaskLog.TaskID] == 0 {
+			continue
+		}
+		// The estimate and the time spent of a task are counted once per task for the task types,
+		// and the points earned and the time spent of the members are counted for the members and the sprints
+		if !countedTasks[memberTaskLog.TaskID] {
+			countedTasks[memberTaskLog.TaskID] = true
+			taskType := strings.ToLower(memberTaskLog.Type)
+			if taskTypes[taskType] == nil {
+				taskTypes[taskType] = &estimationAccuracy{}
+			}
+			taskTypes[taskType].add(memberTaskLog.TaskID, memberTaskLog.Estimate, taskMinutes[memberTaskLog.TaskID])
+			overall.add(memberTaskLog.TaskID, memberTaskLog.Estimate, taskMinutes[memberTaskLog.TaskID])
+		}
+
+		if members[memberTaskLog.MemberID] == nil {
+			members[memberTaskLog.MemberID] = &estimationAccuracy{}
+			memberReports[memberTaskLog.MemberID] = retroSerializers.MemberEstimationAccuracy{
+				MemberID:  memberTaskLog.MemberID,
+				FirstName: memberTaskLog.FirstName,
+				LastName:  memberTaskLog.LastName,
+			}
+		}
+		members[memberTaskLog.MemberID].add(
+			memberTaskLog.TaskID, memberTaskLog.PointsEarned, memberTaskLog.TimeSpentMinutes)
+
+		if sprints[memberTaskLog.SprintID] == nil {
+			sprints[memberTaskLog.SprintID] = &estimationAccuracy{}
+			sprintReports[memberTaskLog.SprintID] = retroSerializers.SprintEstimationAccuracy{
+				SprintID: memberTaskLog.SprintID,
+				Title:    memberTaskLog.Title,
+			}
+			sprintEndDates[memberTaskLog.SprintID] = memberTaskLog.EndDate
+		}
+		sprints[memberTaskLog.SprintID].add(
+			memberTaskLog.TaskID, memberTaskLog.PointsEarned, memberTaskLog.TimeSpentMinutes)
+	}
+
+	report := &retroSerializers.EstimationAccuracyReport{
+		StoryPointPerWeek: retro.StoryPointPerWeek,
+		TaskTypes:         []retroSerializers.TaskTypeEstimationAccuracy{},
+		Members:           []retroSerializers.MemberEstimationAccuracy{},
+		Sprints:           []retroSerializers.SprintEstimationAccuracy{},
+	}
+	hoursPerWeek := float64(hoursPerWorkingDay * retro.Team.GetWorkWeek().DaysPerWeek())
+	if retro.StoryPointPerWeek > 0 {
+		report.HrsPerStoryPoint = hoursPerWeek / retro.StoryPointPerWeek
+	}
+
+	report.Overall = overall.serialize(report.HrsPerStoryPoint)
+	if report.Overall.HrsPerStoryPoint > 0 {
+		suggestedHrsPerStoryPoint := report.Overall.HrsPerStoryPoint
+		suggestedStoryPointPerWeek := hoursPerWeek / suggestedHrsPerStoryPoint
+		report.SuggestedHrsPerStoryPoint = &suggestedHrsPerStoryPoint
+		report.SuggestedStoryPointPerWeek = &suggestedStoryPointPerWeek
+	}
+
+	for taskType, accuracy := range taskTypes {
+		taskTypeReport := retroSerializers.TaskTypeEstimationAccuracy{
+			TaskType:           taskType,
+			EstimationAccuracy: accuracy.serialize(report.HrsPerStoryPoint),
+		}
+		// The types are compared with the actual hours per story point of the retrospective, so that a type is
+		// flagged only if it is mis-estimated relative to the other types
+		if taskTypeReport.TaskCount >= minFlaggedTypeTasks && report.Overall.HrsPerStoryPoint > 0 {
+			relativeAccuracy := taskTypeReport.HrsPerStoryPoint / report.Overall.HrsPerStoryPoint
+			switch {
+			case relativeAccuracy > underEstimatedAccuracy:
+				taskTypeReport.Flag = "under-estimated"
+			case relativeAccuracy < overEstimatedAccuracy:
+				taskTypeReport.Flag = "over-estimated"
+			}
+		}
+		report.TaskTypes = append(report.TaskTypes, taskTypeReport)
+	}
+	sort.Slice(report.TaskTypes, func(i, j int) bool {
+		return report.TaskTypes[i].TaskType < report.TaskTypes[j].TaskType
+	})
+
+	for memberID, accuracy := range members {
+		memberReport := memberReports[memberID]
+		memberReport.EstimationAccuracy = accuracy.serialize(report.HrsPerStoryPoint)
+		report.Members = append(report.Members, memberReport)
+	}
+	sort.Slice(report.Members, func(i, j int) bool {
+		first, second := report.Members[i], report.Members[j]
+		if first.FirstName != second.FirstName {
+			return first.FirstName < second.FirstName
+		}
+		if first.LastName != second.LastName {
+			return first.LastName < second.LastName
+		}
+		return first.MemberID < second.MemberID
+	})
+
+	for sprintID, accuracy := range sprints {
+		sprintReport := sprintReports[sprintID]
+		sprintReport.EstimationAccuracy = accuracy.serialize(report.HrsPerStoryPoint)
+		report.Sprints = append(report.Sprints, sprintReport)
+	}
+	sort.Slice(report.Sprints, func(i, j int) bool {
+		first, second := sprintEndDates[report.Sprints[i].SprintID], sprintEndDates[report.Sprints[j].SprintID]
+		if first != nil && second != nil && !first.Equal(*second) {
+			return first.Before(*second)
+		}
+		return report.Sprints[i].SprintID < report.Sprints[j].SprintID
+	})
+
+	return report, http.StatusOK, nil
+}
@@ -30,6 +30,22 @@ func (service PermissionService) UserCanAccessRetro(retroID string, userID uint)
 	return err == nil
 }
 
+// UserCanEditRetro checks if the user created the retrospective or is an admin
+func (service PermissionService) UserCanEditRetro(retroID string, userID uint) bool {
+	if service.IsUserAdmin(userID) {
+		return true
+	}
+
+	db := service.DB
+	err := db.Model(&retroModels.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("retrospectives.id = ?", retroID).
+		Where("retrospectives.created_by_id = ?", userID).
+		Find(&retroSerializers.Retrospective{}).
+		Error
+	return err == nil
+}
+
 // UserCanAccessSprint ...
 func (service PermissionService) UserCanAccessSprint(retroID string, sprintID string, userID uint) bool {
 	if service.IsUserAdmin(userID) {
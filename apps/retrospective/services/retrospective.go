@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/jinzhu/gorm"
 	"strconv"
+	"strings"
 
 	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
 	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
@@ -12,6 +13,7 @@ import (
 	userModels "github.com/iReflect/reflect-app/apps/user/models"
 	userSerializers "github.com/iReflect/reflect-app/apps/user/serializers"
 	userServices "github.com/iReflect/reflect-app/apps/user/services"
+	"github.com/iReflect/reflect-app/db/models/fields"
 	"github.com/iReflect/reflect-app/libs/utils"
 	"net/http"
 )
@@ -172,6 +174,22 @@ func (service RetrospectiveService) Create(userID uint,
 	retro.Title = retrospectiveData.Title
 	retro.ProjectName = retrospectiveData.ProjectName
 	retro.StoryPointPerWeek = retrospectiveData.StoryPointPerWeek
+	retro.SyncSchedule = strings.TrimSpace(retrospectiveData.SyncSchedule)
+
+	if err := retroModels.ValidateSyncSchedule(retro.SyncSchedule); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	retro.PointAllocationStrategy = retrospectiveData.PointAllocationStrategy
+	if retro.PointAllocationStrategy == "" {
+		retro.PointAllocationStrategy = retroModels.TimeProportionalAllocation
+	}
+	if err := retroModels.ValidatePointAllocationStrategy(retro.PointAllocationStrategy); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if retro.RoleWeights, err = getRoleWeights(retrospectiveData.RoleWeights); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
 
 	if err := tasktracker.ValidateConfigs(retrospectiveData.TaskProviderConfig); err != nil {
 		return nil, http.StatusBadRequest, err
@@ -194,3 +212,75 @@ func (service RetrospectiveService) Create(userID uint,
 	}
 	return &retro, http.StatusCreated, nil
 }
+
+// UpdateSyncSchedule updates the schedule for the automatic sync of the active sprints of the retrospective
+func (service RetrospectiveService) UpdateSyncSchedule(retroID string,
+	scheduleData *retroSerializers.RetrospectiveSyncScheduleSerializer) (*retroSerializers.Retrospective, int, error) {
+	db := service.DB
+	syncSchedule := strings.TrimSpace(scheduleData.SyncSchedule)
+
+	if err := retroModels.ValidateSyncSchedule(syncSchedule); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	result := db.Model(&retroModels.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		UpdateColumn("sync_schedule", syncSchedule)
+	if result.Error != nil {
+		utils.LogToSentry(result.Error)
+		return nil, http.StatusInternalServerError, errors.New("failed to update sync schedule")
+	}
+	if result.RowsAffected == 0 {
+		return nil, http.StatusNotFound, errors.New("retrospective not found")
+	}
+	return service.Get(retroID, false)
+}
+
+// UpdatePointAllocationStrategy updates the strategy used to split the points of the tasks among the sprint members,
+// along with the weights of the roles used by the role weighted strategy. The points of the active sprints are
+// reassigned with the new strategy in their next sync.
+func (service RetrospectiveService) UpdatePointAllocationStrategy(retroID string,
+	strategyData *retroSerializers.RetrospectivePointAllocationSerializer) (*retroSerializers.Retrospective, int, error) {
+	db := service.DB
+
+	if err := retroModels.ValidatePointAllocationStrategy(strategyData.PointAllocationStrategy); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	roleWeights, err := getRoleWeights(strategyData.RoleWeights)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	result := db.Model(&retroModels.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		UpdateColumns(map[string]interface{}{
+			"point_allocation_strategy": strategyData.PointAllocationStrategy,
+			"role_weights":              roleWeights,
+		})
+	if result.Error != nil {
+		utils.LogToSentry(result.Error)
+		return nil, http.StatusInternalServerError, errors.New("failed to update point allocation strategy")
+	}
+	if result.RowsAffected == 0 {
+		return nil, http.StatusNotFound, errors.New("retrospective not found")
+	}
+	return service.Get(retroID, false)
+}
+
+// getRoleWeights validates the role weights given for a retrospective and returns them as stored, no role weights
+// are stored if none are given so that the default role weights are used
+func getRoleWeights(roleWeights map[string]float64) (fields.JSONB, error) {
+	if roleWeights == nil {
+		roleWeights = map[string]float64{}
+	}
+	roleWeightsJSON, err := json.Marshal(roleWeights)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = retroModels.ParseRoleWeights(roleWeightsJSON); err != nil {
+		return nil, err
+	}
+	return fields.JSONB(roleWeightsJSON), nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -143,7 +144,7 @@ func (service SprintService) ActivateSprint(sprintID string, retroID string) (in
 		if rowsAffected := db.Save(&sprint).RowsAffected; rowsAffected == 0 {
 			return http.StatusInternalServerError, errors.New("sprint couldn't be activated")
 		}
-		service.QueueSprint(sprint.ID, true)
+		service.QueueSprint(sprint.ID, true, false, retroModels.ManualSyncTrigger)
 		return http.StatusNoContent, nil
 	}
 	return http.StatusBadRequest, errors.New("cannot activate an invalid draft sprint")
@@ -253,6 +254,12 @@ func (service SprintService) GetSprintSummary(
 		return nil, http.StatusInternalServerError, errors.New("failed to get sprint summary")
 	}
 
+	calendar, err := sprint.GetWorkCalendar(db)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint summary")
+	}
+
 	err = db.Model(&retroModels.Sprint{}).
 		Where("sprints.deleted_at IS NULL").
 		Scopes(retroModels.SprintJoinSM).
@@ -263,9 +270,10 @@ func (service SprintService) GetSprintSummary(
             SUM(expectation_percent) AS total_expectation,
             SUM((? - vacations) * expectation_percent / 100.0 * allocation_percent / 100.0 * ?) AS target_sp,
             SUM(vacations) AS total_vacations,
-            0 AS holidays`,
-			utils.GetWorkingDaysBetweenTwoDates(*sprint.StartDate, *sprint.EndDate),
-			sprint.Retrospective.StoryPointPerWeek/5).
+            ? AS holidays`,
+			calendar.WorkingDays,
+			sprint.Retrospective.StoryPointPerWeek/float64(calendar.WorkWeek.DaysPerWeek()),
+			calendar.Holidays).
 		Scan(&summary).Error
 
 	if err != nil {
@@ -273,6 +281,18 @@ func (service SprintService) GetSprintSummary(
 		return nil, http.StatusInternalServerError, errors.New("failed to get sprint summary")
 	}
 
+	// The total output is the story points earned by all the members in the sprint
+	err = db.Model(&retroModels.SprintMemberTask{}).
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Scopes(retroModels.SMTJoinSM).
+		Where("sprint_members.sprint_id = ?", sprint.ID).
+		Select("COALESCE(SUM(sprint_member_tasks.points_earned), 0)").
+		Row().Scan(&summary.TotalOutput)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint summary")
+	}
+
 	taskTypesSummary, status, err := service.GetSprintTaskSummary(sprintID, retroID)
 
 	summary.TaskSummary = taskTypesSummary
@@ -310,7 +330,11 @@ func (service SprintService) GetSprintTaskSummary(
 	summary = make(map[string]retroSerializers.SprintTaskSummary)
 
 	for _, taskType := range tasktracker.TaskTypes {
-		taskSummary, status, err := service.getSprintTaskTypeSummary(sprintID, taskTypes[taskType])
+		providerTaskTypes := make(map[string][]string)
+		for providerKey, types := range taskTypes {
+			providerTaskTypes[providerKey] = types[taskType]
+		}
+		taskSummary, status, err := service.getSprintTaskTypeSummary(sprintID, taskType, providerTaskTypes)
 		if err != nil {
 			return nil, status, err
 		}
@@ -320,18 +344,31 @@ func (service SprintService) GetSprintTaskSummary(
 	return summary, http.StatusOK, nil
 }
 
+// getSprintTaskTypeSummary returns the summary of the tasks of the sprint of a task type, the task types of each task
+// provider, mapped by the provider keys, apply only to the tasks of the task provider
 func (service SprintService) getSprintTaskTypeSummary(
 	sprintID string,
-	taskTypes []string) (*retroSerializers.SprintTaskSummary, int, error) {
+	taskType string,
+	providerTaskTypes map[string][]string) (*retroSerializers.SprintTaskSummary, int, error) {
 	db := service.DB
 
 	var summary retroSerializers.SprintTaskSummary
 
+	var typeConditions []string
+	var typeValues []interface{}
+	for providerKey, taskTypes := range providerTaskTypes {
+		typeConditions = append(typeConditions, "(tasks.provider_key = ? AND LOWER(tasks.type) IN (?))")
+		typeValues = append(typeValues, providerKey, taskTypes)
+	}
+	if len(typeConditions) == 0 {
+		typeConditions = append(typeConditions, "FALSE")
+	}
+
 	taskList := db.Model(&retroModels.Sprint{}).
 		Where("sprints.deleted_at IS NULL").
 		Scopes(retroModels.SprintJoinSM, retroModels.SMJoinSMT, retroModels.SMTJoinST, retroModels.STJoinTask).
 		Where("sprints.id = ?", sprintID).
-		Where("LOWER(tasks.type) IN (?)", taskTypes)
+		Where(strings.Join(typeConditions, " OR "), typeValues...)
 
 	doneTaskQuery := taskList.Where("sprints.start_date <= tasks.done_at").
 		Where("sprints.end_date >= tasks.done_at").
@@ -346,7 +383,7 @@ func (service SprintService) getSprintTaskTypeSummary(
 
 	if err != nil {
 		utils.LogToSentry(err)
-		return nil, http.StatusInternalServerError, errors.New("failed to get task info for " + strings.Join(taskTypes, ", "))
+		return nil, http.StatusInternalServerError, errors.New("failed to get task info for " + taskType)
 	}
 
 	return &summary, http.StatusOK, nil
@@ -419,7 +456,7 @@ func (service SprintService) Create(
 	sprint.CreatedByID = sprintData.CreatedByID
 	sprint.Status = retroModels.DraftSprint
 
-	if sprint.SprintID != "" {
+	if sprint.SprintID != "" || len(sprintData.ProviderSprintIDs) != 0 {
 
 		taskProviderConfig, err := tasktracker.DecryptTaskProviders(retro.TaskProviderConfig)
 		if err != nil {
@@ -427,16 +464,38 @@ func (service SprintService) Create(
 			return nil, http.StatusInternalServerError,
 				errors.New("failed to get task provider config. please contact admin")
 		}
-
-		connection := tasktracker.GetConnection(taskProviderConfig)
-		if connection == nil {
+		connections, err := tasktracker.GetConnections(taskProviderConfig)
+		if err != nil {
+			utils.LogToSentry(err)
 			return nil, http.StatusInternalServerError, errors.New("invalid connection config")
 		}
 
-		var providerSprint *taskTrackerSerializers.Sprint
+		// The sprint ID without the provider keys is of the first task provider
+		providerSprintIDs := make(map[string]string)
+		for providerKey, providerSprintID := range sprintData.ProviderSprintIDs {
+			if providerSprintID = strings.TrimSpace(providerSprintID); providerSprintID != "" {
+				providerSprintIDs[providerKey] = providerSprintID
+			}
+		}
+		if len(providerSprintIDs) == 0 && sprint.SprintID != "" {
+			providerSprintIDs[connections.GetFirstKey()] = sprint.SprintID
+		}
+		// The sprint ID is kept for the display, as the sprint ID of the first task provider which has the sprint
+		for _, connection := range connections {
+			if providerSprintID, ok := providerSprintIDs[connection.ProviderKey]; ok && sprint.SprintID == "" {
+				sprint.SprintID = providerSprintID
+			}
+		}
+		if sprint.ProviderSprintIDs, err = json.Marshal(providerSprintIDs); err != nil {
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to create sprint")
+		}
 
-		// ToDo: The form will take task provider specific sprint ids in the future
-		providerSprint = connection.GetSprint(sprintData.SprintID)
+		var providerSprint *taskTrackerSerializers.Sprint
+		providerSprint, err = tasktracker.GetSprint(taskProviderConfig, providerSprintIDs)
+		if err != nil {
+			return nil, http.StatusUnprocessableEntity, err
+		}
 		if providerSprint != nil {
 			if sprint.StartDate == nil {
 				sprint.StartDate = providerSprint.FromDate
@@ -538,7 +597,7 @@ func (service SprintService) Create(
 	}
 
 	service.SetNotSynced(sprint.ID)
-	service.QueueSprint(sprint.ID, false)
+	service.QueueSprint(sprint.ID, false, false, retroModels.ManualSyncTrigger)
 
 	return service.Get(fmt.Sprint(sprint.ID), userID, true)
 }
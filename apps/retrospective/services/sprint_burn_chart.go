@@ -0,0 +1,331 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// sprintBurnData is the day wise burn data of a sprint, the days are the calendar days of the sprint in the server
+// time zone
+type sprintBurnData struct {
+	calendar     retroModels.WorkCalendar
+	workingDays  float64
+	initialScope float64
+	days         []sprintBurnDay
+}
+
+// sprintBurnDay is the burn data of a sprint at the end of a day
+type sprintBurnDay struct {
+	date         time.Time
+	isWorkingDay bool
+	// isFuture is set for the days yet to come, which have only the ideal burn
+	isFuture bool
+	// idealCompletedPoints is the part of the initial scope which should be completed by the day
+	idealCompletedPoints float64
+	addedPoints          float64
+	removedPoints        float64
+	scopePoints          float64
+	completedPoints      float64
+	loggedHours          float64
+	totalLoggedHours     float64
+}
+
+// GetSprintBurndown returns the daily remaining estimate of the sprint against the ideal burndown
+func (service SprintService) GetSprintBurndown(
+	sprintID string,
+	retroID string) (*retroSerializers.SprintBurndownSerializer, int, error) {
+	burnData, status, err := service.getSprintBurnData(sprintID, retroID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	burndown := &retroSerializers.SprintBurndownSerializer{
+		WorkingDays:  burnData.workingDays,
+		InitialScope: burnData.initialScope,
+		Days:         []retroSerializers.BurndownDay{},
+	}
+	for _, day := range burnData.days {
+		burndownDay := retroSerializers.BurndownDay{
+			Date:                 day.date,
+			IsWorkingDay:         day.isWorkingDay,
+			IdealRemainingPoints: burnData.initialScope - day.idealCompletedPoints,
+		}
+		if !day.isFuture {
+			remainingPoints := day.scopePoints - day.completedPoints
+			addedPoints, removedPoints, loggedHours := day.addedPoints, day.removedPoints, day.loggedHours
+			burndownDay.RemainingPoints = &remainingPoints
+			burndownDay.AddedPoints = &addedPoints
+			burndownDay.RemovedPoints = &removedPoints
+			burndownDay.LoggedHours = &loggedHours
+		}
+		burndown.Days = append(burndown.Days, burndownDay)
+	}
+	return burndown, http.StatusOK, nil
+}
+
+// GetSprintBurnup returns the daily completed points and scope of the sprint against the ideal burnup
+func (service SprintService) GetSprintBurnup(
+	sprintID string,
+	retroID string) (*retroSerializers.SprintBurnupSerializer, int, error) {
+	burnData, status, err := service.getSprintBurnData(sprintID, retroID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	burnup := &retroSerializers.SprintBurnupSerializer{
+		WorkingDays:  burnData.workingDays,
+		InitialScope: burnData.initialScope,
+		Days:         []retroSerializers.BurnupDay{},
+	}
+	for _, day := range burnData.days {
+		burnupDay := retroSerializers.BurnupDay{
+			Date:                 day.date,
+			IsWorkingDay:         day.isWorkingDay,
+			IdealCompletedPoints: day.idealCompletedPoints,
+		}
+		if !day.isFuture {
+			scopePoints, completedPoints, loggedHours := day.scopePoints, day.completedPoints, day.totalLoggedHours
+			burnupDay.ScopePoints = &scopePoints
+			burnupDay.CompletedPoints = &completedPoints
+			burnupDay.LoggedHours = &loggedHours
+		}
+		burnup.Days = append(burnup.Days, burnupDay)
+	}
+	return burnup, http.StatusOK, nil
+}
+
+// burnSprintTask is a task of a sprint as needed for its burn data
+type burnSprintTask struct {
+	SprintID  uint
+	AddedAt   time.Time
+	RemovedAt *time.Time
+	Estimate  float64
+	DoneAt    *time.Time
+}
+
+// burnDailyTimeLog is the time logged in a sprint on a day
+type burnDailyTimeLog struct {
+	SprintID uint
+	Date     time.Time
+	Minutes  uint
+}
+
+// getSprintBurnData returns the day wise burn data of the sprint
+func (service SprintService) getSprintBurnData(sprintID string, retroID string) (*sprintBurnData, int, error) {
+	db := service.DB
+	var sprint retroModels.Sprint
+
+	err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Scopes(retroModels.NotDeletedSprint).
+		Where("id = ?", sprintID).
+		Where("retrospective_id = ?", retroID).
+		Find(&sprint).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint burn data")
+	}
+	if sprint.StartDate == nil || sprint.EndDate == nil {
+		return nil, http.StatusBadRequest, errors.New("sprint dates are not set")
+	}
+
+	burnData, err := service.getSprintsBurnData([]retroModels.Sprint{sprint})
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint burn data")
+	}
+	return burnData[sprint.ID], http.StatusOK, nil
+}
+
+// getSprintsBurnData fetches the tasks and the daily time logs of the sprints with a query each for all the sprints,
+// and returns their burn data mapped by the sprint ids. The sprints without the dates are left out.
+func (service SprintService) getSprintsBurnData(sprints []retroModels.Sprint) (map[uint]*sprintBurnData, error) {
+	db := service.DB
+
+	burnData := make(map[uint]*sprintBurnData)
+	var sprintIDs []uint
+	for _, sprint := range sprints {
+		if sprint.StartDate != nil && sprint.EndDate != nil {
+			sprintIDs = append(sprintIDs, sprint.ID)
+		}
+	}
+	if len(sprintIDs) == 0 {
+		return burnData, nil
+	}
+
+	calendars, err := retroModels.GetWorkCalendars(db, sprints)
+	if err != nil {
+		return nil, err
+	}
+
+	// The tasks removed from the sprints are included, so that the scope is not rewritten after they are removed
+	var sprintTasks []burnSprintTask
+	err = db.Unscoped().Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.sprint_id IN (?)", sprintIDs).
+		Scopes(retroModels.STJoinTask).
+		Select("sprint_tasks.sprint_id, sprint_tasks.created_at AS added_at, sprint_tasks.deleted_at AS removed_at, " +
+			"tasks.estimate, tasks.done_at").
+		Scan(&sprintTasks).Error
+	if err != nil {
+		return nil, err
+	}
+	tasksBySprint := make(map[uint][]burnSprintTask)
+	for _, sprintTask := range sprintTasks {
+		tasksBySprint[sprintTask.SprintID] = append(tasksBySprint[sprintTask.SprintID], sprintTask)
+	}
+
+	var dailyTimeLogs []burnDailyTimeLog
+	err = db.Model(&retroModels.DailyTimeLog{}).
+		Where("daily_time_logs.deleted_at IS NULL").
+		Joins("JOIN sprint_member_tasks ON daily_time_logs.sprint_member_task_id = sprint_member_tasks.id").
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Scopes(retroModels.SMTJoinSM).
+		Where("sprint_members.sprint_id IN (?)", sprintIDs).
+		Group("sprint_members.sprint_id, daily_time_logs.date").
+		Select("sprint_members.sprint_id, daily_time_logs.date, SUM(daily_time_logs.minutes) AS minutes").
+		Scan(&dailyTimeLogs).Error
+	if err != nil {
+		return nil, err
+	}
+	timeLogsBySprint := make(map[uint][]burnDailyTimeLog)
+	for _, dailyTimeLog := range dailyTimeLogs {
+		timeLogsBySprint[dailyTimeLog.SprintID] = append(timeLogsBySprint[dailyTimeLog.SprintID], dailyTimeLog)
+	}
+
+	for _, sprint := range sprints {
+		if sprint.StartDate == nil || sprint.EndDate == nil {
+			continue
+		}
+		burnData[sprint.ID] = buildSprintBurnData(sprint, calendars[sprint.ID], tasksBySprint[sprint.ID],
+			timeLogsBySprint[sprint.ID])
+	}
+	return burnData, nil
+}
+
+// buildSprintBurnData builds the day wise burn data of the sprint from its tasks. A task is in the scope of the sprint
+// from the day it was synced into the sprint till the day it was removed from it, the tasks synced till the start date
+// make the initial scope, and is completed on its done date. The estimates are the current estimates of the tasks. The
+// ideal burn is spread evenly over the working days of the sprint, excluding the holidays of the team.
+func buildSprintBurnData(
+	sprint retroModels.Sprint,
+	calendar retroModels.WorkCalendar,
+	sprintTasks []burnSprintTask,
+	dailyTimeLogs []burnDailyTimeLog) *sprintBurnData {
+	startDate := utils.GetStartOfDay(utils.InServerTimeZone(*sprint.StartDate))
+	endDate := utils.GetStartOfDay(utils.InServerTimeZone(*sprint.EndDate))
+	today := utils.GetStartOfDay(utils.InServerTimeZone(time.Now()))
+	startKey, endKey := startDate.Format(constants.CustomDateFormat), endDate.Format(constants.CustomDateFormat)
+
+	// The changes are keyed by the 'YYYY-MM-DD' dates, which sort chronologically
+	burnData := &sprintBurnData{calendar: calendar, workingDays: calendar.WorkingDays, days: []sprintBurnDay{}}
+	addedPoints := make(map[string]float64)
+	removedPoints := make(map[string]float64)
+	completedPoints := make(map[string]float64)
+	for _, sprintTask := range sprintTasks {
+		// The tasks removed by the start of the sprint were never in its scope, and the ones removed after its end
+		// stay in it
+		var removedKey string
+		if sprintTask.RemovedAt != nil {
+			removedKey = utils.InServerTimeZone(*sprintTask.RemovedAt).Format(constants.CustomDateFormat)
+			if removedKey <= startKey {
+				continue
+			}
+			if removedKey > endKey {
+				removedKey = ""
+			}
+		}
+
+		addedKey := utils.InServerTimeZone(sprintTask.AddedAt).Format(constants.CustomDateFormat)
+		if addedKey <= startKey {
+			addedKey = startKey
+			burnData.initialScope += sprintTask.Estimate
+		} else {
+			// The tasks synced after the end of the sprint are taken as added on its last day
+			addedKey = minDateKey(addedKey, endKey)
+			addedPoints[addedKey] += sprintTask.Estimate
+		}
+		if removedKey != "" {
+			removedPoints[removedKey] += sprintTask.Estimate
+		}
+
+		if sprintTask.DoneAt == nil {
+			continue
+		}
+		doneKey := utils.InServerTimeZone(*sprintTask.DoneAt).Format(constants.CustomDateFormat)
+		if doneKey > endKey {
+			continue
+		}
+		// A task done before it was added to the sprint is completed on the day it was added
+		if doneKey < addedKey {
+			doneKey = addedKey
+		}
+		// A task done after it was removed was not completed in the sprint, and the completion of a task done before
+		// it was removed leaves the sprint with it
+		if removedKey != "" {
+			if doneKey >= removedKey {
+				continue
+			}
+			completedPoints[removedKey] -= sprintTask.Estimate
+		}
+		completedPoints[doneKey] += sprintTask.Estimate
+	}
+
+	loggedHours := make(map[string]float64)
+	for _, dailyTimeLog := range dailyTimeLogs {
+		loggedHours[dailyTimeLog.Date.Format(constants.CustomDateFormat)] += float64(dailyTimeLog.Minutes) / 60
+	}
+
+	scope := burnData.initialScope
+	var completed, totalLoggedHours, elapsedWorkingDays float64
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		key := date.Format(constants.CustomDateFormat)
+		day := sprintBurnDay{
+			date:         date,
+			isWorkingDay: calendar.IsWorkingDay(date),
+			isFuture:     date.After(today),
+		}
+
+		if day.isWorkingDay {
+			elapsedWorkingDays++
+		}
+		if burnData.workingDays > 0 {
+			idealRatio := math.Min(elapsedWorkingDays/burnData.workingDays, 1)
+			day.idealCompletedPoints = burnData.initialScope * idealRatio
+		} else if key == endKey {
+			day.idealCompletedPoints = burnData.initialScope
+		}
+
+		scope += addedPoints[key] - removedPoints[key]
+		completed += completedPoints[key]
+		totalLoggedHours += loggedHours[key]
+
+		day.addedPoints = addedPoints[key]
+		day.removedPoints = removedPoints[key]
+		day.scopePoints = scope
+		day.completedPoints = completed
+		day.loggedHours = loggedHours[key]
+		day.totalLoggedHours = totalLoggedHours
+		burnData.days = append(burnData.days, day)
+	}
+	return burnData
+}
+
+// minDateKey returns the earlier of the two 'YYYY-MM-DD' dates
+func minDateKey(first string, second string) string {
+	if first < second {
+		return first
+	}
+	return second
+}
@@ -91,8 +91,14 @@ func (service SprintService) AddSprintMember(
 		return nil, http.StatusInternalServerError, errors.New("failed to get member summary")
 	}
 
+	calendar, err := sprint.GetWorkCalendar(db)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get member summary")
+	}
+
 	sprintMemberSummary.ActualStoryPoint = 0
-	sprintMemberSummary.SetExpectedStoryPoint(sprint, sprint.Retrospective)
+	sprintMemberSummary.SetExpectedStoryPoint(calendar, sprint.Retrospective)
 
 	return sprintMemberSummary, http.StatusOK, nil
 }
@@ -180,8 +186,13 @@ func (service SprintService) GetSprintMembersSummary(
 		utils.LogToSentry(err)
 		return nil, http.StatusInternalServerError, errors.New("failed to get member summary")
 	}
+	calendar, err := sprint.GetWorkCalendar(db)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get member summary")
+	}
 	for _, sprintMemberSummary := range sprintMemberSummaryList.Members {
-		sprintMemberSummary.SetExpectedStoryPoint(sprint, sprint.Retrospective)
+		sprintMemberSummary.SetExpectedStoryPoint(calendar, sprint.Retrospective)
 	}
 	return sprintMemberSummaryList, http.StatusOK, nil
 }
@@ -262,12 +273,18 @@ func (service SprintService) UpdateSprintMember(sprintID string, sprintMemberID
 		return nil, http.StatusInternalServerError, errors.New("failed to update sprint member")
 	}
 
-	sprintMemberSummary.SetExpectedStoryPoint(sprintMember.Sprint, sprintMember.Sprint.Retrospective)
+	calendar, err := sprintMember.Sprint.GetWorkCalendar(db)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to update sprint member")
+	}
+	sprintMemberSummary.SetExpectedStoryPoint(calendar, sprintMember.Sprint.Retrospective)
 
 	return &sprintMemberSummary, http.StatusOK, nil
 }
 
 func (service SprintService) addOrUpdateSMT(timeLog timeTrackerSerializers.TimeLog,
+	providerKey string,
 	sprintMemberID uint,
 	sprintID uint,
 	retroID uint) (err error) {
@@ -279,6 +296,7 @@ func (service SprintService) addOrUpdateSMT(timeLog timeTrackerSerializers.TimeL
 		Where("sprint_member_id = ?", sprintMemberID).
 		Scopes(retroModels.SMTJoinST, retroModels.STJoinTask, retroModels.TaskJoinTaskKeyMaps).
 		Where("task_key_maps.key = ?", timeLog.TaskKey).
+		Where("task_key_maps.provider_key = ?", providerKey).
 		Where("tasks.retrospective_id = ?", retroID).
 		FirstOrInit(&sprintMemberTask).Error
 	if err != nil {
@@ -291,6 +309,7 @@ func (service SprintService) addOrUpdateSMT(timeLog timeTrackerSerializers.TimeL
 		Scopes(retroModels.STJoinTask, retroModels.TaskJoinTaskKeyMaps).
 		Where("sprint_tasks.sprint_id = ?", sprintID).
 		Where("task_key_maps.key = ?", timeLog.TaskKey).
+		Where("task_key_maps.provider_key = ?", providerKey).
 		Where("tasks.retrospective_id = ?", retroID).
 		First(&sprintTask).Error
 	if err != nil {
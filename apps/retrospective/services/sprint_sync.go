@@ -16,13 +16,24 @@ import (
 	taskTrackerSerializers "github.com/iReflect/reflect-app/apps/tasktracker/serializers"
 	"github.com/iReflect/reflect-app/apps/timetracker"
 	timeTrackerSerializers "github.com/iReflect/reflect-app/apps/timetracker/serializers"
+	"github.com/iReflect/reflect-app/config"
+	"github.com/iReflect/reflect-app/constants"
 	"github.com/iReflect/reflect-app/libs/utils"
 	"github.com/iReflect/reflect-app/workers"
 	"github.com/jinzhu/gorm"
 )
 
-// SyncSprintData ...
-func (service SprintService) SyncSprintData(sprintID string) (err error) {
+// syncMarkOverlap is subtracted from the sync marks while fetching the updated tasks,
+// to allow for the clock skew between the server and the task providers
+const syncMarkOverlap = 5 * time.Minute
+
+// staleSyncingAge is the age after which a sprint still marked as syncing is taken to be stuck, eg. its worker was
+// killed mid-sync, and is queued by the schedule again
+const staleSyncingAge = 2 * time.Hour
+
+// SyncSprintData syncs the tasks and the time logs of the sprint. Only the tasks updated in the task providers since
+// the last sync are fetched, unless fullResync is set, in which case all the tasks of the sprint are fetched again.
+func (service SprintService) SyncSprintData(sprintID string, fullResync bool, trigger retroModels.SyncTrigger) (err error) {
 	db := service.DB
 	var sprint retroModels.Sprint
 	err = db.Model(&retroModels.Sprint{}).
@@ -38,76 +49,79 @@ func (service SprintService) SyncSprintData(sprintID string) (err error) {
 		return err
 	}
 
+	runLog := service.startSyncRun(sprint.ID, trigger, fullResync)
+	failSync := func(step string, err error) error {
+		utils.LogToSentry(err)
+		service.SetSyncFailed(sprint.ID)
+		runLog.fail(step, err)
+		return err
+	}
+
 	if sprint.StartDate == nil || sprint.EndDate == nil {
+		err = errors.New("sprint has no start/end date")
 		service.SetNotSynced(sprint.ID)
-		return errors.New("sprint has no start/end date")
+		runLog.fail(retroModels.SyncStepConfig, err)
+		return err
 	}
 
 	service.SetSyncing(sprint.ID)
 
-	taskProviderConfig, err := tasktracker.DecryptTaskProviders(sprint.Retrospective.TaskProviderConfig)
+	taskProviderConfig, err := service.getValidTaskProviderConfig(sprint.Retrospective)
 	if err != nil {
-		utils.LogToSentry(err)
-		service.SetSyncFailed(sprint.ID)
-		return err
+		return failSync(retroModels.SyncStepConfig, err)
 	}
 
 	// TODO Restructure code-flow and document it to make it readable
-	taskTrackerTaskKeySet, err := service.fetchAndUpdateTaskTrackerTask(sprint, taskProviderConfig)
+	keyProviders, err := service.fetchAndUpdateTaskTrackerTask(sprint, taskProviderConfig, fullResync, runLog)
 	if err != nil {
-		utils.LogToSentry(err)
-		service.SetSyncFailed(sprint.ID)
-		return err
+		return failSync(retroModels.SyncStepTaskTracker, err)
 	}
 
 	var timeTrackerTaskKeys []string
 	var timeLogs []timeTrackerSerializers.TimeLog
 	sprintMemberTimeLogs := map[uint][]timeTrackerSerializers.TimeLog{}
 	for _, sprintMember := range sprint.SprintMembers {
+		if !timetracker.HasConnections(sprintMember.Member.TimeProviderConfig) {
+			runLog.addError(retroModels.SyncStepTimeTracker,
+				fmt.Errorf("member %s has no time tracker config", sprintMember.Member.Email))
+		}
+
 		var memberTaskKeys []string
-		memberTaskKeys, timeLogs, err = service.GetSprintMemberTimeTrackerData(sprintMember, sprint)
-		sprintMemberTimeLogs[sprintMember.ID] = timeLogs
-		timeTrackerTaskKeys = append(timeTrackerTaskKeys, memberTaskKeys...)
+		memberTaskKeys, timeLogs, err = service.GetSprintMemberTimeTrackerData(sprintMember, sprint, fullResync)
 		if err != nil {
-			utils.LogToSentry(err)
-			service.SetSyncFailed(sprint.ID)
-			return err
+			return failSync(retroModels.SyncStepTimeTracker,
+				fmt.Errorf("failed to get the time logs of member %s: %s", sprintMember.Member.Email, err))
 		}
+		sprintMemberTimeLogs[sprintMember.ID] = timeLogs
+		timeTrackerTaskKeys = append(timeTrackerTaskKeys, memberTaskKeys...)
+		runLog.run.TimeLogsProcessed += uint(len(timeLogs))
 	}
 
-	insertedTimeTrackerTaskKeySet, err := service.fetchAndUpdateTimeTrackerTask(
+	err = service.fetchAndUpdateTimeTrackerTask(
 		sprint,
 		sprint.RetrospectiveID,
 		taskProviderConfig,
-		taskTrackerTaskKeySet,
-		timeTrackerTaskKeys)
+		keyProviders,
+		timeTrackerTaskKeys,
+		runLog)
 	if err != nil {
-		utils.LogToSentry(err)
-		service.SetSyncFailed(sprint.ID)
-		return err
+		return failSync(retroModels.SyncStepTimeTrackerTasks, err)
 	}
 
 	err = service.updateMissingTimeTrackerTask(sprint,
 		sprint.RetrospectiveID,
 		taskProviderConfig,
 		timeTrackerTaskKeys,
-		taskTrackerTaskKeySet,
-		insertedTimeTrackerTaskKeySet)
+		keyProviders,
+		runLog)
 	if err != nil {
-		utils.LogToSentry(err)
-		service.SetSyncFailed(sprint.ID)
-		return err
+		return failSync(retroModels.SyncStepTimeTrackerTasks, err)
 	}
 	for _, sprintMember := range sprint.SprintMembers {
 		err = service.updateSprintMemberTimeLog(
-			sprint.ID,
-			sprint.RetrospectiveID,
-			sprintMember.ID,
-			sprintMemberTimeLogs[sprintMember.ID])
+			sprint, sprintMember.ID, sprintMemberTimeLogs[sprintMember.ID], keyProviders)
 		if err != nil {
-			utils.LogToSentry(err)
-			service.SetSyncFailed(sprint.ID)
-			return err
+			return failSync(retroModels.SyncStepSprintMemberTasks, err)
 		}
 
 	}
@@ -115,15 +129,72 @@ func (service SprintService) SyncSprintData(sprintID string) (err error) {
 	// Maybe a Join table ST
 
 	service.SetSynced(sprint.ID)
+	runLog.finish(retroModels.Synced)
 
 	return nil
 }
 
-// QueueSprint ...
-func (service SprintService) QueueSprint(sprintID uint, assignPoints bool) {
+// QueueSprint queues the sync of the sprint. The job is unique per sprint, so that the syncs of a sprint never run
+// side by side, the options are stored on the queued status and merged by GetQueuedSyncOptions when the job runs.
+func (service SprintService) QueueSprint(
+	sprintID uint,
+	assignPoints bool,
+	fullResync bool,
+	trigger retroModels.SyncTrigger) {
 	db := service.DB
-	workers.Enqueuer.EnqueueUnique("sync_sprint_data", work.Q{"sprintID": fmt.Sprint(sprintID), "assignPoints": assignPoints})
-	db.Create(&retroModels.SprintSyncStatus{SprintID: sprintID, Status: retroModels.Queued})
+	// The status is recorded first, so that the options are in place before the job can run
+	db.Create(&retroModels.SprintSyncStatus{
+		SprintID:     sprintID,
+		Status:       retroModels.Queued,
+		Trigger:      trigger,
+		FullResync:   fullResync,
+		AssignPoints: assignPoints,
+	})
+	workers.Enqueuer.EnqueueUnique("sync_sprint_data", work.Q{"sprintID": fmt.Sprint(sprintID)})
+}
+
+// SyncOptions are the options of a sprint sync
+type SyncOptions struct {
+	AssignPoints bool
+	FullResync   bool
+	Trigger      retroModels.SyncTrigger
+}
+
+// GetQueuedSyncOptions merges the options of the syncs of the sprint queued since its last sync started, which are
+// run as one. The points are assigned and all the tasks are fetched again if any of the syncs asked for it, and the
+// sync is taken as manual if any of them was. It returns nil if no sync with the options is queued.
+func (service SprintService) GetQueuedSyncOptions(sprintID string) (*SyncOptions, error) {
+	db := service.DB
+
+	var queuedStatuses []retroModels.SprintSyncStatus
+	err := db.Model(&retroModels.SprintSyncStatus{}).
+		Where("sprint_sync_statuses.deleted_at IS NULL").
+		Where("sprint_id = ?", sprintID).
+		Where("status = ?", retroModels.Queued).
+		// The sprint member syncs are queued without the options
+		Where("trigger <> ''").
+		Where(`created_at > COALESCE((SELECT MAX(created_at) FROM sprint_sync_statuses AS started
+			WHERE started.deleted_at IS NULL AND started.sprint_id = sprint_sync_statuses.sprint_id
+			AND started.status = ?), '-infinity')`, retroModels.Syncing).
+		Order("created_at").
+		Find(&queuedStatuses).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+	if len(queuedStatuses) == 0 {
+		return nil, nil
+	}
+
+	options := &SyncOptions{}
+	for _, queuedStatus := range queuedStatuses {
+		options.AssignPoints = options.AssignPoints || queuedStatus.AssignPoints
+		options.FullResync = options.FullResync || queuedStatus.FullResync
+		if options.Trigger != retroModels.ManualSyncTrigger {
+			options.Trigger = queuedStatus.Trigger
+		}
+	}
+	return options, nil
 }
 
 // QueueSprintMember ...
@@ -133,6 +204,83 @@ func (service SprintService) QueueSprintMember(sprintID uint, sprintMemberID str
 	db.Create(&retroModels.SprintSyncStatus{SprintID: sprintID, Status: retroModels.Queued})
 }
 
+// QueueScheduledSprints queues the sync of the active sprints whose retrospective schedule has
+// fired since their last sync was queued. The sprints which are still syncing are skipped, unless
+// they have been syncing for longer than staleSyncingAge.
+func (service SprintService) QueueScheduledSprints() error {
+	db := service.DB
+	var sprints []retroModels.Sprint
+
+	location, err := time.LoadLocation(config.GetConfig().Server.TimeZone)
+	if err != nil {
+		utils.LogToSentry(err)
+		return err
+	}
+
+	err = db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Scopes(retroModels.NotDeletedSprint).
+		Where("sprints.status = ?", retroModels.ActiveSprint).
+		Joins("JOIN retrospectives ON sprints.retrospective_id = retrospectives.id").
+		Where("retrospectives.deleted_at IS NULL").
+		Where("retrospectives.sync_schedule <> ''").
+		Preload("Retrospective").
+		Find(&sprints).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return err
+	}
+
+	now := time.Now().In(location)
+	for _, sprint := range sprints {
+		var lastSyncStatus retroModels.SprintSyncStatus
+		err = db.Model(&retroModels.SprintSyncStatus{}).
+			Where("sprint_sync_statuses.deleted_at IS NULL").
+			Where("sprint_id = ?", sprint.ID).
+			Order("created_at DESC").
+			First(&lastSyncStatus).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			utils.LogToSentry(err)
+			return err
+		}
+		if err == nil && lastSyncStatus.Status == retroModels.Syncing &&
+			lastSyncStatus.CreatedAt.After(now.Add(-staleSyncingAge)) {
+			continue
+		}
+
+		var lastQueuedStatus retroModels.SprintSyncStatus
+		err = db.Model(&retroModels.SprintSyncStatus{}).
+			Where("sprint_sync_statuses.deleted_at IS NULL").
+			Where("sprint_id = ?", sprint.ID).
+			Where("status = ?", retroModels.Queued).
+			Order("created_at DESC").
+			First(&lastQueuedStatus).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			utils.LogToSentry(err)
+			return err
+		}
+
+		lastQueuedAt := sprint.CreatedAt
+		if err == nil {
+			lastQueuedAt = lastQueuedStatus.CreatedAt
+		}
+
+		nextSyncAt, err := retroModels.NextSyncTime(
+			sprint.Retrospective.SyncSchedule,
+			sprint.EndDate,
+			lastQueuedAt.In(location))
+		if err != nil {
+			// The invalid schedules are rejected on save, so this is unlikely unless edited in the database
+			utils.LogToSentry(err)
+			continue
+		}
+		if !nextSyncAt.IsZero() && !nextSyncAt.After(now) {
+			service.QueueSprint(sprint.ID, true, false, retroModels.JobSyncTrigger)
+		}
+	}
+	return nil
+}
+
 // SetNotSynced ...
 func (service SprintService) SetNotSynced(sprintID uint) {
 	db := service.DB
@@ -189,79 +337,80 @@ func (service SprintService) SyncSprintMemberData(sprintMemberID string) (err er
 
 	sprint := sprintMember.Sprint
 
-	if sprint.StartDate == nil || sprint.EndDate == nil {
+	runLog := service.startSyncRun(sprint.ID, retroModels.ManualSyncTrigger, false)
+	failSync := func(step string, err error) error {
+		utils.LogToSentry(err)
 		service.SetSyncFailed(sprint.ID)
-		return errors.New("sprint has no start/end date")
+		runLog.fail(step, err)
+		return err
+	}
+
+	if sprint.StartDate == nil || sprint.EndDate == nil {
+		return failSync(retroModels.SyncStepConfig, errors.New("sprint has no start/end date"))
 	}
 
 	service.SetSyncing(sprint.ID)
 
-	taskProviderConfig, err := tasktracker.DecryptTaskProviders(sprint.Retrospective.TaskProviderConfig)
+	taskProviderConfig, err := service.getValidTaskProviderConfig(sprint.Retrospective)
 	if err != nil {
-		utils.LogToSentry(err)
-		service.SetSyncFailed(sprint.ID)
-		return err
+		return failSync(retroModels.SyncStepConfig, err)
 	}
 
-	taskTrackerTaskKeySet, err := service.fetchAndUpdateTaskTrackerTask(sprint, taskProviderConfig)
+	keyProviders, err := service.fetchAndUpdateTaskTrackerTask(sprint, taskProviderConfig, false, runLog)
 	if err != nil {
-		utils.LogToSentry(err)
-		service.SetSyncFailed(sprint.ID)
-		return err
+		return failSync(retroModels.SyncStepTaskTracker, err)
+	}
+
+	if !timetracker.HasConnections(sprintMember.Member.TimeProviderConfig) {
+		runLog.addError(retroModels.SyncStepTimeTracker,
+			fmt.Errorf("member %s has no time tracker config", sprintMember.Member.Email))
 	}
 
 	var timeTrackerTaskKeys []string
 	var timeLogs []timeTrackerSerializers.TimeLog
-	timeTrackerTaskKeys, timeLogs, err = service.GetSprintMemberTimeTrackerData(sprintMember, sprint)
+	timeTrackerTaskKeys, timeLogs, err = service.GetSprintMemberTimeTrackerData(sprintMember, sprint, false)
 	if err != nil {
-		utils.LogToSentry(err)
-		service.SetSyncFailed(sprint.ID)
-		return err
+		return failSync(retroModels.SyncStepTimeTracker,
+			fmt.Errorf("failed to get the time logs of member %s: %s", sprintMember.Member.Email, err))
 	}
+	runLog.run.TimeLogsProcessed += uint(len(timeLogs))
 
-	insertedTimeTrackerTaskKeySet, err := service.fetchAndUpdateTimeTrackerTask(
+	err = service.fetchAndUpdateTimeTrackerTask(
 		sprint,
 		sprint.RetrospectiveID,
 		taskProviderConfig,
-		taskTrackerTaskKeySet,
-		timeTrackerTaskKeys)
+		keyProviders,
+		timeTrackerTaskKeys,
+		runLog)
 	if err != nil {
-		utils.LogToSentry(err)
-		service.SetSyncFailed(sprint.ID)
-		return err
+		return failSync(retroModels.SyncStepTimeTrackerTasks, err)
 	}
 
 	err = service.updateMissingTimeTrackerTask(sprint,
 		sprint.RetrospectiveID,
 		taskProviderConfig,
 		timeTrackerTaskKeys,
-		taskTrackerTaskKeySet,
-		insertedTimeTrackerTaskKeySet)
+		keyProviders,
+		runLog)
 	if err != nil {
-		utils.LogToSentry(err)
-		service.SetSyncFailed(sprint.ID)
-		return err
+		return failSync(retroModels.SyncStepTimeTrackerTasks, err)
 	}
 
-	err = service.updateSprintMemberTimeLog(
-		sprint.ID,
-		sprint.RetrospectiveID,
-		sprintMember.ID,
-		timeLogs)
+	err = service.updateSprintMemberTimeLog(sprint, sprintMember.ID, timeLogs, keyProviders)
 	if err != nil {
-		utils.LogToSentry(err)
-		service.SetSyncFailed(sprint.ID)
-		return err
+		return failSync(retroModels.SyncStepSprintMemberTasks, err)
 	}
 	// ToDo: Store tickets not in SMT
 	// Maybe a Join table ST
 
 	service.SetSynced(sprint.ID)
+	runLog.finish(retroModels.Synced)
 
 	return nil
 }
 
-// AssignPoints ...
+// AssignPoints splits the remaining points of the done tasks of the sprint among the sprint member tasks, as per the
+// point allocation strategy of the retrospective
 func (service SprintService) AssignPoints(sprintID string, sprintTaskID *string) (err error) {
 	fmt.Println("Assigning Points")
 	db := service.DB
@@ -279,6 +428,15 @@ func (service SprintService) AssignPoints(sprintID string, sprintTaskID *string)
 		return err
 	}
 
+	strategy, err := sprint.Retrospective.GetPointAllocationStrategy()
+	if err != nil {
+		utils.LogToSentry(err)
+		return err
+	}
+	if strategy == nil {
+		return nil
+	}
+
 	service.SetSyncing(sprint.ID)
 
 	// sprintTaskToSkipPointsAllocation is the list of all the sprint tasks which can be skipped for the points allocation,
@@ -297,21 +455,22 @@ func (service SprintService) AssignPoints(sprintID string, sprintTaskID *string)
 		Scopes(retroModels.NotDeletedSprint).
 		Where("tasks.retrospective_id = ?", sprint.RetrospectiveID).
 		Where("tasks.done_at IS NOT NULL").
-		Select(`
+		Select(fmt.Sprintf(`
             sprint_member_tasks.*, 
             sprint_members.sprint_id,
-            (SUM(sprint_member_tasks.time_spent_minutes)
-				OVER (PARTITION BY sprint_tasks.task_id, sprint_members.sprint_id)::numeric) AS sprint_task_total_time_spent,
+            (%[1]s)::numeric AS allocation_weight,
+            (SUM(%[1]s)
+				OVER (PARTITION BY sprint_tasks.task_id, sprint_members.sprint_id)::numeric) AS sprint_task_total_weight,
             (tasks.estimate - (SUM(sprint_member_tasks.points_earned) OVER
 				(PARTITION BY sprint_tasks.task_id)) + (SUM(sprint_member_tasks.points_earned) OVER
 				(PARTITION BY sprint_tasks.id))) AS remaining_points
-        `).QueryExpr()
+        `, strategy.WeightExpr())).QueryExpr()
 
 	updateSQL := `UPDATE sprint_member_tasks
-	    SET points_assigned = COALESCE(sprint_member_tasks.time_spent_minutes
-				/ NULLIF(s1.sprint_task_total_time_spent, 0) * s1.remaining_points, 0),
-            points_earned = COALESCE(sprint_member_tasks.time_spent_minutes
-				/ NULLIF(s1.sprint_task_total_time_spent, 0) * s1.remaining_points, 0),
+	    SET points_assigned = COALESCE(s1.allocation_weight
+				/ NULLIF(s1.sprint_task_total_weight, 0) * s1.remaining_points, 0),
+            points_earned = COALESCE(s1.allocation_weight
+				/ NULLIF(s1.sprint_task_total_weight, 0) * s1.remaining_points, 0),
             updated_at = NOW()
         FROM (?) AS s1 
         WHERE s1.sprint_id = ? AND sprint_member_tasks.id = s1.id`
@@ -336,33 +495,121 @@ func (service SprintService) AssignPoints(sprintID string, sprintTaskID *string)
 	return nil
 }
 
-// GetSprintMemberTimeTrackerData ...
+// GetSprintMemberTimeTrackerData returns the keys of the tasks worked on in the sprint by the sprint member, and the
+// time logs of the member aggregated per task per day. The time logs include the dated time logged within
+// lateTimeLogDays after the end of the sprint, the keys of the tasks worked on only after the end are not returned.
+// The time logs are fetched from the time trackers only from lateTimeLogDays before the time log sync mark of the
+// member, unless it is a full resync, and the earlier ones are taken from the daily time logs stored by the last sync.
 func (service SprintService) GetSprintMemberTimeTrackerData(
 	sprintMember retroModels.SprintMember,
-	sprint retroModels.Sprint) ([]string, []timeTrackerSerializers.TimeLog, error) {
+	sprint retroModels.Sprint,
+	fullResync bool) ([]string, []timeTrackerSerializers.TimeLog, error) {
+
+	var timeLogs []timeTrackerSerializers.TimeLog
+	var err error
+	// fetchStartKey is the date from which the time logs are fetched, all of them are fetched if it is empty
+	var fetchStartKey string
+	// The time of the members with no time tracker is taken from the uploaded time logs alone
+	if timetracker.HasConnections(sprintMember.Member.TimeProviderConfig) {
+		startDate, endDate, err := getSprintMemberDates(sprintMember, sprint)
+		if err != nil {
+			utils.LogToSentry(err)
+			return nil, nil, err
+		}
+		location := startDate.Location()
+
+		// The time logged till lateTimeLogDays before the last sync is taken to be settled
+		fetchStartDate := startDate
+		if !fullResync && sprintMember.TimeLogsSyncedAt != nil {
+			settledDate := utils.GetStartOfDay(sprintMember.TimeLogsSyncedAt.In(location)).
+				AddDate(0, 0, -lateTimeLogDays)
+			if settledDate.After(fetchStartDate) {
+				fetchStartDate = settledDate
+			}
+		}
+		if fetchStartDate.After(startDate) {
+			timeLogs, err = service.getStoredTimeLogs(sprintMember.ID, sprint.Retrospective.ProjectName,
+				startDate, fetchStartDate)
+			if err != nil {
+				utils.LogToSentry(err)
+				return nil, nil, err
+			}
+		}
+
+		if !fetchStartDate.After(endDate) {
+			sprintTimeLogs, err := timetracker.GetProjectTimeLogs(
+				sprintMember.Member.TimeProviderConfig,
+				sprint.Retrospective.ProjectName,
+				fetchStartDate,
+				endDate)
+			if err != nil {
+				utils.LogToSentry(err)
+				return nil, nil, err
+			}
+			timeLogs = append(timeLogs, sprintTimeLogs...)
+		}
+
+		// The time logged after the end of the sprint is fetched separately, so that the time of the time trackers
+		// which do not report the days is not counted in the sprint
+		lateStartDate := endDate.AddDate(0, 0, 1)
+		if fetchStartDate.After(lateStartDate) {
+			lateStartDate = fetchStartDate
+		}
+		if time.Now().After(lateStartDate) && !lateStartDate.After(endDate.AddDate(0, 0, lateTimeLogDays)) {
+			lateTimeLogs, err := timetracker.GetProjectTimeLogs(
+				sprintMember.Member.TimeProviderConfig,
+				sprint.Retrospective.ProjectName,
+				lateStartDate,
+				endDate.AddDate(0, 0, lateTimeLogDays))
+			if err != nil {
+				utils.LogToSentry(err)
+				return nil, nil, err
+			}
+			for _, timeLog := range lateTimeLogs {
+				if isLoggedAfterSprintEnd(timeLog, sprint) {
+					timeLogs = append(timeLogs, timeLog)
+				}
+			}
+		}
 
-	timeLogs, err := timetracker.GetProjectTimeLogs(
-		sprintMember.Member.TimeProviderConfig,
-		sprint.Retrospective.ProjectName,
-		*sprint.StartDate,
-		*sprint.EndDate)
+		fetchStartKey = fetchStartDate.Format(constants.CustomDateFormat)
+	}
 
+	uploadedTimeLogs, err := service.getUploadedTimeLogs(sprintMember.ID, sprint.Retrospective.ProjectName)
 	if err != nil {
 		utils.LogToSentry(err)
 		return nil, nil, err
 	}
+	// The uploaded time logs of the settled days are already in the stored daily time logs
+	for _, uploadedTimeLog := range uploadedTimeLogs {
+		if uploadedTimeLog.Date.Format(constants.CustomDateFormat) >= fetchStartKey {
+			timeLogs = append(timeLogs, uploadedTimeLog)
+		}
+	}
+	timeLogs = mergeTimeLogs(timeLogs, true)
 
 	var ticketKeys []string
 	for _, timeLog := range timeLogs {
-		ticketKeys = append(ticketKeys, timeLog.TaskKey)
-		if err != nil {
-			utils.LogToSentry(err)
-			return nil, nil, err
+		if !isLoggedAfterSprintEnd(timeLog, sprint) {
+			ticketKeys = append(ticketKeys, timeLog.TaskKey)
 		}
 	}
 	return ticketKeys, timeLogs, nil
 }
 
+// getSprintMemberDates returns the start and the end dates of the sprint in the time zone of the sprint member, the
+// members without a time zone are in the time zone of the time trackers
+func getSprintMemberDates(
+	sprintMember retroModels.SprintMember,
+	sprint retroModels.Sprint) (time.Time, time.Time, error) {
+	defaultLocation, err := time.LoadLocation(config.GetConfig().TimeTracker.TimeZone)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	location := sprintMember.Member.GetTimeZoneLocation(defaultLocation)
+	return utils.GetServerDateIn(*sprint.StartDate, location), utils.GetServerDateIn(*sprint.EndDate, location), nil
+}
+
 func (service SprintService) insertTimeTrackerTask(sprintID uint, ticketKey string, retroID uint) (err error) {
 	tx := service.DB.Begin()
 	var task retroModels.Task
@@ -381,7 +628,7 @@ func (service SprintService) insertTimeTrackerTask(sprintID uint, ticketKey stri
 		utils.LogToSentry(err)
 		return err
 	}
-	err = tx.Where(retroModels.TaskKeyMap{TaskID: task.ID, Key: ticketKey}).
+	err = tx.Where(retroModels.TaskKeyMap{TaskID: task.ID, Key: ticketKey, ProviderKey: task.ProviderKey}).
 		Where("task_key_maps.deleted_at IS NULL").
 		FirstOrCreate(&retroModels.TaskKeyMap{}).Error
 
@@ -501,46 +748,85 @@ func (service SprintService) changeTaskEstimates(tx *gorm.DB, task retroModels.T
 	return nil
 }
 
+// addOrUpdateTaskTrackerTask adds or updates the task tracker task in the sprint, it returns
+// whether the task was new to the retrospective
 func (service SprintService) addOrUpdateTaskTrackerTask(
 	sprint retroModels.Sprint,
 	ticket taskTrackerSerializers.Task,
 	retroID uint,
-	alternateTaskKey string) (err error) {
+	alternateTaskKey string) (isNewTask bool, err error) {
 
 	tx := service.DB.Begin()
-	var task retroModels.Task
+	var task, existingTask retroModels.Task
 
+	isNewTask = tx.Model(&retroModels.Task{}).
+		Where("tasks.deleted_at IS NULL").
+		Where(retroModels.Task{
+			RetrospectiveID: retroID,
+			TrackerUniqueID: ticket.TrackerUniqueID,
+			ProviderKey:     ticket.ProviderKey,
+		}).
+		First(&existingTask).RecordNotFound()
+
+	taskAttrs := retroModels.Task{
+		RetrospectiveID: retroID,
+		TrackerUniqueID: ticket.TrackerUniqueID,
+		ProviderKey:     ticket.ProviderKey,
+		Key:             ticket.Key,
+		Summary:         ticket.Summary,
+		Description:     ticket.Description,
+		Type:            ticket.Type,
+		Priority:        ticket.Priority,
+		Assignee:        ticket.Assignee,
+		Status:          ticket.Status,
+		IsTrackerTask:   true,
+		// The zero fields are not assigned, so a missing creation time does not clear the recorded one
+		TrackerCreatedAt: ticket.CreatedAt,
+	}
 	err = tx.Model(&retroModels.Task{}).
 		Where("tasks.deleted_at IS NULL").
-		Where(retroModels.Task{RetrospectiveID: retroID, TrackerUniqueID: ticket.TrackerUniqueID}).
-		Assign(retroModels.Task{
+		Where(retroModels.Task{
 			RetrospectiveID: retroID,
 			TrackerUniqueID: ticket.TrackerUniqueID,
-			Key:             ticket.Key,
-			Summary:         ticket.Summary,
-			Description:     ticket.Description,
-			Type:            ticket.Type,
-			Priority:        ticket.Priority,
-			Assignee:        ticket.Assignee,
-			Status:          ticket.Status,
-			IsTrackerTask:   true,
+			ProviderKey:     ticket.ProviderKey,
 		}).
+		Assign(taskAttrs).
 		FirstOrCreate(&task).Error
 
 	if err != nil {
 		tx.Rollback()
 		utils.LogToSentry(err)
-		return err
+		return false, err
+	}
+
+	// The links are updated explicitly, since they are cleared when the task is moved out of its parent or epic
+	err = tx.Model(&task).UpdateColumns(map[string]interface{}{
+		"parent_key": ticket.ParentKey,
+		"epic_key":   ticket.EpicKey,
+		"epic_name":  ticket.EpicName,
+	}).Error
+	if err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return false, err
+	}
+
+	err = retroModels.RecordTaskStatusChanges(
+		tx, task.ID, isNewTask, existingTask.Status, ticket.Status, ticket.StatusChanges)
+	if err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return false, err
 	}
 
 	statusMap, err := tasktracker.GetStatusMapping(sprint.Retrospective.TaskProviderConfig)
 	if err != nil {
 		utils.LogToSentry(err)
-		return errors.New("failed to fetch status mapping")
+		return false, errors.New("failed to fetch status mapping")
 	}
 
-	if len(statusMap[tasktracker.DoneStatus]) != 0 {
-		for _, status := range statusMap[tasktracker.DoneStatus] {
+	if doneStatuses := statusMap[ticket.ProviderKey][tasktracker.DoneStatus]; len(doneStatuses) != 0 {
+		for _, status := range doneStatuses {
 			if strings.ToLower(ticket.Status) == status {
 				err = tx.Model(&retroModels.Task{}).
 					Where("id = ?", task.ID).
@@ -548,31 +834,31 @@ func (service SprintService) addOrUpdateTaskTrackerTask(
 
 				if err != nil {
 					utils.LogToSentry(err)
-					return err
+					return false, err
 				}
 				break
 			}
 		}
 	}
-	err = tx.Where(retroModels.TaskKeyMap{TaskID: task.ID, Key: ticket.Key}).
+	err = tx.Where(retroModels.TaskKeyMap{TaskID: task.ID, Key: ticket.Key, ProviderKey: ticket.ProviderKey}).
 		Where("task_key_maps.deleted_at IS NULL").
 		FirstOrCreate(&retroModels.TaskKeyMap{}).Error
 
 	if err != nil {
 		tx.Rollback()
 		utils.LogToSentry(err)
-		return err
+		return false, err
 	}
 
 	if alternateTaskKey != "" {
-		err = tx.Where(retroModels.TaskKeyMap{TaskID: task.ID, Key: alternateTaskKey}).
+		err = tx.Where(retroModels.TaskKeyMap{TaskID: task.ID, Key: alternateTaskKey, ProviderKey: ticket.ProviderKey}).
 			Where("task_key_maps.deleted_at IS NULL").
 			FirstOrCreate(&retroModels.TaskKeyMap{}).Error
 
 		if err != nil {
 			tx.Rollback()
 			utils.LogToSentry(err)
-			return err
+			return false, err
 		}
 
 	}
@@ -584,7 +870,7 @@ func (service SprintService) addOrUpdateTaskTrackerTask(
 	if err != nil {
 		tx.Rollback()
 		utils.LogToSentry(err)
-		return err
+		return false, err
 	}
 
 	if ticket.Estimate == nil {
@@ -595,25 +881,61 @@ func (service SprintService) addOrUpdateTaskTrackerTask(
 	if err != nil {
 		tx.Rollback()
 		utils.LogToSentry(err)
-		return err
+		return false, err
 	}
 	tx.Commit()
-	return nil
+	return isNewTask, nil
 }
 
-// fetchAndUpdateTaskTrackerTask ...
+// fetchAndUpdateTaskTrackerTask fetches the sprint tasks updated in the task providers since the sync marks of the
+// sprint, and returns the task providers of the keys of the fetched tasks and of the tasks already in the sprint. The
+// tasks of the task providers which could be fetched are updated even if the others fail, but only their sync marks
+// are moved, so the updates of the failed providers are fetched again in the next sync.
 func (service SprintService) fetchAndUpdateTaskTrackerTask(
 	sprint retroModels.Sprint,
-	taskProviderConfig []byte) (mapset.Set, error) {
-	taskTrackerTaskKeySet := mapset.NewSet()
+	taskProviderConfig []byte,
+	fullResync bool,
+	runLog *syncRunLog) (*taskKeyProviders, error) {
+	syncStartedAt := time.Now()
 
-	tickets, err := tasktracker.GetSprintTaskList(
+	keyProviders, err := newTaskKeyProviders(taskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+
+	updatedSince := make(map[string]time.Time)
+	if !fullResync {
+		var err error
+		if updatedSince, err = service.getSyncMarks(sprint.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// The tasks already in the sprint need not be refetched for the time logs in an incremental sync
+	if len(updatedSince) != 0 {
+		sprintTaskKeys, err := service.getSprintTaskKeys(sprint.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, taskKey := range sprintTaskKeys {
+			keyProviders.add(taskKey.Key, taskKey.ProviderKey)
+		}
+	}
+
+	sprintIDs, err := sprint.GetProviderSprintIDs()
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+	tickets, providerErrors, err := tasktracker.GetUpdatedSprintTaskList(
 		taskProviderConfig,
 		taskTrackerSerializers.Sprint{
-			ID:       sprint.SprintID,
 			FromDate: sprint.StartDate,
 			ToDate:   sprint.EndDate,
 		},
+		sprintIDs,
+		updatedSince,
 	)
 	if err != nil {
 		utils.LogToSentry(err)
@@ -621,68 +943,149 @@ func (service SprintService) fetchAndUpdateTaskTrackerTask(
 	}
 
 	for _, ticket := range tickets {
-		err = service.addOrUpdateTaskTrackerTask(sprint, ticket, sprint.RetrospectiveID, "")
+		isNewTask, err := service.addOrUpdateTaskTrackerTask(sprint, ticket, sprint.RetrospectiveID, "")
 		if err != nil {
 			utils.LogToSentry(err)
 			return nil, err
 		}
-		taskTrackerTaskKeySet.Add(ticket.Key)
+		runLog.addTask(isNewTask)
+		keyProviders.add(ticket.Key, ticket.ProviderKey)
 	}
-	return taskTrackerTaskKeySet, nil
+
+	err = service.updateSyncMarks(sprint.ID, taskProviderConfig, syncStartedAt, providerErrors)
+	if err != nil {
+		return nil, err
+	}
+	if err = tasktracker.JoinProviderErrors(providerErrors); err != nil {
+		return nil, fmt.Errorf("failed to fetch the sprint tasks: %s", err)
+	}
+	return keyProviders, nil
+}
+
+// getSyncMarks returns the times since which the tasks are to be fetched from the task providers of the sprint
+func (service SprintService) getSyncMarks(sprintID uint) (map[string]time.Time, error) {
+	db := service.DB
+	var syncMarks []retroModels.SprintSyncMark
+
+	err := db.Model(&retroModels.SprintSyncMark{}).
+		Where("sprint_sync_marks.deleted_at IS NULL").
+		Where("sprint_id = ?", sprintID).
+		Find(&syncMarks).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+
+	updatedSince := make(map[string]time.Time)
+	for _, syncMark := range syncMarks {
+		updatedSince[syncMark.ProviderKey] = syncMark.SyncedAt.Add(-syncMarkOverlap)
+	}
+	return updatedSince, nil
+}
+
+// updateSyncMarks moves the sync marks of the task providers of the sprint to the given time, except the ones whose
+// tasks could not be fetched
+func (service SprintService) updateSyncMarks(
+	sprintID uint,
+	taskProviderConfig []byte,
+	syncedAt time.Time,
+	providerErrors map[string]error) error {
+	connections, err := tasktracker.GetConnections(taskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return err
+	}
+
+	tx := service.DB.Begin()
+	for _, connection := range connections {
+		if _, failed := providerErrors[connection.ProviderKey]; failed {
+			continue
+		}
+		err = tx.Where(retroModels.SprintSyncMark{SprintID: sprintID, ProviderKey: connection.ProviderKey}).
+			Where("sprint_sync_marks.deleted_at IS NULL").
+			Assign(retroModels.SprintSyncMark{SyncedAt: syncedAt}).
+			FirstOrCreate(&retroModels.SprintSyncMark{}).Error
+		if err != nil {
+			tx.Rollback()
+			utils.LogToSentry(err)
+			return err
+		}
+	}
+	return tx.Commit().Error
 }
 
-// fetchAndUpdateTimeTrackerTask ...
+// getSprintTaskKeys returns all the keys of the tasks in the sprint along with their task providers
+func (service SprintService) getSprintTaskKeys(sprintID uint) ([]providerTaskKey, error) {
+	db := service.DB
+	var taskKeys []providerTaskKey
+
+	err := db.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Where("sprint_tasks.sprint_id = ?", sprintID).
+		Scopes(retroModels.STJoinTask, retroModels.TaskJoinTaskKeyMaps).
+		Select("DISTINCT task_key_maps.key, task_key_maps.provider_key").
+		Scan(&taskKeys).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+	return taskKeys, nil
+}
+
+// fetchAndUpdateTimeTrackerTask fetches the tasks of the time logs which are not known yet from the task providers,
+// and records their task providers
 func (service SprintService) fetchAndUpdateTimeTrackerTask(
 	sprint retroModels.Sprint,
 	retroID uint,
 	taskProviderConfig []byte,
-	taskTrackerTaskKeySet mapset.Set,
-	timeTrackerTaskKeys []string) (mapset.Set, error) {
-	timeTrackerTaskKeySet := mapset.NewSetFromSlice(utils.StringSliceToInterfaceSlice(timeTrackerTaskKeys))
-	missingTaskSet := timeTrackerTaskKeySet.Difference(taskTrackerTaskKeySet)
-	missingTaskKeys := missingTaskSet.ToSlice()
+	keyProviders *taskKeyProviders,
+	timeTrackerTaskKeys []string,
+	runLog *syncRunLog) error {
+	missingTaskKeys := keyProviders.getMissingKeys(timeTrackerTaskKeys)
 
-	tickets, err := tasktracker.GetTaskList(taskProviderConfig, utils.InterfaceSliceToStringSlice(missingTaskKeys))
+	tickets, err := tasktracker.GetTaskList(taskProviderConfig, missingTaskKeys)
 	if err != nil {
 		utils.LogToSentry(err)
-		return nil, err
+		return err
 	}
 
-	timeTrackerTaskKeySet.Clear()
 	for _, ticket := range tickets {
-		err = service.addOrUpdateTaskTrackerTask(sprint, ticket, retroID, "")
+		isNewTask, err := service.addOrUpdateTaskTrackerTask(sprint, ticket, retroID, "")
 		if err != nil {
 			utils.LogToSentry(err)
-			return nil, err
+			return err
 		}
-		timeTrackerTaskKeySet.Add(ticket.Key)
+		runLog.addTask(isNewTask)
+		keyProviders.add(ticket.Key, ticket.ProviderKey)
 	}
-	return timeTrackerTaskKeySet, nil
+	return nil
 }
 
-// updateMissingTimeTrackerTask ...
+// updateMissingTimeTrackerTask adds the tasks of the time logs which are still not known, either by looking them up
+// with their alternate keys in the task providers, or as the time tracker tasks, and records their task providers
 func (service SprintService) updateMissingTimeTrackerTask(
 	sprint retroModels.Sprint,
 	retroID uint,
 	taskProviderConfig []byte,
 	timeTrackerTaskKeys []string,
-	taskTrackerTaskKeySet mapset.Set,
-	insertedTimeTrackerTaskKeySet mapset.Set) error {
-	timeTrackerTaskKeySet := mapset.NewSetFromSlice(utils.StringSliceToInterfaceSlice(timeTrackerTaskKeys))
-	missingTaskSet := timeTrackerTaskKeySet.Difference(insertedTimeTrackerTaskKeySet)
-	missingTaskSet = missingTaskSet.Difference(taskTrackerTaskKeySet)
-	missingTaskKeys := missingTaskSet.ToSlice()
-	for _, taskKey := range missingTaskKeys {
-		task, err := tasktracker.GetTaskDetails(taskProviderConfig, taskKey.(string))
+	keyProviders *taskKeyProviders,
+	runLog *syncRunLog) error {
+	for _, taskKey := range keyProviders.getMissingKeys(timeTrackerTaskKeys) {
+		task, err := tasktracker.GetTaskDetails(taskProviderConfig, taskKey)
 		if err != nil {
 			utils.LogToSentry(err)
 			return err
 		}
 
 		if task != nil {
-			err = service.addOrUpdateTaskTrackerTask(sprint, *task, retroID, taskKey.(string))
+			var isNewTask bool
+			isNewTask, err = service.addOrUpdateTaskTrackerTask(sprint, *task, retroID, taskKey)
+			runLog.addTask(isNewTask)
+			keyProviders.add(taskKey, task.ProviderKey)
 		} else {
-			err = service.insertTimeTrackerTask(sprint.ID, taskKey.(string), retroID)
+			// The time tracker tasks do not belong to any task provider
+			err = service.insertTimeTrackerTask(sprint.ID, taskKey, retroID)
+			keyProviders.add(taskKey, "")
 		}
 		if err != nil {
 			utils.LogToSentry(err)
@@ -692,26 +1095,148 @@ func (service SprintService) updateMissingTimeTrackerTask(
 	return nil
 }
 
+// updateSprintMemberTimeLog updates the time spent by the sprint member on the tasks as per the time logs, and the
+// daily time logs of the member. Only the changed time spent is written, and the time spent on the tasks which are
+// no longer in the time logs is reset. The time logged after the end of the sprint is not counted in the time spent.
 func (service SprintService) updateSprintMemberTimeLog(
-	sprintID uint,
-	retroID uint,
+	sprint retroModels.Sprint,
 	sprintMemberID uint,
-	timeLogs []timeTrackerSerializers.TimeLog) error {
+	timeLogs []timeTrackerSerializers.TimeLog,
+	keyProviders *taskKeyProviders) error {
 
 	db := service.DB
-	// Reset existing time_spent
-	err := db.Exec("UPDATE sprint_member_tasks SET time_spent_minutes=0 WHERE sprint_member_id = ?", sprintMemberID).Error
+	sprintID, retroID := sprint.ID, sprint.RetrospectiveID
 
+	var sprintTimeLogs []timeTrackerSerializers.TimeLog
+	for _, timeLog := range timeLogs {
+		if !isLoggedAfterSprintEnd(timeLog, sprint) {
+			sprintTimeLogs = append(sprintTimeLogs, timeLog)
+		}
+	}
+	sprintTimeLogs = mergeTimeLogs(sprintTimeLogs, false)
+
+	sprintMemberTaskLogs, err := service.getSprintMemberTaskLogs(sprintMemberID)
 	if err != nil {
 		utils.LogToSentry(err)
 		return err
 	}
-	for _, timeLog := range timeLogs {
-		err = service.addOrUpdateSMT(timeLog, sprintMemberID, sprintID, retroID)
+
+	loggedMinutes := make(map[providerTaskKey]uint)
+	for _, timeLog := range sprintTimeLogs {
+		loggedMinutes[keyProviders.get(timeLog.TaskKey)] = timeLog.Minutes
+	}
+
+	// A task can have more than one key, the time spent is kept if it is logged against any of them
+	existingMinutes := make(map[providerTaskKey]uint)
+	loggedSMTIDs := mapset.NewSet()
+	for _, smtLog := range sprintMemberTaskLogs {
+		taskKey := providerTaskKey{Key: smtLog.Key, ProviderKey: smtLog.ProviderKey}
+		existingMinutes[taskKey] = smtLog.TimeSpentMinutes
+		if _, isLogged := loggedMinutes[taskKey]; isLogged {
+			loggedSMTIDs.Add(smtLog.ID)
+		}
+	}
+
+	var unloggedSMTIDs []uint
+	for _, smtLog := range sprintMemberTaskLogs {
+		if smtLog.TimeSpentMinutes != 0 && !loggedSMTIDs.Contains(smtLog.ID) {
+			unloggedSMTIDs = append(unloggedSMTIDs, smtLog.ID)
+		}
+	}
+	if len(unloggedSMTIDs) != 0 {
+		err = db.Exec("UPDATE sprint_member_tasks SET time_spent_minutes=0 WHERE id IN (?)", unloggedSMTIDs).Error
+		if err != nil {
+			utils.LogToSentry(err)
+			return err
+		}
+	}
+
+	for _, timeLog := range sprintTimeLogs {
+		taskKey := keyProviders.get(timeLog.TaskKey)
+		if minutes, exists := existingMinutes[taskKey]; exists && minutes == timeLog.Minutes {
+			continue
+		}
+		err = service.addOrUpdateSMT(timeLog, taskKey.ProviderKey, sprintMemberID, sprintID, retroID)
+		if err != nil {
+			utils.LogToSentry(err)
+			return err
+		}
+	}
+	if err = service.updateDailyTimeLogs(sprintMemberID, timeLogs, keyProviders); err != nil {
+		return err
+	}
+
+	err = db.Model(&retroModels.SprintMember{}).
+		Where("id = ?", sprintMemberID).
+		UpdateColumn("time_logs_synced_at", time.Now()).Error
+	if err != nil {
+		utils.LogToSentry(err)
+	}
+	return err
+}
+
+// SyncTaskTrackerTask refreshes the given task in the active sprints of the retrospective which have the task,
+// used to apply the task tracker changes received through the webhooks without syncing the whole sprint
+func (service SprintService) SyncTaskTrackerTask(retroID string, providerKey string, taskKey string) error {
+	db := service.DB
+	var sprints []retroModels.Sprint
+
+	err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("sprints.retrospective_id = ?", retroID).
+		Where("sprints.status = ?", retroModels.ActiveSprint).
+		Where("sprints.start_date IS NOT NULL AND sprints.end_date IS NOT NULL").
+		Scopes(retroModels.SprintJoinST, retroModels.STJoinTask, retroModels.TaskJoinTaskKeyMaps).
+		Where("task_key_maps.key = ?", taskKey).
+		Where("task_key_maps.provider_key = ?", providerKey).
+		Select("DISTINCT sprints.*").
+		Preload("Retrospective").
+		Find(&sprints).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return err
+	}
+	// The task is not part of any active sprint, it will be picked up by the next sprint sync if needed
+	if len(sprints) == 0 {
+		return nil
+	}
+
+	taskProviderConfig, err := tasktracker.DecryptTaskProviders(sprints[0].Retrospective.TaskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return err
+	}
+	connections, err := tasktracker.GetConnections(taskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return err
+	}
+
+	ticket, err := connections.Get(providerKey).GetTask(taskKey)
+	if err != nil {
+		utils.LogToSentry(err)
+		return err
+	}
+	if ticket == nil {
+		return nil
+	}
+	ticket.ProviderKey = providerKey
+
+	// The key of the task might have changed in the task tracker, eg. the JIRA issue moved to another project
+	alternateTaskKey := ""
+	if ticket.Key != taskKey {
+		alternateTaskKey = taskKey
+	}
+	for _, sprint := range sprints {
+		runLog := service.startSyncRun(sprint.ID, retroModels.WebhookSyncTrigger, false)
+		isNewTask, err := service.addOrUpdateTaskTrackerTask(sprint, *ticket, sprint.RetrospectiveID, alternateTaskKey)
 		if err != nil {
 			utils.LogToSentry(err)
+			runLog.fail(retroModels.SyncStepTaskTracker, err)
 			return err
 		}
+		runLog.addTask(isNewTask)
+		runLog.finish(retroModels.Synced)
 	}
 	return nil
 }
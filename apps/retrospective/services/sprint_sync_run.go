@@ -0,0 +1,187 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// syncRunsPerPage is the number of the latest sync runs returned for a sprint
+const syncRunsPerPage = 20
+
+// syncRunLog records the progress of a sprint sync run
+type syncRunLog struct {
+	db     *gorm.DB
+	run    retroModels.SprintSyncRun
+	errors []retroModels.SyncRunError
+}
+
+// startSyncRun records the start of a sync run of the sprint
+func (service SprintService) startSyncRun(
+	sprintID uint,
+	trigger retroModels.SyncTrigger,
+	fullResync bool) *syncRunLog {
+	runLog := &syncRunLog{
+		db: service.DB,
+		run: retroModels.SprintSyncRun{
+			SprintID:   sprintID,
+			Trigger:    trigger,
+			FullResync: fullResync,
+			Status:     retroModels.Syncing,
+			StartedAt:  time.Now(),
+			Errors:     []byte("[]"),
+		},
+		errors: []retroModels.SyncRunError{},
+	}
+	// The sync goes on even if the run could not be recorded
+	if err := service.DB.Create(&runLog.run).Error; err != nil {
+		utils.LogToSentry(err)
+	}
+	return runLog
+}
+
+// addError records the error of a step of the run, the run goes on unless it is failed
+func (runLog *syncRunLog) addError(step string, err error) {
+	runLog.errors = append(runLog.errors, retroModels.SyncRunError{Step: step, Message: err.Error()})
+}
+
+// addTask counts a task fetched from the task tracker in the run
+func (runLog *syncRunLog) addTask(isNewTask bool) {
+	runLog.run.TasksFetched++
+	if isNewTask {
+		runLog.run.TasksInserted++
+	} else {
+		runLog.run.TasksUpdated++
+	}
+}
+
+// fail records the error of the step and marks the run as failed
+func (runLog *syncRunLog) fail(step string, err error) {
+	runLog.addError(step, err)
+	runLog.finish(retroModels.SyncFailed)
+}
+
+// finish records the end of the run with the given status
+func (runLog *syncRunLog) finish(status retroModels.SyncStatus) {
+	endedAt := time.Now()
+	runLog.run.EndedAt = &endedAt
+	runLog.run.Status = status
+
+	runErrors, err := json.Marshal(runLog.errors)
+	if err != nil {
+		utils.LogToSentry(err)
+		return
+	}
+	runLog.run.Errors = runErrors
+
+	if err = runLog.db.Save(&runLog.run).Error; err != nil {
+		utils.LogToSentry(err)
+	}
+}
+
+// GetSyncRuns returns the latest sync runs of the sprint
+func (service SprintService) GetSyncRuns(
+	sprintID string,
+	retroID string) (*retroSerializers.SprintSyncRunsSerializer, int, error) {
+	db := service.DB
+	syncRuns := new(retroSerializers.SprintSyncRunsSerializer)
+
+	err := db.Model(&retroModels.SprintSyncRun{}).
+		Where("sprint_sync_runs.deleted_at IS NULL").
+		Joins("JOIN sprints ON sprint_sync_runs.sprint_id = sprints.id AND sprints.deleted_at IS NULL").
+		Scopes(retroModels.NotDeletedSprint).
+		Where("sprints.id = ?", sprintID).
+		Where("sprints.retrospective_id = ?", retroID).
+		Order("sprint_sync_runs.started_at DESC").
+		Limit(syncRunsPerPage).
+		Select("sprint_sync_runs.*").
+		Scan(&syncRuns.SyncRuns).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sync runs")
+	}
+	return syncRuns, http.StatusOK, nil
+}
+
+// RetrySync queues the sync of the sprint again, with the options of its last sync run
+func (service SprintService) RetrySync(sprintID string, retroID string) (int, error) {
+	db := service.DB
+	var sprint retroModels.Sprint
+
+	err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Scopes(retroModels.NotDeletedSprint).
+		Where("id = ?", sprintID).
+		Where("retrospective_id = ?", retroID).
+		Find(&sprint).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return http.StatusNotFound, errors.New("sprint not found")
+		}
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to retry sync")
+	}
+
+	var lastSyncStatus retroModels.SprintSyncStatus
+	err = db.Model(&retroModels.SprintSyncStatus{}).
+		Where("sprint_sync_statuses.deleted_at IS NULL").
+		Where("sprint_id = ?", sprint.ID).
+		Order("created_at DESC").
+		First(&lastSyncStatus).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to retry sync")
+	}
+	// The queued syncs are not checked since the queue runs the duplicate syncs of a sprint as one anyway
+	if err == nil && lastSyncStatus.Status == retroModels.Syncing {
+		return http.StatusConflict, errors.New("sprint is already being synced")
+	}
+
+	var lastSyncRun retroModels.SprintSyncRun
+	err = db.Model(&retroModels.SprintSyncRun{}).
+		Where("sprint_sync_runs.deleted_at IS NULL").
+		Where("sprint_id = ?", sprint.ID).
+		Order("started_at DESC").
+		First(&lastSyncRun).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to retry sync")
+	}
+
+	service.QueueSprint(
+		sprint.ID,
+		sprint.Status == retroModels.ActiveSprint,
+		lastSyncRun.FullResync,
+		retroModels.ManualSyncTrigger)
+	return http.StatusNoContent, nil
+}
+
+// getValidTaskProviderConfig returns the decrypted task provider config of the retrospective after checking that
+// the task providers are reachable with the configured credentials, so that the sync fails with the reason instead
+// of syncing no tasks
+func (service SprintService) getValidTaskProviderConfig(retro retroModels.Retrospective) ([]byte, error) {
+	taskProviderConfig, err := tasktracker.DecryptTaskProviders(retro.TaskProviderConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt the task provider config: %s", err)
+	}
+
+	connections, err := tasktracker.GetConnections(taskProviderConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task provider config: %s", err)
+	}
+	for _, connection := range connections {
+		if err = connection.ValidateConfig(); err != nil {
+			return nil, fmt.Errorf("%s: %s", connection.ProviderKey, err)
+		}
+	}
+	return taskProviderConfig, nil
+}
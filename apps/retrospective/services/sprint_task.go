@@ -44,7 +44,7 @@ func (service SprintTaskService) List(
 		return nil, http.StatusInternalServerError, errors.New("failed to get issues")
 	}
 
-	connection, err := retroModels.GetTaskTrackerConnectionFromRetro(db, retroID)
+	connections, err := retroModels.GetTaskTrackerConnectionsFromRetro(db, retroID)
 	if err != nil {
 		utils.LogToSentry(err)
 		return nil, http.StatusBadRequest, errors.New("invalid retrospective")
@@ -52,13 +52,18 @@ func (service SprintTaskService) List(
 	for _, task := range taskList.Tasks {
 		// Set task URL according to the task provider
 		if task.IsTrackerTask {
-			task.URL = connection.GetTaskUrl(task.Key)
+			task.URL = connections.GetTaskUrl(task.ProviderKey, task.Key)
 		}
 		var participantsSlice = strings.Split(task.TaskParticipants, ", ")
 
 		task.TaskParticipants = strings.Join(utils.RemoveDuplicatesFromSlice(participantsSlice)[:], ", ")
 	}
 
+	if err = setCarryOvers(db, sprintID, taskList.Tasks); err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get issues")
+	}
+
 	return taskList, http.StatusOK, nil
 }
 
@@ -88,13 +93,17 @@ func (service SprintTaskService) Get(
 		return nil, http.StatusInternalServerError, errors.New("failed to get issue")
 	}
 
-	connection, err := retroModels.GetTaskTrackerConnectionFromRetro(db, retroID)
+	connections, err := retroModels.GetTaskTrackerConnectionsFromRetro(db, retroID)
 	if err != nil {
 		return nil, http.StatusBadRequest, errors.New("invalid retrospective")
 	}
 	// Set task URL according to the task provider
 	if task.IsTrackerTask {
-		task.URL = connection.GetTaskUrl(task.Key)
+		task.URL = connections.GetTaskUrl(task.ProviderKey, task.Key)
+	}
+	if err = setCarryOvers(db, sprintID, []*retroSerializers.SprintTask{&task}); err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get issue")
 	}
 	return &task, http.StatusOK, nil
 }
@@ -280,11 +289,15 @@ func (service SprintTaskService) tasksWithTimeDetailsForCurrentAndPrevSprint(ret
             sprint_tasks.id,
             tasks.key,
             tasks.tracker_unique_id,
+            tasks.provider_key,
             tasks.summary,
             tasks.description,
             tasks.type,
             tasks.status,
             tasks.priority,
+            tasks.parent_key,
+            tasks.epic_key,
+            tasks.epic_name,
             tasks.assignee,
             task_owners.member_name AS owner,
             task_owners.task_participants AS task_participants,
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// chronicCarryOverSprints is the number of the sprints spanned by a task after which its carry-over is chronic
+const chronicCarryOverSprints = 3
+
+// taskCarryOver is the carry-over of a task of a sprint from the previous sprints of the retrospective
+type taskCarryOver struct {
+	// isCarriedOver is set if the task was in the previous sprint and was not done by its end
+	isCarriedOver bool
+	// sprintEfforts are the efforts on the task in the sprints it has spanned till the sprint, in the sprint order
+	sprintEfforts []retroSerializers.TaskSprintEffort
+}
+
+// getTaskCarryOvers returns the carry-overs of the tasks of the sprint mapped by their sprint task ids
+func getTaskCarryOvers(db *gorm.DB, sprint retroModels.Sprint) (map[uint]*taskCarryOver, error) {
+	var previousSprint retroModels.Sprint
+	err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Scopes(retroModels.NotDeletedSprint).
+		Where("retrospective_id = ?", sprint.RetrospectiveID).
+		Where("start_date < ?", sprint.StartDate).
+		Order("start_date DESC").
+		First(&previousSprint).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	var sprintTasks []struct {
+		ID     uint
+		TaskID uint
+	}
+	err = db.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Where("sprint_id = ?", sprint.ID).
+		Select("id, task_id").
+		Scan(&sprintTasks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	taskSprintEfforts := make(map[uint][]retroSerializers.TaskSprintEffort)
+	taskDoneDates := make(map[uint]*time.Time)
+	taskSprintTaskIDs := make(map[uint]uint)
+	for _, sprintTask := range sprintTasks {
+		taskSprintTaskIDs[sprintTask.TaskID] = sprintTask.ID
+	}
+
+	var sprintEfforts []struct {
+		retroSerializers.TaskSprintEffort
+		TaskID uint
+		DoneAt *time.Time
+	}
+	err = db.Model(&retroModels.Task{}).
+		Where("tasks.deleted_at IS NULL").
+		Scopes(retroModels.TaskJoinST, retroModels.STJoinSprint, retroModels.NotDeletedSprint, retroModels.STLeftJoinSMT).
+		Where("tasks.id IN (?)", db.Model(&retroModels.SprintTask{}).
+			Where("sprint_tasks.deleted_at IS NULL").
+			Where("sprint_id = ?", sprint.ID).
+			Select("task_id").
+			QueryExpr()).
+		Where("sprints.start_date <= ?", sprint.StartDate).
+		Group("tasks.id, sprints.id").
+		Order("tasks.id, sprints.start_date").
+		Select(`
+            tasks.id AS task_id,
+            tasks.done_at,
+            sprints.id AS sprint_id,
+            sprints.title,
+            sprints.start_date,
+            sprints.end_date,
+            COALESCE(SUM(sprint_member_tasks.time_spent_minutes), 0) AS time_spent_minutes,
+            COALESCE(SUM(sprint_member_tasks.points_earned), 0) AS points_earned`).
+		Scan(&sprintEfforts).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, sprintEffort := range sprintEfforts {
+		taskSprintEfforts[sprintEffort.TaskID] = append(
+			taskSprintEfforts[sprintEffort.TaskID], sprintEffort.TaskSprintEffort)
+		taskDoneDates[sprintEffort.TaskID] = sprintEffort.DoneAt
+	}
+
+	carryOvers := make(map[uint]*taskCarryOver)
+	for taskID, sprintTaskID := range taskSprintTaskIDs {
+		carryOver := &taskCarryOver{sprintEfforts: taskSprintEfforts[taskID]}
+		if carryOver.sprintEfforts == nil {
+			carryOver.sprintEfforts = []retroSerializers.TaskSprintEffort{}
+		}
+		for _, sprintEffort := range carryOver.sprintEfforts {
+			if previousSprint.ID == 0 || sprintEffort.SprintID != previousSprint.ID {
+				continue
+			}
+			doneAt := taskDoneDates[taskID]
+			carryOver.isCarriedOver = doneAt == nil || previousSprint.EndDate == nil || doneAt.After(*previousSprint.EndDate)
+		}
+		carryOvers[sprintTaskID] = carryOver
+	}
+	return carryOvers, nil
+}
+
+// setCarryOvers sets the carry-over details of the tasks of the sprint
+func setCarryOvers(db *gorm.DB, sprintID string, tasks []*retroSerializers.SprintTask) error {
+	var sprint retroModels.Sprint
+	err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("id = ?", sprintID).
+		First(&sprint).Error
+	if err != nil {
+		return err
+	}
+
+	carryOvers, err := getTaskCarryOvers(db, sprint)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if carryOver, exists := carryOvers[task.ID]; exists {
+			task.IsCarriedOver = carryOver.isCarriedOver
+			task.SprintCount = len(carryOver.sprintEfforts)
+			task.SprintEfforts = carryOver.sprintEfforts
+		}
+	}
+	return nil
+}
+
+// GetSpilloverSummary returns the tasks of the sprint which were carried over from the previous sprint, the tasks
+// which were not done by the end of the sprint, and the tasks which have been carried over chronically
+func (service SprintService) GetSpilloverSummary(
+	sprintID string,
+	retroID string) (*retroSerializers.SpilloverSummary, int, error) {
+	db := service.DB
+	var sprint retroModels.Sprint
+
+	err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Scopes(retroModels.NotDeletedSprint).
+		Where("id = ?", sprintID).
+		Where("retrospective_id = ?", retroID).
+		Find(&sprint).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get spillover summary")
+	}
+
+	var tasks []retroSerializers.SpilloverTask
+	err = db.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Where("sprint_tasks.sprint_id = ?", sprint.ID).
+		Scopes(retroModels.STJoinTask).
+		Order("tasks.tracker_unique_id").
+		Select(`
+            sprint_tasks.id AS sprint_task_id,
+            tasks.key,
+            tasks.summary,
+            tasks.type,
+            tasks.status,
+            tasks.estimate,
+            tasks.done_at`).
+		Scan(&tasks).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get spillover summary")
+	}
+
+	carryOvers, err := getTaskCarryOvers(db, sprint)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get spillover summary")
+	}
+
+	summary := &retroSerializers.SpilloverSummary{
+		TaskCount:        len(tasks),
+		CarriedOverTasks: []retroSerializers.SpilloverTask{},
+		SpilledOverTasks: []retroSerializers.SpilloverTask{},
+		ChronicTasks:     []retroSerializers.SpilloverTask{},
+	}
+	for _, task := range tasks {
+		carryOver, exists := carryOvers[task.SprintTaskID]
+		if !exists {
+			continue
+		}
+		task.SprintCount = len(carryOver.sprintEfforts)
+		task.SprintEfforts = carryOver.sprintEfforts
+
+		isDone := task.DoneAt != nil && (sprint.EndDate == nil || !task.DoneAt.After(*sprint.EndDate))
+		if carryOver.isCarriedOver {
+			summary.CarriedOverPoints += task.Estimate
+			summary.CarriedOverTasks = append(summary.CarriedOverTasks, task)
+		}
+		if !isDone {
+			summary.SpilledOverPoints += task.Estimate
+			summary.SpilledOverTasks = append(summary.SpilledOverTasks, task)
+		}
+		if !isDone && task.SprintCount >= chronicCarryOverSprints {
+			summary.ChronicTasks = append(summary.ChronicTasks, task)
+		}
+	}
+	// The chronic carry-overs are listed from the ones spanning the most sprints
+	sort.SliceStable(summary.ChronicTasks, func(i, j int) bool {
+		return summary.ChronicTasks[i].SprintCount > summary.ChronicTasks[j].SprintCount
+	})
+	return summary, http.StatusOK, nil
+}
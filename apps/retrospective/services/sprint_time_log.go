@@ -0,0 +1,318 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/deckarep/golang-set"
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	"github.com/iReflect/reflect-app/apps/timetracker"
+	timeTrackerSerializers "github.com/iReflect/reflect-app/apps/timetracker/serializers"
+	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// uploadedTimeLogLogger is the logger of the time logs imported from the uploaded files
+const uploadedTimeLogLogger = "Upload"
+
+// ImportTimeLogs validates the uploaded time log file and imports the time logs of the sprint members. The
+// uploaded time logs of a member replace the ones previously uploaded for the member, and the sprint is queued
+// for sync so that they are processed along with the time tracker logs. Nothing is imported if any of the rows
+// is invalid, the report is returned with the StatusUnprocessableEntity status in that case.
+func (service SprintService) ImportTimeLogs(
+	retroID string,
+	sprintID string,
+	userID uint,
+	fileName string,
+	file io.Reader,
+	dryRun bool) (*retroSerializers.TimeLogImportReport, int, error) {
+	db := service.DB
+	var sprint retroModels.Sprint
+
+	err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Scopes(retroModels.NotDeletedSprint).
+		Where("id = ?", sprintID).
+		Where("retrospective_id = ?", retroID).
+		Preload("SprintMembers.Member").
+		Preload("Retrospective").
+		Find(&sprint).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint")
+	}
+
+	if sprint.StartDate == nil || sprint.EndDate == nil {
+		return nil, http.StatusUnprocessableEntity, errors.New("sprint has no start/end date")
+	}
+
+	timeLogs, rowErrors, err := timetracker.ParseTimeLogFile(fileName, file)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	report := &retroSerializers.TimeLogImportReport{
+		DryRun:    dryRun,
+		TotalRows: len(timeLogs) + len(rowErrors),
+		Errors:    rowErrors,
+	}
+
+	// The dates of the time logs are checked against the sprint dates in the time zone of the member, as the time
+	// logs of the time trackers are fetched
+	type sprintMemberDates struct {
+		sprintMemberID uint
+		startDate      string
+		endDate        string
+	}
+	sprintMembers := make(map[string]sprintMemberDates)
+	for _, sprintMember := range sprint.SprintMembers {
+		startDate, endDate, err := getSprintMemberDates(sprintMember, sprint)
+		if err != nil {
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to import time logs")
+		}
+		sprintMembers[strings.ToLower(sprintMember.Member.Email)] = sprintMemberDates{
+			sprintMemberID: sprintMember.ID,
+			startDate:      startDate.Format(constants.CustomDateFormat),
+			endDate:        endDate.Format(constants.CustomDateFormat),
+		}
+	}
+
+	var uploadedTimeLogs []retroModels.UploadedTimeLog
+	unknownMemberEmails := mapset.NewSet()
+	taskKeys := mapset.NewSet()
+	for _, timeLog := range timeLogs {
+		memberDates, isMember := sprintMembers[timeLog.Logger]
+		if !isMember {
+			unknownMemberEmails.Add(timeLog.Logger)
+			report.Errors = append(report.Errors, newTimeLogRowError(timeLog, "logger is not a member of the sprint"))
+			continue
+		}
+
+		// The dates are compared as YYYY-MM-DD strings, which sort chronologically
+		date := timeLog.Date.Format(constants.CustomDateFormat)
+		if date < memberDates.startDate || date > memberDates.endDate {
+			report.Errors = append(report.Errors, newTimeLogRowError(timeLog, "date is outside the sprint"))
+			continue
+		}
+
+		taskKeys.Add(timeLog.TaskKey)
+		uploadedTimeLogs = append(uploadedTimeLogs, retroModels.UploadedTimeLog{
+			SprintMemberID: memberDates.sprintMemberID,
+			TaskKey:        timeLog.TaskKey,
+			Minutes:        timeLog.Minutes,
+			Date:           timeLog.Date,
+			UploadedByID:   userID,
+		})
+	}
+
+	sort.Slice(report.Errors, func(i, j int) bool { return report.Errors[i].Row < report.Errors[j].Row })
+	report.UnknownMemberEmails = sortedStringSet(unknownMemberEmails)
+	report.UnknownTaskKeys, err = service.getUnknownTaskKeys(sprint, utils.InterfaceSliceToStringSlice(taskKeys.ToSlice()))
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to validate the task keys")
+	}
+
+	if len(report.Errors) != 0 {
+		return report, http.StatusUnprocessableEntity, nil
+	}
+	if dryRun {
+		return report, http.StatusOK, nil
+	}
+
+	tx := db.Begin()
+	uploadedSprintMemberIDs := mapset.NewSet()
+	for _, uploadedTimeLog := range uploadedTimeLogs {
+		if !uploadedSprintMemberIDs.Contains(uploadedTimeLog.SprintMemberID) {
+			uploadedSprintMemberIDs.Add(uploadedTimeLog.SprintMemberID)
+			err = tx.Where("uploaded_time_logs.deleted_at IS NULL").
+				Where("sprint_member_id = ?", uploadedTimeLog.SprintMemberID).
+				Delete(&retroModels.UploadedTimeLog{}).Error
+			// The replaced time logs might be of the settled days, so all the time logs are synced again
+			if err == nil {
+				err = tx.Model(&retroModels.SprintMember{}).
+					Where("id = ?", uploadedTimeLog.SprintMemberID).
+					UpdateColumn("time_logs_synced_at", nil).Error
+			}
+			if err != nil {
+				tx.Rollback()
+				utils.LogToSentry(err)
+				return nil, http.StatusInternalServerError, errors.New("failed to import time logs")
+			}
+		}
+
+		if err = tx.Create(&uploadedTimeLog).Error; err != nil {
+			tx.Rollback()
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to import time logs")
+		}
+	}
+	if err = tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to import time logs")
+	}
+	report.ImportedRows = len(uploadedTimeLogs)
+
+	service.QueueSprint(sprint.ID, sprint.Status == retroModels.ActiveSprint, false, retroModels.ManualSyncTrigger)
+
+	return report, http.StatusOK, nil
+}
+
+// getUnknownTaskKeys returns the task keys which are neither present in the retrospective nor in the task trackers
+func (service SprintService) getUnknownTaskKeys(sprint retroModels.Sprint, taskKeys []string) ([]string, error) {
+	if len(taskKeys) == 0 {
+		return nil, nil
+	}
+	db := service.DB
+
+	taskProviderConfig, err := tasktracker.DecryptTaskProviders(sprint.Retrospective.TaskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+	connections, err := tasktracker.GetConnections(taskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+	// The keys of the tasks of the removed task providers are no longer known, the time tracker tasks have no provider
+	providerKeys := []string{""}
+	for _, connection := range connections {
+		providerKeys = append(providerKeys, connection.ProviderKey)
+	}
+
+	var knownTaskKeys []string
+	err = db.Model(&retroModels.TaskKeyMap{}).
+		Where("task_key_maps.deleted_at IS NULL").
+		Joins("JOIN tasks ON task_key_maps.task_id = tasks.id AND tasks.deleted_at IS NULL").
+		Where("tasks.retrospective_id = ?", sprint.RetrospectiveID).
+		Where("task_key_maps.provider_key IN (?)", providerKeys).
+		Where("task_key_maps.key IN (?)", taskKeys).
+		Pluck("DISTINCT task_key_maps.key", &knownTaskKeys).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+
+	missingTaskKeys := mapset.NewSetFromSlice(utils.StringSliceToInterfaceSlice(taskKeys)).
+		Difference(mapset.NewSetFromSlice(utils.StringSliceToInterfaceSlice(knownTaskKeys)))
+	if missingTaskKeys.Cardinality() == 0 {
+		return nil, nil
+	}
+
+	tickets, err := tasktracker.GetTaskList(taskProviderConfig, utils.InterfaceSliceToStringSlice(missingTaskKeys.ToSlice()))
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+	for _, ticket := range tickets {
+		missingTaskKeys.Remove(ticket.Key)
+	}
+	return sortedStringSet(missingTaskKeys), nil
+}
+
+// getUploadedTimeLogs returns the uploaded time logs of the sprint member aggregated per task per day
+func (service SprintService) getUploadedTimeLogs(
+	sprintMemberID uint,
+	project string) ([]timeTrackerSerializers.TimeLog, error) {
+	db := service.DB
+
+	var timeLogs []timeTrackerSerializers.TimeLog
+	err := db.Model(&retroModels.UploadedTimeLog{}).
+		Where("uploaded_time_logs.deleted_at IS NULL").
+		Where("sprint_member_id = ?", sprintMemberID).
+		Group("task_key, date").
+		Order("task_key, date").
+		Select("task_key, date, SUM(minutes) AS minutes").
+		Scan(&timeLogs).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+
+	for index := range timeLogs {
+		timeLogs[index].Project = project
+		timeLogs[index].Logger = uploadedTimeLogLogger
+	}
+	return timeLogs, nil
+}
+
+// getStoredTimeLogs returns the daily time logs of the sprint member stored for the dates from the start date till
+// before the end date, as the time logs of the tasks
+func (service SprintService) getStoredTimeLogs(
+	sprintMemberID uint,
+	project string,
+	startDate time.Time,
+	endDate time.Time) ([]timeTrackerSerializers.TimeLog, error) {
+	db := service.DB
+
+	var timeLogs []timeTrackerSerializers.TimeLog
+	err := db.Model(&retroModels.DailyTimeLog{}).
+		Where("daily_time_logs.deleted_at IS NULL").
+		Joins("JOIN sprint_member_tasks ON daily_time_logs.sprint_member_task_id = sprint_member_tasks.id").
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Where("sprint_member_tasks.sprint_member_id = ?", sprintMemberID).
+		Scopes(retroModels.SMTJoinST, retroModels.STJoinTask).
+		Where("daily_time_logs.date >= ? AND daily_time_logs.date < ?",
+			startDate.Format(constants.CustomDateFormat), endDate.Format(constants.CustomDateFormat)).
+		Select("tasks.key AS task_key, daily_time_logs.date, daily_time_logs.minutes").
+		Scan(&timeLogs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range timeLogs {
+		timeLogs[index].Project = project
+	}
+	return timeLogs, nil
+}
+
+// mergeTimeLogs merges the time logs of the same task, per day if byDate is set. The time spent on a task is stored
+// as a whole for the sprint member, along with the time spent on each day.
+func mergeTimeLogs(timeLogs []timeTrackerSerializers.TimeLog, byDate bool) []timeTrackerSerializers.TimeLog {
+	var mergedTimeLogs []timeTrackerSerializers.TimeLog
+	taskIndex := make(map[string]int)
+	for _, timeLog := range timeLogs {
+		if !byDate {
+			timeLog.Date = time.Time{}
+		}
+		key := timeLog.TaskKey + "|" + timeLog.Date.Format(constants.CustomDateFormat)
+		index, exists := taskIndex[key]
+		if !exists {
+			taskIndex[key] = len(mergedTimeLogs)
+			mergedTimeLogs = append(mergedTimeLogs, timeLog)
+			continue
+		}
+		mergedTimeLogs[index].Minutes += timeLog.Minutes
+		if !strings.Contains(mergedTimeLogs[index].Logger, timeLog.Logger) {
+			mergedTimeLogs[index].Logger += ", " + timeLog.Logger
+		}
+	}
+	return mergedTimeLogs
+}
+
+func newTimeLogRowError(timeLog timeTrackerSerializers.UploadedTimeLog, message string) timeTrackerSerializers.TimeLogRowError {
+	return timeTrackerSerializers.TimeLogRowError{
+		Row:     timeLog.Row,
+		TaskKey: timeLog.TaskKey,
+		Logger:  timeLog.Logger,
+		Error:   message,
+	}
+}
+
+func sortedStringSet(set mapset.Set) []string {
+	values := utils.InterfaceSliceToStringSlice(set.ToSlice())
+	sort.Strings(values)
+	return values
+}
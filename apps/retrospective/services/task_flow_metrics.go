@@ -0,0 +1,275 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	taskTrackerSerializers "github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// flowTask is a task of a sprint whose flow metrics are computed
+type flowTask struct {
+	SprintTaskID     uint
+	TaskID           uint
+	Key              string
+	Summary          string
+	Status           string
+	ProviderKey      string
+	TrackerCreatedAt *time.Time
+	CreatedAt        time.Time
+}
+
+// getFlowTasks returns the tasks of the sprint, or the given task of the sprint
+func getFlowTasks(db *gorm.DB, sprintID string, sprintTaskID *string) ([]flowTask, error) {
+	var tasks []flowTask
+	query := db.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Where("sprint_tasks.sprint_id = ?", sprintID).
+		Scopes(retroModels.STJoinTask)
+	if sprintTaskID != nil {
+		query = query.Where("sprint_tasks.id = ?", *sprintTaskID)
+	}
+	err := query.
+		Order("tasks.tracker_unique_id").
+		Select(`
+            sprint_tasks.id AS sprint_task_id,
+            tasks.id AS task_id,
+            tasks.key,
+            tasks.summary,
+            tasks.status,
+            tasks.provider_key,
+            tasks.tracker_created_at,
+            tasks.created_at`).
+		Scan(&tasks).Error
+	return tasks, err
+}
+
+// getTaskStatusChanges returns the status changes of the tasks in the chronological order, mapped by their task ids
+func getTaskStatusChanges(db *gorm.DB, tasks []flowTask) (map[uint][]retroModels.TaskStatusChange, error) {
+	taskIDs := []uint{0}
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.TaskID)
+	}
+	var changes []retroModels.TaskStatusChange
+	err := db.Model(&retroModels.TaskStatusChange{}).
+		Where("task_status_changes.deleted_at IS NULL").
+		Where("task_id IN (?)", taskIDs).
+		Order("changed_at, id").
+		Find(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	taskChanges := make(map[uint][]retroModels.TaskStatusChange)
+	for _, change := range changes {
+		taskChanges[change.TaskID] = append(taskChanges[change.TaskID], change)
+	}
+	return taskChanges, nil
+}
+
+// getDoneStatuses returns the done statuses of the task providers of the retrospective, mapped by the provider keys
+func getDoneStatuses(db *gorm.DB, retroID string) (map[string][]string, error) {
+	var retro retroModels.Retrospective
+	err := db.Model(&retroModels.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		First(&retro).Error
+	if err != nil {
+		return nil, err
+	}
+	statusMap, err := tasktracker.GetStatusMapping(retro.TaskProviderConfig)
+	if err != nil {
+		return nil, err
+	}
+	doneStatuses := make(map[string][]string)
+	for providerKey, statuses := range statusMap {
+		doneStatuses[providerKey] = statuses[tasktracker.DoneStatus]
+	}
+	return doneStatuses, nil
+}
+
+// getTaskFlowMetrics computes the flow metrics of the task from its status changes, the lead time is counted from
+// the creation of the task in the task tracker, or from its creation in the retrospective if that is not known
+func getTaskFlowMetrics(
+	task flowTask,
+	changes []retroModels.TaskStatusChange,
+	doneStatuses []string,
+	now time.Time) (retroSerializers.TaskFlowMetrics, retroModels.FlowMetrics) {
+	createdAt := task.CreatedAt
+	if task.TrackerCreatedAt != nil {
+		createdAt = *task.TrackerCreatedAt
+	}
+	var statusChanges []taskTrackerSerializers.StatusChange
+	for _, change := range changes {
+		statusChanges = append(statusChanges, taskTrackerSerializers.StatusChange{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			ChangedAt:  change.ChangedAt,
+		})
+	}
+	metrics := retroModels.GetFlowMetrics(createdAt, statusChanges, doneStatuses, now)
+
+	taskMetrics := retroSerializers.TaskFlowMetrics{
+		SprintTaskID:   task.SprintTaskID,
+		Key:            task.Key,
+		Summary:        task.Summary,
+		Status:         task.Status,
+		CompletedAt:    metrics.CompletedAt,
+		LeadTimeHours:  durationHours(metrics.LeadTime),
+		CycleTimeHours: durationHours(metrics.CycleTime),
+		TimeInStatus:   []retroSerializers.StatusTime{},
+	}
+	for _, statusDuration := range metrics.TimeInStatus {
+		taskMetrics.TimeInStatus = append(taskMetrics.TimeInStatus, retroSerializers.StatusTime{
+			Status: statusDuration.Status,
+			Hours:  statusDuration.Duration.Hours(),
+		})
+	}
+	return taskMetrics, metrics
+}
+
+// GetStatusHistory returns the status changes of the task of the sprint with its flow metrics
+func (service SprintTaskService) GetStatusHistory(
+	sprintTaskID string,
+	retroID string,
+	sprintID string) (*retroSerializers.TaskStatusHistory, int, error) {
+	db := service.DB
+
+	tasks, err := getFlowTasks(db, sprintID, &sprintTaskID)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get status history")
+	}
+	if len(tasks) == 0 {
+		return nil, http.StatusNotFound, errors.New("issue not found")
+	}
+
+	taskChanges, err := getTaskStatusChanges(db, tasks)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get status history")
+	}
+	doneStatuses, err := getDoneStatuses(db, retroID)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get status history")
+	}
+
+	task := tasks[0]
+	taskMetrics, _ := getTaskFlowMetrics(task, taskChanges[task.TaskID], doneStatuses[task.ProviderKey], time.Now())
+	history := &retroSerializers.TaskStatusHistory{
+		TaskFlowMetrics: taskMetrics,
+		StatusChanges:   []retroSerializers.TaskStatusChange{},
+	}
+	for _, change := range taskChanges[task.TaskID] {
+		history.StatusChanges = append(history.StatusChanges, retroSerializers.TaskStatusChange{
+			FromStatus:      change.FromStatus,
+			ToStatus:        change.ToStatus,
+			ChangedAt:       change.ChangedAt,
+			IsFromChangelog: change.IsFromChangelog,
+		})
+	}
+	return history, http.StatusOK, nil
+}
+
+// GetFlowMetrics returns the flow metrics of the tasks of the sprint, with the average and the median lead and cycle
+// times of the completed tasks and the total time spent by the tasks in each of the statuses
+func (service SprintService) GetFlowMetrics(
+	sprintID string,
+	retroID string) (*retroSerializers.SprintFlowMetrics, int, error) {
+	db := service.DB
+	var sprint retroModels.Sprint
+
+	err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Scopes(retroModels.NotDeletedSprint).
+		Where("id = ?", sprintID).
+		Where("retrospective_id = ?", retroID).
+		Find(&sprint).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get flow metrics")
+	}
+
+	tasks, err := getFlowTasks(db, sprintID, nil)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get flow metrics")
+	}
+	taskChanges, err := getTaskStatusChanges(db, tasks)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get flow metrics")
+	}
+	doneStatuses, err := getDoneStatuses(db, retroID)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get flow metrics")
+	}
+
+	flowMetrics := &retroSerializers.SprintFlowMetrics{
+		Tasks:        []retroSerializers.TaskFlowMetrics{},
+		TimeInStatus: []retroSerializers.StatusTime{},
+	}
+	now := time.Now()
+	var leadTimes, cycleTimes []float64
+	statusHours := make(map[string]float64)
+	for _, task := range tasks {
+		taskMetrics, metrics := getTaskFlowMetrics(task, taskChanges[task.TaskID], doneStatuses[task.ProviderKey], now)
+		flowMetrics.Tasks = append(flowMetrics.Tasks, taskMetrics)
+
+		if metrics.CompletedAt != nil {
+			flowMetrics.CompletedTaskCount++
+		}
+		if metrics.LeadTime != nil {
+			leadTimes = append(leadTimes, metrics.LeadTime.Hours())
+		}
+		if metrics.CycleTime != nil {
+			cycleTimes = append(cycleTimes, metrics.CycleTime.Hours())
+		}
+		for _, statusTime := range taskMetrics.TimeInStatus {
+			if _, exists := statusHours[statusTime.Status]; !exists {
+				flowMetrics.TimeInStatus = append(flowMetrics.TimeInStatus, retroSerializers.StatusTime{
+					Status: statusTime.Status,
+				})
+			}
+			statusHours[statusTime.Status] += statusTime.Hours
+		}
+	}
+	for index := range flowMetrics.TimeInStatus {
+		flowMetrics.TimeInStatus[index].Hours = statusHours[flowMetrics.TimeInStatus[index].Status]
+	}
+	sort.SliceStable(flowMetrics.TimeInStatus, func(i, j int) bool {
+		return flowMetrics.TimeInStatus[i].Hours > flowMetrics.TimeInStatus[j].Hours
+	})
+
+	if len(leadTimes) > 0 {
+		averageLeadTime, medianLeadTime := average(leadTimes), utils.Percentile(leadTimes, 50)
+		flowMetrics.AverageLeadTimeHours = &averageLeadTime
+		flowMetrics.MedianLeadTimeHours = &medianLeadTime
+	}
+	if len(cycleTimes) > 0 {
+		averageCycleTime, medianCycleTime := average(cycleTimes), utils.Percentile(cycleTimes, 50)
+		flowMetrics.AverageCycleTimeHours = &averageCycleTime
+		flowMetrics.MedianCycleTimeHours = &medianCycleTime
+	}
+	return flowMetrics, http.StatusOK, nil
+}
+
+func durationHours(duration *time.Duration) *float64 {
+	if duration == nil {
+		return nil
+	}
+	hours := duration.Hours()
+	return &hours
+}
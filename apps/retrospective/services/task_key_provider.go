@@ -0,0 +1,70 @@
+package services
+
+import (
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+)
+
+// providerTaskKey is a task key along with the key of its task provider, the keys of the different task providers
+// can collide, eg. the GitHub issue numbers and the Pivotal story IDs
+type providerTaskKey struct {
+	Key         string
+	ProviderKey string
+}
+
+// taskKeyProviders resolves the task keys of the time logs, which carry no task provider, to the task providers of
+// the sprint tasks. A key of the tasks of more than one task provider resolves to the first of them in the order of
+// the configs, the same order in which the task keys are looked up in the task providers.
+type taskKeyProviders struct {
+	providerOrder map[string]int
+	providerKeys  map[string]string
+}
+
+func newTaskKeyProviders(taskProviderConfig []byte) (*taskKeyProviders, error) {
+	connections, err := tasktracker.GetConnections(taskProviderConfig)
+	if err != nil {
+		return nil, err
+	}
+	keyProviders := &taskKeyProviders{
+		providerOrder: make(map[string]int),
+		providerKeys:  make(map[string]string),
+	}
+	for index, connection := range connections {
+		keyProviders.providerOrder[connection.ProviderKey] = index
+	}
+	return keyProviders, nil
+}
+
+// rank returns the position of the task provider among the configs, the time tracker tasks, which have no task
+// provider, and the tasks of the removed task providers rank last
+func (keyProviders *taskKeyProviders) rank(providerKey string) int {
+	if order, ok := keyProviders.providerOrder[providerKey]; ok {
+		return order
+	}
+	return len(keyProviders.providerOrder)
+}
+
+// add records the task provider of the task key
+func (keyProviders *taskKeyProviders) add(taskKey string, providerKey string) {
+	existingProviderKey, exists := keyProviders.providerKeys[taskKey]
+	if !exists || keyProviders.rank(providerKey) < keyProviders.rank(existingProviderKey) {
+		keyProviders.providerKeys[taskKey] = providerKey
+	}
+}
+
+// get returns the task key along with its task provider
+func (keyProviders *taskKeyProviders) get(taskKey string) providerTaskKey {
+	return providerTaskKey{Key: taskKey, ProviderKey: keyProviders.providerKeys[taskKey]}
+}
+
+// getMissingKeys returns the distinct task keys whose task providers are not known yet
+func (keyProviders *taskKeyProviders) getMissingKeys(taskKeys []string) []string {
+	var missingKeys []string
+	seenKeys := make(map[string]bool)
+	for _, taskKey := range taskKeys {
+		if _, exists := keyProviders.providerKeys[taskKey]; !exists && !seenKeys[taskKey] {
+			missingKeys = append(missingKeys, taskKey)
+		}
+		seenKeys[taskKey] = true
+	}
+	return missingKeys
+}
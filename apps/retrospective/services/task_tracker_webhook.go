@@ -0,0 +1,163 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gocraft/work"
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	"github.com/iReflect/reflect-app/libs/utils"
+	"github.com/iReflect/reflect-app/workers"
+)
+
+// TaskTrackerWebhookService ...
+type TaskTrackerWebhookService struct {
+	DB *gorm.DB
+}
+
+// Task tracker webhook sources
+const (
+	WebhookSourceJIRA    = "jira"
+	WebhookSourcePivotal = "pivotal"
+)
+
+// webhookDeliveryExpiry is the duration for which a webhook delivery is remembered for the deduplication
+const webhookDeliveryExpiry = 24 * time.Hour
+
+// WebhookEvent is a task tracker change event received through a webhook
+type WebhookEvent struct {
+	Source string
+	// ProviderKey is the key of the task provider config the event belongs to, defaults to the first config of the
+	// type of the source
+	ProviderKey string
+	// DeliveryID uniquely identifies the delivery of the event, used for the deduplication
+	DeliveryID string
+	Signature  string
+	Token      string
+	Body       []byte
+}
+
+// HandleEvent authenticates the webhook event of the retrospective and queues the
+// update of the tasks changed by the event. It returns the number of tasks queued.
+func (service TaskTrackerWebhookService) HandleEvent(retroID string, event WebhookEvent) (int, int, error) {
+	db := service.DB
+	var retro retroModels.Retrospective
+
+	err := db.Model(&retroModels.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		Find(&retro).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return 0, http.StatusNotFound, errors.New("retrospective not found")
+		}
+		utils.LogToSentry(err)
+		return 0, http.StatusInternalServerError, errors.New("failed to get retrospective")
+	}
+
+	if !tasktracker.IsValidWebhookRequest(retro.WebhookSecret, event.Signature, event.Token, event.Body) {
+		return 0, http.StatusUnauthorized, errors.New("invalid webhook signature")
+	}
+
+	var taskKeys []string
+	var deliveryID string
+	switch event.Source {
+	case WebhookSourceJIRA:
+		deliveryID, taskKeys, err = tasktracker.ParseJIRAWebhookPayload(event.Body)
+	case WebhookSourcePivotal:
+		deliveryID, taskKeys, err = tasktracker.ParsePivotalWebhookPayload(event.Body)
+	default:
+		return 0, http.StatusNotFound, errors.New("unsupported webhook source")
+	}
+	if err != nil {
+		return 0, http.StatusBadRequest, err
+	}
+	if event.DeliveryID != "" {
+		deliveryID = event.DeliveryID
+	}
+	if deliveryID == "" {
+		checksum := sha256.Sum256(event.Body)
+		deliveryID = hex.EncodeToString(checksum[:])
+	}
+
+	providerKey := event.ProviderKey
+	if providerKey == "" {
+		if providerKey, err = getWebhookProviderKey(retro, event.Source); err != nil {
+			return 0, http.StatusBadRequest, err
+		}
+	}
+
+	// The task trackers retry the deliveries which are not acknowledged in time, ignore the ones already handled
+	deliveryKey := fmt.Sprintf("webhook:%v:%s:%s", retro.ID, event.Source, deliveryID)
+	isNew, err := workers.MarkOnce(deliveryKey, webhookDeliveryExpiry)
+	if err != nil {
+		utils.LogToSentry(err)
+		return 0, http.StatusInternalServerError, errors.New("failed to process the webhook")
+	}
+	if !isNew {
+		return 0, http.StatusOK, nil
+	}
+
+	for _, taskKey := range taskKeys {
+		_, err = workers.Enqueuer.EnqueueUnique("sync_task_tracker_task", work.Q{
+			"retroID":     fmt.Sprint(retro.ID),
+			"providerKey": providerKey,
+			"taskKey":     taskKey,
+		})
+		if err != nil {
+			utils.LogToSentry(err)
+			// The delivery is handled again on the retry of the task tracker, the tasks already queued are
+			// ignored by the queue then
+			if err = workers.Unmark(deliveryKey); err != nil {
+				utils.LogToSentry(err)
+			}
+			return 0, http.StatusInternalServerError, errors.New("failed to process the webhook")
+		}
+	}
+	return len(taskKeys), http.StatusOK, nil
+}
+
+// RotateSecret generates a new webhook secret for the retrospective, the old secret stops working immediately
+func (service TaskTrackerWebhookService) RotateSecret(retroID string) (*retroSerializers.WebhookSecret, int, error) {
+	db := service.DB
+	secret := utils.RandToken()
+
+	result := db.Model(&retroModels.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		UpdateColumn("webhook_secret", secret)
+	if result.Error != nil {
+		utils.LogToSentry(result.Error)
+		return nil, http.StatusInternalServerError, errors.New("failed to update webhook secret")
+	}
+	if result.RowsAffected == 0 {
+		return nil, http.StatusNotFound, errors.New("retrospective not found")
+	}
+	return &retroSerializers.WebhookSecret{Secret: secret}, http.StatusOK, nil
+}
+
+// getWebhookProviderKey returns the key of the first task provider config of the retrospective of the webhook source
+func getWebhookProviderKey(retro retroModels.Retrospective, source string) (string, error) {
+	taskProviderConfig, err := tasktracker.DecryptTaskProviders(retro.TaskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return "", errors.New("invalid task provider config")
+	}
+	connections, err := tasktracker.GetConnections(taskProviderConfig)
+	if err != nil {
+		return "", errors.New("invalid task provider config")
+	}
+	providerKey := connections.GetKeyOfType(source)
+	if providerKey == "" {
+		return "", fmt.Errorf("no %s task provider is configured", source)
+	}
+	return providerKey, nil
+}
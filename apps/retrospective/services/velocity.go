@@ -0,0 +1,271 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+const (
+	// velocityRollingWindow is the number of the sprints averaged for the rolling velocity
+	velocityRollingWindow  = 3
+	defaultVelocitySprints = 10
+	maxVelocitySprints     = 50
+	defaultForecastSprints = 3
+	maxForecastSprints     = 20
+	// forecastTrials is the number of the Monte Carlo simulations run for a forecast
+	forecastTrials = 1000
+	// maxBacklogSprints is the number of the sprints after which a backlog is taken as not completed
+	maxBacklogSprints = 100
+)
+
+// GetVelocity returns the velocity of the last completed sprints of the retrospective, and forecasts the story
+// points completed in the next sprints, and optionally the sprints needed to complete the given backlog, by
+// simulating the next sprints with the completed points of the past sprints
+func (service SprintService) GetVelocity(
+	retroID string,
+	sprintsString string,
+	forecastSprintsString string,
+	backlogString string) (*retroSerializers.RetrospectiveVelocitySerializer, int, error) {
+	db := service.DB
+
+	intRetroID, err := strconv.Atoi(retroID)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("invalid retrospective id")
+	}
+	sprintCount, err := parseCount(sprintsString, defaultVelocitySprints, maxVelocitySprints)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("invalid number of sprints")
+	}
+	forecastSprints, err := parseCount(forecastSprintsString, defaultForecastSprints, maxForecastSprints)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("invalid number of forecast sprints")
+	}
+	var backlog float64
+	if backlogString != "" {
+		backlog, err = strconv.ParseFloat(backlogString, 64)
+		if err != nil || backlog <= 0 {
+			return nil, http.StatusBadRequest, errors.New("backlog should be a positive number of story points")
+		}
+	}
+
+	var sprints []retroModels.Sprint
+	err = db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("retrospective_id = ?", retroID).
+		Where("status = ?", retroModels.CompletedSprint).
+		Order("end_date DESC, id DESC").
+		Limit(sprintCount).
+		Preload("Retrospective").
+		Find(&sprints).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get velocity")
+	}
+	sprintDetails, err := service.getVelocitySprintDetails(sprints)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get velocity")
+	}
+	sprintsBurnData, err := service.getSprintsBurnData(sprints)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get velocity")
+	}
+
+	velocity := &retroSerializers.RetrospectiveVelocitySerializer{
+		Sprints:       []retroSerializers.SprintVelocity{},
+		RollingWindow: velocityRollingWindow,
+		Forecast:      []retroSerializers.VelocityForecast{},
+	}
+	var throughputs []float64
+	// The sprints are fetched latest first, and are reported in the chronological order
+	for index := len(sprints) - 1; index >= 0; index-- {
+		sprint := sprints[index]
+		burnData, ok := sprintsBurnData[sprint.ID]
+		if !ok {
+			return nil, http.StatusBadRequest, errors.New("sprint dates are not set")
+		}
+		details := sprintDetails[sprint.ID]
+
+		sprintVelocity := retroSerializers.SprintVelocity{
+			SprintID:    sprint.ID,
+			Title:       sprint.Title,
+			StartDate:   sprint.StartDate,
+			EndDate:     sprint.EndDate,
+			TotalOutput: details.totalOutput,
+		}
+		// The tasks synced till the start date make the initial scope, which is not the commitment of the sprints
+		// first synced after their start
+		if details.firstSyncedAt != nil &&
+			utils.InServerTimeZone(*details.firstSyncedAt).Format(constants.CustomDateFormat) <=
+				utils.InServerTimeZone(*sprint.StartDate).Format(constants.CustomDateFormat) {
+			committedPoints := burnData.initialScope
+			sprintVelocity.CommittedPoints = &committedPoints
+		}
+		for _, member := range details.members {
+			sprintVelocity.TargetSP += utils.CalculateExpectedSP(burnData.calendar.WorkingDays,
+				burnData.calendar.WorkWeek.DaysPerWeek(), member.Vacations, member.ExpectationPercent,
+				member.AllocationPercent, sprint.Retrospective.StoryPointPerWeek)
+		}
+		if len(burnData.days) > 0 {
+			sprintVelocity.CompletedPoints = burnData.days[len(burnData.days)-1].completedPoints
+		}
+		throughputs = append(throughputs, sprintVelocity.CompletedPoints)
+		sprintVelocity.RollingVelocity = average(throughputs[maxInt(len(throughputs)-velocityRollingWindow, 0):])
+
+		velocity.Sprints = append(velocity.Sprints, sprintVelocity)
+	}
+	velocity.AverageVelocity = average(throughputs)
+
+	if len(throughputs) == 0 {
+		return velocity, http.StatusOK, nil
+	}
+
+	// The simulations are seeded with the retrospective, so that the forecast is the same across the requests
+	random := rand.New(rand.NewSource(int64(intRetroID)))
+	outcomes := utils.SimulateThroughput(throughputs, forecastSprints, forecastTrials, random)
+	for sprint, sprintOutcomes := range outcomes {
+		velocity.Forecast = append(velocity.Forecast, retroSerializers.VelocityForecast{
+			Sprint:           sprint + 1,
+			ConfidentPoints:  utils.Percentile(sprintOutcomes, 15),
+			LikelyPoints:     utils.Percentile(sprintOutcomes, 50),
+			OptimisticPoints: utils.Percentile(sprintOutcomes, 85),
+		})
+	}
+
+	if backlog > 0 {
+		sprintsToComplete := utils.SimulateSprintsToComplete(
+			throughputs, backlog, forecastTrials, maxBacklogSprints, random)
+		velocity.BacklogForecast = &retroSerializers.BacklogForecast{
+			Backlog:          backlog,
+			LikelySprints:    finiteOrNil(utils.Percentile(sprintsToComplete, 50)),
+			ConfidentSprints: finiteOrNil(utils.Percentile(sprintsToComplete, 85)),
+		}
+	}
+	return velocity, http.StatusOK, nil
+}
+
+// velocitySprintDetails are the details of a sprint needed for its velocity, besides its burn data
+type velocitySprintDetails struct {
+	members     []retroModels.SprintMember
+	totalOutput float64
+	// firstSyncedAt is when the first task of the sprint was synced, nil if none of its tasks are synced yet
+	firstSyncedAt *time.Time
+}
+
+// getVelocitySprintDetails fetches the members, the total output and the first sync of the sprints, mapped by the
+// sprint ids, with a query each for all the sprints
+func (service SprintService) getVelocitySprintDetails(
+	sprints []retroModels.Sprint) (map[uint]*velocitySprintDetails, error) {
+	db := service.DB
+
+	sprintDetails := make(map[uint]*velocitySprintDetails)
+	var sprintIDs []uint
+	for _, sprint := range sprints {
+		sprintDetails[sprint.ID] = &velocitySprintDetails{}
+		sprintIDs = append(sprintIDs, sprint.ID)
+	}
+	if len(sprintIDs) == 0 {
+		return sprintDetails, nil
+	}
+
+	var sprintMembers []retroModels.SprintMember
+	err := db.Model(&retroModels.SprintMember{}).
+		Where("sprint_members.deleted_at IS NULL").
+		Where("sprint_members.sprint_id IN (?)", sprintIDs).
+		Find(&sprintMembers).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, sprintMember := range sprintMembers {
+		sprintDetails[sprintMember.SprintID].members = append(sprintDetails[sprintMember.SprintID].members,
+			sprintMember)
+	}
+
+	// The total output is the story points earned by all the members in the sprint
+	var totalOutputs []struct {
+		SprintID    uint
+		TotalOutput float64
+	}
+	err = db.Model(&retroModels.SprintMemberTask{}).
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Scopes(retroModels.SMTJoinSM).
+		Where("sprint_members.sprint_id IN (?)", sprintIDs).
+		Group("sprint_members.sprint_id").
+		Select("sprint_members.sprint_id, COALESCE(SUM(sprint_member_tasks.points_earned), 0) AS total_output").
+		Scan(&totalOutputs).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, totalOutput := range totalOutputs {
+		sprintDetails[totalOutput.SprintID].totalOutput = totalOutput.TotalOutput
+	}
+
+	// The tasks removed from the sprints since are included, since they were synced as well
+	var firstSyncs []struct {
+		SprintID      uint
+		FirstSyncedAt time.Time
+	}
+	err = db.Unscoped().Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.sprint_id IN (?)", sprintIDs).
+		Group("sprint_tasks.sprint_id").
+		Select("sprint_tasks.sprint_id, MIN(sprint_tasks.created_at) AS first_synced_at").
+		Scan(&firstSyncs).Error
+	if err != nil {
+		return nil, err
+	}
+	for index := range firstSyncs {
+		sprintDetails[firstSyncs[index].SprintID].firstSyncedAt = &firstSyncs[index].FirstSyncedAt
+	}
+	return sprintDetails, nil
+}
+
+// parseCount parses a positive count, the default count is returned for an empty string and the count is capped at
+// the max count
+func parseCount(countString string, defaultCount int, maxCount int) (int, error) {
+	if countString == "" {
+		return defaultCount, nil
+	}
+	count, err := strconv.Atoi(countString)
+	if err != nil || count <= 0 {
+		return 0, errors.New("invalid count")
+	}
+	if count > maxCount {
+		count = maxCount
+	}
+	return count, nil
+}
+
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+func maxInt(first int, second int) int {
+	if first > second {
+		return first
+	}
+	return second
+}
+
+func finiteOrNil(value float64) *float64 {
+	if math.IsInf(value, 0) {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,233 @@
+package tasktracker
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// Codes of the config diagnostics
+const (
+	InvalidConfigDiagnostic        = "invalid_config"
+	ConnectionFailedDiagnostic     = "connection_failed"
+	AuthFailedDiagnostic           = "auth_failed"
+	UnknownProjectDiagnostic       = "unknown_project"
+	UnknownBoardDiagnostic         = "unknown_board"
+	InvalidJQLDiagnostic           = "invalid_jql"
+	MissingEstimateFieldDiagnostic = "missing_estimate_field"
+	MissingEpicFieldDiagnostic     = "missing_epic_field"
+)
+
+// ConfigError is the error of the validation of the config of a task provider with the problems found in the config
+type ConfigError struct {
+	Diagnostics []serializers.ConfigDiagnostic
+}
+
+// Error ...
+func (err *ConfigError) Error() string {
+	var messages []string
+	for _, diagnostic := range err.Diagnostics {
+		messages = append(messages, diagnostic.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Add adds a diagnostic to the config error
+func (err *ConfigError) Add(code string, field string, message string) {
+	err.Diagnostics = append(err.Diagnostics, serializers.ConfigDiagnostic{Code: code, Field: field, Message: message})
+}
+
+// OrNil returns the config error if it has any diagnostics, it is returned by the config validations so that a
+// config error without any diagnostics is not returned as a non nil error
+func (err *ConfigError) OrNil() error {
+	if len(err.Diagnostics) == 0 {
+		return nil
+	}
+	return err
+}
+
+// AddResponseDiagnostic adds the diagnostic of a failed request to the task tracker. The status code is 0 if there
+// was no response, and the not found responses are reported with the given code and field. The messages are fixed,
+// since the diagnostics are returned to the users, and the errors of the task tracker are logged instead.
+func (err *ConfigError) AddResponseDiagnostic(statusCode int, requestErr error, notFoundCode string, field string) {
+	switch statusCode {
+	case 0:
+		utils.LogToSentry(requestErr)
+		err.Add(ConnectionFailedDiagnostic, "", "failed to connect to the task tracker")
+	case http.StatusUnauthorized, http.StatusForbidden:
+		err.Add(AuthFailedDiagnostic, "credentials", "the credentials are invalid or lack the access")
+	case http.StatusNotFound:
+		err.Add(notFoundCode, field, fmt.Sprintf("%s was not found", field))
+	default:
+		utils.LogToSentry(requestErr)
+		err.Add(ConnectionFailedDiagnostic, "", "the task tracker returned an error")
+	}
+}
+
+// GetConfigDiagnostics returns the diagnostics of the error of a config validation, an error other than the config
+// error is reported as an invalid config
+func GetConfigDiagnostics(err error) []serializers.ConfigDiagnostic {
+	if err == nil {
+		return []serializers.ConfigDiagnostic{}
+	}
+	if configErr, ok := err.(*ConfigError); ok {
+		return configErr.Diagnostics
+	}
+	return []serializers.ConfigDiagnostic{{Code: InvalidConfigDiagnostic, Message: err.Error()}}
+}
+
+// TestConnection connects to the task provider with the given config and returns the problems found in the config
+func TestConnection(taskProviderConfig map[string]interface{}) ([]serializers.ConfigDiagnostic, error) {
+	providerType, ok := taskProviderConfig["type"].(string)
+	if !ok {
+		return nil, errors.New("task provider type is required")
+	}
+	taskProvider := GetTaskProvider(providerType)
+	if taskProvider == nil {
+		return nil, fmt.Errorf("unknown task provider %s", providerType)
+	}
+	data, ok := taskProviderConfig["data"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("task provider config is required")
+	}
+
+	if baseURL, _ := data["BaseURL"].(string); baseURL != "" {
+		if err := validatePublicURL(baseURL); err != nil {
+			return []serializers.ConfigDiagnostic{
+				{Code: InvalidConfigDiagnostic, Field: "BaseURL", Message: err.Error()},
+			}, nil
+		}
+	}
+	credentials, _ := data["credentials"].(map[string]interface{})
+	if err := ValidateCredentials(credentials); err != nil {
+		return []serializers.ConfigDiagnostic{
+			{Code: InvalidConfigDiagnostic, Field: "credentials", Message: err.Error()},
+		}, nil
+	}
+	connection := taskProvider.New(data)
+	if connection == nil {
+		return []serializers.ConfigDiagnostic{
+			{Code: InvalidConfigDiagnostic, Message: "invalid config for " + providerType},
+		}, nil
+	}
+	return GetConfigDiagnostics(connection.ValidateConfig()), nil
+}
+
+// validatePublicURL checks that the url is of a public host, so that the connection tests can not be used to send
+// requests to the internal network of the server
+func validatePublicURL(rawURL string) error {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Hostname() == "" || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return errors.New("base url should be an http or https url")
+	}
+	ips, err := net.LookupIP(parsedURL.Hostname())
+	if err != nil || len(ips) == 0 {
+		return errors.New("host of the base url could not be resolved")
+	}
+	for _, ip := range ips {
+		if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+			ip.IsUnspecified() {
+			return errors.New("base url should be of a public host")
+		}
+	}
+	return nil
+}
+
+// GetConfigSchema returns the JSON Schema of the config of the task provider, built from its config template
+func GetConfigSchema(name string) map[string]interface{} {
+	taskProvider := GetTaskProvider(name)
+	if taskProvider == nil {
+		return nil
+	}
+	template := taskProvider.ConfigTemplate()
+
+	properties := map[string]interface{}{}
+	required := []string{"credentials"}
+
+	var credentialSchemas []interface{}
+	authTypes, _ := template["SupportedAuthTypes"].([]string)
+	for _, authType := range authTypes {
+		credentialSchemas = append(credentialSchemas, getCredentialsSchema(authType))
+	}
+	properties["credentials"] = map[string]interface{}{
+		"title": "Credentials",
+		"type":  "object",
+		"oneOf": credentialSchemas,
+	}
+
+	fields, _ := template["Fields"].([]map[string]interface{})
+	for _, field := range fields {
+		fieldName, _ := field["FieldName"].(string)
+		fieldSchema := map[string]interface{}{
+			"title": field["FieldDisplayName"],
+			"type":  field["Type"],
+		}
+		if hint, ok := field["Hint"]; ok {
+			fieldSchema["description"] = hint
+		}
+		if format, ok := field["Format"]; ok {
+			fieldSchema["format"] = format
+		}
+		if pattern, ok := field["Pattern"]; ok {
+			fieldSchema["pattern"] = pattern
+		}
+		properties[fieldName] = fieldSchema
+		if isRequired, _ := field["Required"].(bool); isRequired {
+			required = append(required, fieldName)
+		}
+	}
+
+	// The type and the status mappings are common to all the task providers
+	for _, taskType := range TaskTypes {
+		properties[taskType] = map[string]interface{}{
+			"title":       taskType,
+			"type":        "string",
+			"description": "Comma separated types of the task tracker mapped to " + taskType,
+		}
+		required = append(required, taskType)
+	}
+	for _, status := range StatusTypes {
+		properties[status] = map[string]interface{}{
+			"title":       status,
+			"type":        "string",
+			"description": "Comma separated statuses of the task tracker mapped to " + status,
+		}
+	}
+
+	return map[string]interface{}{
+		"$schema":    "http://json-schema.org/draft-07/schema#",
+		"title":      template["DisplayTitle"],
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+// getCredentialsSchema returns the JSON Schema of the credentials of the auth type
+func getCredentialsSchema(authType string) map[string]interface{} {
+	properties := map[string]interface{}{
+		"type": map[string]interface{}{"const": authType},
+	}
+	required := []string{"type"}
+	switch authType {
+	case "basicAuth":
+		properties["username"] = map[string]interface{}{"title": "Username", "type": "string"}
+		properties["password"] = map[string]interface{}{"title": "Password", "type": "string", "writeOnly": true}
+		required = append(required, "username", "password")
+	case "apiToken":
+		properties["apiToken"] = map[string]interface{}{"title": "API Token", "type": "string", "writeOnly": true}
+		required = append(required, "apiToken")
+	}
+	return map[string]interface{}{
+		"title":      authType,
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
@@ -0,0 +1,36 @@
+package tasktracker
+
+import (
+	"testing"
+)
+
+func TestValidatePublicURL(t *testing.T) {
+	testCases := []struct {
+		url     string
+		isValid bool
+	}{
+		{"https://93.184.216.34/jira", true},
+		{"http://127.0.0.1:8080", false},
+		{"http://10.0.0.5", false},
+		{"http://192.168.1.10", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://[::1]", false},
+		{"http://0.0.0.0", false},
+		{"ftp://93.184.216.34", false},
+		{"not a url", false},
+	}
+	for _, testCase := range testCases {
+		if err := validatePublicURL(testCase.url); (err == nil) != testCase.isValid {
+			t.Errorf("validatePublicURL(%q) returned %v, want valid %t", testCase.url, err, testCase.isValid)
+		}
+	}
+
+	// The connection is not tested with a private base url
+	diagnostics, err := TestConnection(map[string]interface{}{
+		"type": "fake",
+		"data": map[string]interface{}{"BaseURL": "http://127.0.0.1"},
+	})
+	if err != nil || len(diagnostics) != 1 || diagnostics[0].Field != "BaseURL" {
+		t.Errorf("TestConnection() returned %+v, %v for a private base url", diagnostics, err)
+	}
+}
@@ -1,10 +1,15 @@
 package tasktracker
 
 import (
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 
 	"errors"
+	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/blaskovicz/go-cryptkeeper"
 	"github.com/iReflect/reflect-app/apps/tasktracker/serializers"
@@ -32,7 +37,8 @@ type Connection interface {
 	GetTask(ticketKey string) (*serializers.Task, error)
 	GetTaskUrl(ticketKey string) string
 	GetSprint(sprintID string) *serializers.Sprint
-	GetSprintTaskList(sprint serializers.Sprint) []serializers.Task
+	// GetSprintTaskList returns the tasks of the sprint, no tasks are returned if the sprint ID is not given
+	GetSprintTaskList(sprint serializers.Sprint) ([]serializers.Task, error)
 	ValidateConfig() error
 }
 
@@ -144,75 +150,354 @@ func ValidateCredentials(credentials map[string]interface{}) (err error) {
 	return nil
 }
 
-// GetTaskList ...
+// ProviderConnection is the connection of a configured task provider,
+// ProviderKey identifies the task provider config among the configs of a retrospective
+type ProviderConnection struct {
+	ProviderKey string
+	Type        string
+	Connection
+}
+
+// ProviderConnections ...
+type ProviderConnections []ProviderConnection
+
+// Get returns the connection for the given provider key,
+// falls back to the first connection for the tasks which predate the provider keys
+func (connections ProviderConnections) Get(providerKey string) Connection {
+	if len(connections) == 0 {
+		return nil
+	}
+	if connection := connections.get(providerKey); connection != nil {
+		return connection
+	}
+	return connections[0].Connection
+}
+
+// get returns the connection for the given provider key, nil if there is no such connection
+func (connections ProviderConnections) get(providerKey string) Connection {
+	for _, connection := range connections {
+		if connection.ProviderKey == providerKey {
+			return connection.Connection
+		}
+	}
+	return nil
+}
+
+// GetFirstKey returns the key of the first connection, the legacy sprint IDs belong to the first task provider
+func (connections ProviderConnections) GetFirstKey() string {
+	if len(connections) == 0 {
+		return ""
+	}
+	return connections[0].ProviderKey
+}
+
+// GetTaskUrl ...
+func (connections ProviderConnections) GetTaskUrl(providerKey string, ticketKey string) string {
+	connection := connections.Get(providerKey)
+	if connection == nil {
+		return ""
+	}
+	return connection.GetTaskUrl(ticketKey)
+}
+
+// GetTaskList fetches the given tasks from all the task providers, a task key is
+// looked up in the providers (in the order of configuration) till a provider returns it
 func GetTaskList(config []byte, taskKeys []string) (tasks []serializers.Task, err error) {
 	if len(taskKeys) == 0 {
 		return tasks, nil
 	}
 
-	connection := GetConnection(config)
-	if connection == nil {
+	connections, err := GetConnections(config)
+	if err != nil {
 		return nil, errors.New("task_list: invalid connection config")
 	}
 
-	tasks = append(tasks, connection.GetTaskList(taskKeys)...)
+	remainingTaskKeys := taskKeys
+	for _, connection := range connections {
+		if len(remainingTaskKeys) == 0 {
+			break
+		}
+		fetchedTaskKeys := make(map[string]bool)
+		for _, task := range connection.GetTaskList(remainingTaskKeys) {
+			task.ProviderKey = connection.ProviderKey
+			tasks = append(tasks, task)
+			fetchedTaskKeys[task.Key] = true
+		}
+
+		var pendingTaskKeys []string
+		for _, taskKey := range remainingTaskKeys {
+			if !fetchedTaskKeys[taskKey] {
+				pendingTaskKeys = append(pendingTaskKeys, taskKey)
+			}
+		}
+		remainingTaskKeys = pendingTaskKeys
+	}
 	return tasks, nil
 }
 
-// GetTaskDetails ...
+// GetTaskDetails returns the task from the first task provider which has it
 func GetTaskDetails(config []byte, taskKey string) (*serializers.Task, error) {
-
-	connection := GetConnection(config)
-	if connection == nil {
+	connections, err := GetConnections(config)
+	if err != nil {
 		return nil, errors.New("task_details: invalid connection config")
 	}
 
-	return connection.GetTask(taskKey)
+	for _, connection := range connections {
+		task, err := connection.GetTask(taskKey)
+		if err != nil {
+			return nil, err
+		}
+		if task != nil {
+			task.ProviderKey = connection.ProviderKey
+			return task, nil
+		}
+	}
+	return nil, nil
 }
 
-// GetSprintTaskList ...
-func GetSprintTaskList(config []byte, sprint serializers.Sprint) (tasks []serializers.Task, err error) {
-	connection := GetConnection(config)
-	if connection == nil {
-		return nil, errors.New("sprint_task_list: invalid connection config")
+// GetSprintTaskList returns the tasks of the sprint from all the task providers, it fails if the tasks of any of the
+// task providers could not be fetched
+func GetSprintTaskList(
+	config []byte,
+	sprint serializers.Sprint,
+	sprintIDs map[string]string) (tasks []serializers.Task, err error) {
+	tasks, providerErrors, err := GetUpdatedSprintTaskList(config, sprint, sprintIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = JoinProviderErrors(providerErrors); err != nil {
+		return nil, err
 	}
-
-	tasks = append(tasks, connection.GetSprintTaskList(sprint)...)
 	return tasks, nil
 }
 
-// GetConnection ...
-func GetConnection(config []byte) Connection {
-	var configList []interface{}
-	if err := json.Unmarshal(config, &configList); err != nil {
+// GetUpdatedSprintTaskList returns the tasks of the sprint from all the task providers, limited to the tasks updated
+// after the time given for the provider key. Each task provider is queried with the ID of the sprint given for its
+// provider key, if any. All the sprint tasks are fetched from the providers with no time given, as well as from the
+// providers which do not support filtering the tasks by the update time. The errors of the task providers whose
+// tasks could not be fetched are returned by their provider keys, along with the tasks of the other providers.
+func GetUpdatedSprintTaskList(
+	config []byte,
+	sprint serializers.Sprint,
+	sprintIDs map[string]string,
+	updatedSince map[string]time.Time) (tasks []serializers.Task, providerErrors map[string]error, err error) {
+	connections, err := GetConnections(config)
+	if err != nil {
+		return nil, nil, errors.New("sprint_task_list: invalid connection config")
+	}
+
+	providerErrors = make(map[string]error)
+	for _, connection := range connections {
+		providerSprint := sprint
+		providerSprint.ID = sprintIDs[connection.ProviderKey]
+		providerSprint.UpdatedSince = nil
+		if since, ok := updatedSince[connection.ProviderKey]; ok {
+			providerSprint.UpdatedSince = &since
+		}
+		providerTasks, err := connection.GetSprintTaskList(providerSprint)
+		if err != nil {
+			providerErrors[connection.ProviderKey] = err
+			continue
+		}
+		for _, task := range providerTasks {
+			task.ProviderKey = connection.ProviderKey
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, providerErrors, nil
+}
+
+// JoinProviderErrors combines the errors of the task providers into one error, ordered by the provider keys
+func JoinProviderErrors(providerErrors map[string]error) error {
+	if len(providerErrors) == 0 {
 		return nil
 	}
+	var providerKeys []string
+	for providerKey := range providerErrors {
+		providerKeys = append(providerKeys, providerKey)
+	}
+	sort.Strings(providerKeys)
+
+	var messages []string
+	for _, providerKey := range providerKeys {
+		messages = append(messages, fmt.Sprintf("%s: %s", providerKey, providerErrors[providerKey]))
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
+
+// GetSprint returns the sprint from the task providers, each task provider is queried with the ID of the sprint given
+// for its provider key. The sprint runs from the earliest start to the latest end of the sprints of the providers,
+// and it is nil if any of the providers does not have its sprint.
+func GetSprint(config []byte, sprintIDs map[string]string) (*serializers.Sprint, error) {
+	connections, err := GetConnections(config)
+	if err != nil {
+		return nil, errors.New("sprint: invalid connection config")
+	}
+	for providerKey := range sprintIDs {
+		if connections.get(providerKey) == nil {
+			return nil, fmt.Errorf("unknown task provider %s", providerKey)
+		}
+	}
+
+	var sprint *serializers.Sprint
+	for _, connection := range connections {
+		sprintID, ok := sprintIDs[connection.ProviderKey]
+		if !ok {
+			continue
+		}
+		providerSprint := connection.GetSprint(sprintID)
+		if providerSprint == nil {
+			return nil, nil
+		}
+		if sprint == nil {
+			sprint = providerSprint
+			continue
+		}
+		if providerSprint.FromDate != nil && (sprint.FromDate == nil || providerSprint.FromDate.Before(*sprint.FromDate)) {
+			sprint.FromDate = providerSprint.FromDate
+		}
+		if providerSprint.ToDate != nil && (sprint.ToDate == nil || providerSprint.ToDate.After(*sprint.ToDate)) {
+			sprint.ToDate = providerSprint.ToDate
+		}
+	}
+	return sprint, nil
+}
+
+// GetKeyOfType returns the provider key of the first connection of the given task provider type
+func (connections ProviderConnections) GetKeyOfType(providerType string) string {
+	for _, connection := range connections {
+		if connection.Type == providerType {
+			return connection.ProviderKey
+		}
+	}
+	return ""
+}
+
+// GetConnections returns the connections of all the task providers, in the order of configuration
+func GetConnections(config []byte) (connections ProviderConnections, err error) {
+	var configList []interface{}
+	if err = json.Unmarshal(config, &configList); err != nil {
+		return nil, err
+	}
+	if len(configList) == 0 {
+		return nil, errors.New("no task provider configured")
+	}
+
 	var data map[string]interface{}
 	var name string
-	if len(configList) == 0 {
-		return nil
+	providerKeyCount := make(map[string]int)
+	providerKeys := make(map[string]bool)
+	for _, tpConfig := range configList {
+		tp := tpConfig.(map[string]interface{})
+		data = tp["data"].(map[string]interface{})
+		name = tp["type"].(string)
+
+		taskProvider := GetTaskProvider(name)
+		if taskProvider == nil {
+			return nil, fmt.Errorf("unknown task provider %s", name)
+		}
+		connection := taskProvider.New(data)
+		if connection == nil {
+			return nil, fmt.Errorf("invalid config for task provider %s", name)
+		}
+
+		providerKey := getConfigProviderKey(tp, name, providerKeyCount)
+		if providerKeys[providerKey] {
+			return nil, fmt.Errorf("duplicate task provider key %s", providerKey)
+		}
+		providerKeys[providerKey] = true
+
+		connections = append(connections, ProviderConnection{
+			ProviderKey: providerKey,
+			Type:        name,
+			Connection:  connection,
+		})
+	}
+	return connections, nil
+}
+
+// AssignProviderKeys sets the key of the task provider configs which do not have one yet. The key is persisted with
+// the config, so that the tasks and the sync marks of a config stay with it when the configs are reordered or
+// removed. It is derived from the type and the non credential data of the config, so a task tracker which is
+// configured again gets back its old key.
+func AssignProviderKeys(configList []map[string]interface{}) error {
+	providerKeys := make(map[string]bool)
+	for _, taskProviderConfig := range configList {
+		if providerKey, _ := taskProviderConfig["key"].(string); providerKey != "" {
+			providerKeys[providerKey] = true
+		}
+	}
+
+	for _, taskProviderConfig := range configList {
+		if providerKey, _ := taskProviderConfig["key"].(string); providerKey != "" {
+			continue
+		}
+		name, _ := taskProviderConfig["type"].(string)
+		data, _ := taskProviderConfig["data"].(map[string]interface{})
+
+		identity := make(map[string]interface{})
+		for field, value := range data {
+			if field != "credentials" {
+				identity[field] = value
+			}
+		}
+		encodedIdentity, err := json.Marshal(identity)
+		if err != nil {
+			return err
+		}
+		checksum := sha1.Sum(encodedIdentity)
+		baseKey := fmt.Sprintf("%s-%s", name, hex.EncodeToString(checksum[:])[:8])
+
+		providerKey := baseKey
+		for count := 2; providerKeys[providerKey]; count++ {
+			providerKey = fmt.Sprintf("%s-%d", baseKey, count)
+		}
+		providerKeys[providerKey] = true
+		taskProviderConfig["key"] = providerKey
+	}
+	return nil
+}
+
+// getProviderKey returns the positional key of a task provider config which has no key, which is the provider name
+// for the first config of a provider, and suffixed with the count for the subsequent configs of the same provider
+func getProviderKey(name string, providerKeyCount map[string]int) string {
+	providerKeyCount[name]++
+	if count := providerKeyCount[name]; count > 1 {
+		return fmt.Sprintf("%s-%d", name, count)
 	}
-	tpConfig := configList[0]
-	tp := tpConfig.(map[string]interface{})
-	data = tp["data"].(map[string]interface{})
-	name = tp["type"].(string)
-	return GetTaskProvider(name).New(data)
+	return name
 }
 
-// GetTaskTypeMappings ...
-func GetTaskTypeMappings(config []byte) (map[string][]string, error) {
+// getConfigProviderKey returns the key of the task provider config, the configs saved before the provider keys were
+// persisted are keyed by their position
+func getConfigProviderKey(taskProviderConfig map[string]interface{}, name string,
+	providerKeyCount map[string]int) string {
+	positionalKey := getProviderKey(name, providerKeyCount)
+	if providerKey, _ := taskProviderConfig["key"].(string); providerKey != "" {
+		return providerKey
+	}
+	return positionalKey
+}
+
+// GetTaskTypeMappings returns the task type mappings of each task provider, mapped by the provider keys, since the
+// task types of the different task providers can collide
+func GetTaskTypeMappings(config []byte) (map[string]map[string][]string, error) {
 	var configList []interface{}
-	types := make(map[string][]string)
+	providerTypes := make(map[string]map[string][]string)
 
 	if err := json.Unmarshal(config, &configList); err != nil {
 		return nil, err
 	}
 
 	var data map[string]interface{}
+	providerKeyCount := make(map[string]int)
 	for _, tpConfig := range configList {
 		tp := tpConfig.(map[string]interface{})
 		data = tp["data"].(map[string]interface{})
+		name, _ := tp["type"].(string)
 
+		types := make(map[string][]string)
+		providerTypes[getConfigProviderKey(tp, name, providerKeyCount)] = types
 		for _, taskType := range TaskTypes {
 			typeUpper, ok := data[taskType].(string)
 			if !ok {
@@ -227,23 +512,28 @@ func GetTaskTypeMappings(config []byte) (map[string][]string, error) {
 		}
 	}
 
-	return types, nil
+	return providerTypes, nil
 }
 
-// GetStatusMapping ...
-func GetStatusMapping(config []byte) (map[string][]string, error) {
+// GetStatusMapping returns the status mappings of each task provider, mapped by the provider keys, so that a status
+// of one task provider does not apply to the tasks of another
+func GetStatusMapping(config []byte) (map[string]map[string][]string, error) {
 	var configList []interface{}
-	statusType := make(map[string][]string)
+	providerStatuses := make(map[string]map[string][]string)
 
 	if err := json.Unmarshal(config, &configList); err != nil {
 		return nil, err
 	}
 
 	var data map[string]interface{}
+	providerKeyCount := make(map[string]int)
 	for _, tpConfig := range configList {
 		tp := tpConfig.(map[string]interface{})
 		data = tp["data"].(map[string]interface{})
+		name, _ := tp["type"].(string)
 
+		statusType := make(map[string][]string)
+		providerStatuses[getConfigProviderKey(tp, name, providerKeyCount)] = statusType
 		for _, status := range StatusTypes {
 			statusUpper, ok := data[status].(string)
 			if ok {
@@ -258,17 +548,31 @@ func GetStatusMapping(config []byte) (map[string][]string, error) {
 			}
 		}
 	}
-	return statusType, nil
+	return providerStatuses, nil
 }
 
 // ValidateConfigs ...
 func ValidateConfigs(taskProviderConfigList []map[string]interface{}) (err error) {
 	for _, taskProviderConfig := range taskProviderConfigList {
-		taskProvider := GetTaskProvider(taskProviderConfig["type"].(string))
+		name, _ := taskProviderConfig["type"].(string)
+		taskProvider := GetTaskProvider(name)
+		// The unknown providers and the configs the providers can not connect with, eg. with the credentials of
+		// an unsupported type, are reported as the config errors instead of failing on the missing connection
+		configErr := &ConfigError{}
+		if taskProvider == nil {
+			configErr.Add(InvalidConfigDiagnostic, "type", fmt.Sprintf("unknown task provider %s", name))
+			return configErr
+		}
 		taskProviderConnection := taskProvider.New(taskProviderConfig["data"])
+		if taskProviderConnection == nil {
+			configErr.Add(InvalidConfigDiagnostic, "", "invalid config for "+name)
+			return configErr
+		}
 		if err = taskProviderConnection.ValidateConfig(); err != nil {
-			utils.LogToSentry(err)
-			return errors.New("failed to validate config for " + taskProviderConfig["type"].(string))
+			if _, isConfigErr := err.(*ConfigError); !isConfigErr {
+				utils.LogToSentry(err)
+			}
+			return fmt.Errorf("failed to validate config for %s: %v", name, err)
 		}
 	}
 	return nil
@@ -0,0 +1,888 @@
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	"github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// GitHubTaskProvider ...
+type GitHubTaskProvider struct {
+}
+
+// GitHubConnection ...
+type GitHubConnection struct {
+	config GitHubConfig
+	client *http.Client
+}
+
+// GitHubConfig ...
+type GitHubConfig struct {
+	Credentials         tasktracker.Credentials `json:"Credentials"`
+	BaseURL             string                  `json:"BaseURL"`
+	Owner               string                  `json:"Owner"`
+	Repository          string                  `json:"Repository"`
+	SprintSource        string                  `json:"SprintSource"`
+	ProjectNumber       string                  `json:"ProjectNumber"`
+	IterationField      string                  `json:"IterationField"`
+	EstimateLabelPrefix string                  `json:"EstimateLabelPrefix"`
+	FeatureTypes        string                  `json:"FeatureTypes"`
+	TaskTypes           string                  `json:"TaskTypes"`
+	BugTypes            string                  `json:"BugTypes"`
+	DoneStatus          string                  `json:"DoneStatus"`
+}
+
+// GetBaseURL returns the API base url, defaulting to github.com for non-enterprise installations
+func (config GitHubConfig) GetBaseURL() string {
+	if config.BaseURL == "" {
+		return gitHubDefaultBaseURL
+	}
+	// Just to make sure there are no trailing slashes in the base url, even if provided by the user.
+	return strings.Trim(config.BaseURL, "/")
+}
+
+// GetWebURL returns the url of the GitHub web UI corresponding to the API base url
+func (config GitHubConfig) GetWebURL() string {
+	baseURL := config.GetBaseURL()
+	if baseURL == gitHubDefaultBaseURL {
+		return "https://github.com"
+	}
+	// GitHub Enterprise serves its API under "/api/v3" of the web UI host
+	return strings.TrimSuffix(baseURL, "/api/v3")
+}
+
+// GetGraphQLURL returns the url of the GraphQL API, which GitHub Enterprise serves under "/api/graphql"
+func (config GitHubConfig) GetGraphQLURL() string {
+	baseURL := config.GetBaseURL()
+	if baseURL == gitHubDefaultBaseURL {
+		return baseURL + "/graphql"
+	}
+	return strings.TrimSuffix(baseURL, "/v3") + "/graphql"
+}
+
+// UsesIterations tells whether the sprints are backed by the iterations of a GitHub project instead of the milestones
+func (config GitHubConfig) UsesIterations() bool {
+	return config.SprintSource == GitHubSprintSourceIteration
+}
+
+// GetIterationField returns the name of the iteration field of the GitHub project, defaulting to "Iteration"
+func (config GitHubConfig) GetIterationField() string {
+	if config.IterationField == "" {
+		return gitHubDefaultIterationField
+	}
+	return config.IterationField
+}
+
+// TaskProviderGitHub ...
+const (
+	TaskProviderGitHub = "github"
+)
+
+// GitHub sprint sources
+const (
+	GitHubSprintSourceMilestone = "milestone"
+	GitHubSprintSourceIteration = "iteration"
+)
+
+const gitHubDefaultBaseURL = "https://api.github.com"
+
+const gitHubDefaultIterationField = "Iteration"
+
+// gitHubDateLayout is the layout of the start dates of the iterations
+const gitHubDateLayout = "2006-01-02"
+
+// gitHubNotFoundError is the type of the GraphQL errors of the missing resources, eg. the issue numbers which are not
+// issues of the repository
+const gitHubNotFoundError = "NOT_FOUND"
+
+// gitHubIssueFields are the fields of the issues fetched through the GraphQL API
+const gitHubIssueFields = `databaseId number title body state updatedAt repository { nameWithOwner }
+	labels(first: 100) { nodes { name } } assignees(first: 20) { nodes { login } }`
+
+const gitHubIterationsQuery = `query($owner: String!, $number: Int!, $field: String!) {
+	repositoryOwner(login: $owner) { ... on ProjectV2Owner { projectV2(number: $number) {
+		field(name: $field) { ... on ProjectV2IterationField { configuration {
+			iterations { id title startDate duration }
+			completedIterations { id title startDate duration }
+		} } }
+	} } }
+}`
+
+const gitHubProjectItemsQuery = `query($owner: String!, $number: Int!, $field: String!, $after: String) {
+	repositoryOwner(login: $owner) { ... on ProjectV2Owner { projectV2(number: $number) {
+		items(first: 100, after: $after) {
+			pageInfo { hasNextPage endCursor }
+			nodes {
+				updatedAt
+				fieldValueByName(name: $field) { ... on ProjectV2ItemFieldIterationValue { iterationId } }
+				content { ... on Issue { ` + gitHubIssueFields + ` } }
+			}
+		}
+	} } }
+}`
+
+// gitHubPageSize is the maximum page size supported by the GitHub REST API
+const gitHubPageSize = 100
+
+var gitHubNextPageRegex = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+type gitHubLabel struct {
+	Name string `json:"name"`
+}
+
+type gitHubUser struct {
+	Login string `json:"login"`
+}
+
+type gitHubIssue struct {
+	ID          int64           `json:"id"`
+	Number      int             `json:"number"`
+	Title       string          `json:"title"`
+	Body        string          `json:"body"`
+	State       string          `json:"state"`
+	Labels      []gitHubLabel   `json:"labels"`
+	Assignees   []gitHubUser    `json:"assignees"`
+	PullRequest json.RawMessage `json:"pull_request"`
+}
+
+type gitHubMilestone struct {
+	ID        int64      `json:"id"`
+	Number    int        `json:"number"`
+	Title     string     `json:"title"`
+	CreatedAt *time.Time `json:"created_at"`
+	DueOn     *time.Time `json:"due_on"`
+}
+
+// gitHubGraphQLIssue is an issue as returned by the GraphQL API
+type gitHubGraphQLIssue struct {
+	DatabaseID int64      `json:"databaseId"`
+	Number     int        `json:"number"`
+	Title      string     `json:"title"`
+	Body       string     `json:"body"`
+	State      string     `json:"state"`
+	UpdatedAt  *time.Time `json:"updatedAt"`
+	Repository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
+	Labels struct {
+		Nodes []gitHubLabel `json:"nodes"`
+	} `json:"labels"`
+	Assignees struct {
+		Nodes []gitHubUser `json:"nodes"`
+	} `json:"assignees"`
+}
+
+// gitHubIteration is an iteration of the iteration field of a GitHub project
+type gitHubIteration struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	StartDate string `json:"startDate"`
+	Duration  int    `json:"duration"`
+}
+
+type gitHubProjectItem struct {
+	UpdatedAt        *time.Time `json:"updatedAt"`
+	FieldValueByName *struct {
+		IterationID string `json:"iterationId"`
+	} `json:"fieldValueByName"`
+	Content *gitHubGraphQLIssue `json:"content"`
+}
+
+type gitHubGraphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// toIssue converts the GraphQL issue to its REST representation, the GraphQL API has the issue states in upper case
+func (issue gitHubGraphQLIssue) toIssue() gitHubIssue {
+	return gitHubIssue{
+		ID:        issue.DatabaseID,
+		Number:    issue.Number,
+		Title:     issue.Title,
+		Body:      issue.Body,
+		State:     strings.ToLower(issue.State),
+		Labels:    issue.Labels.Nodes,
+		Assignees: issue.Assignees.Nodes,
+	}
+}
+
+func (issue gitHubIssue) labelNames() []string {
+	var labels []string
+	for _, label := range issue.Labels {
+		labels = append(labels, label.Name)
+	}
+	return labels
+}
+
+func init() {
+	provider := &GitHubTaskProvider{}
+	tasktracker.RegisterTaskProvider(TaskProviderGitHub, provider)
+}
+
+// New ...
+func (p *GitHubTaskProvider) New(config interface{}) tasktracker.Connection {
+	var gitHubConfig GitHubConfig
+	gitHubConfig, err := getGitHubConfigObject(config)
+
+	if err != nil {
+		return nil
+	}
+
+	switch gitHubConfig.Credentials.Type {
+	case "apiToken":
+	default:
+		return nil
+	}
+	return &GitHubConnection{config: gitHubConfig, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// getGitHubConfigObject ...
+func getGitHubConfigObject(config interface{}) (GitHubConfig, error) {
+	var c GitHubConfig
+
+	switch config.(type) {
+	case []byte:
+		c = GitHubConfig{}
+		err := json.Unmarshal(config.([]byte), &c)
+		if err != nil {
+			return c, err
+		}
+	case map[string]interface{}:
+		c = GitHubConfig{}
+
+		jsonConfig, err := json.Marshal(config)
+		if err != nil {
+			return c, err
+		}
+
+		err = json.Unmarshal(jsonConfig, &c)
+		if err != nil {
+			return c, err
+		}
+	case GitHubConfig:
+		c = config.(GitHubConfig)
+	default:
+		return c, errors.New("invalid type")
+	}
+	return c, nil
+}
+
+// ConfigTemplate ...
+func (p *GitHubTaskProvider) ConfigTemplate() (configMap map[string]interface{}) {
+	configMap = map[string]interface{}{
+		"Type":               TaskProviderGitHub,
+		"DisplayTitle":       "GitHub Issues",
+		"SupportedAuthTypes": []string{"apiToken"},
+		"Fields": []map[string]interface{}{
+			{
+				"FieldName":        "Owner",
+				"FieldDisplayName": "Owner (user or organization) of the repository. eg. 'iReflect'",
+				"Type":             "string",
+				"Required":         true,
+			},
+			{
+				"FieldName":        "Repository",
+				"FieldDisplayName": "Name of the repository. eg. 'reflect-app'",
+				"Type":             "string",
+				"Required":         true,
+			},
+			{
+				"FieldName":        "BaseURL",
+				"FieldDisplayName": "API Base URL (Leave blank for github.com). eg. 'https://github.example.com/api/v3'",
+				"Type":             "string",
+				"Required":         false,
+			},
+			{
+				"FieldName":        "EstimateLabelPrefix",
+				"FieldDisplayName": "Prefix of the labels holding the estimate. eg. 'estimate:' for a label 'estimate: 3'",
+				"Type":             "string",
+				"Required":         false,
+			},
+			{
+				"FieldName":        "SprintSource",
+				"FieldDisplayName": "Sprints are backed by, 'milestone' (default) or 'iteration' of a GitHub project",
+				"Type":             "string",
+				"Required":         false,
+				"Hint": "<i>Use the milestone number, or the ID of the iteration of the project, as the sprint ID. " +
+					"Milestones have no start date, so a sprint starts the day after the due date of the " +
+					"previous milestone.</i>",
+			},
+			{
+				"FieldName":        "ProjectNumber",
+				"FieldDisplayName": "Number of the GitHub project of the owner, for the 'iteration' sprint source",
+				"Type":             "string",
+				"Required":         false,
+			},
+			{
+				"FieldName":        "IterationField",
+				"FieldDisplayName": "Name of the iteration field of the GitHub project (Leave blank for 'Iteration')",
+				"Type":             "string",
+				"Required":         false,
+			},
+		},
+	}
+	return configMap
+}
+
+// GetTaskUrl ...
+func (c *GitHubConnection) GetTaskUrl(ticketKey string) string {
+	return fmt.Sprintf("%v/%v/%v/issues/%v", c.config.GetWebURL(), c.config.Owner, c.config.Repository,
+		strings.TrimPrefix(ticketKey, "#"))
+}
+
+// GetTaskList fetches the issues through the GraphQL API, a page of issues per request, since the REST API can not
+// filter the issues by their numbers
+func (c *GitHubConnection) GetTaskList(ticketKeys []string) []serializers.Task {
+	var issueNumbers []int
+	for _, ticketKey := range ticketKeys {
+		// Since the issue numbers are always number in GitHub, if a value is not, it can't be a GitHub issue
+		if issueNumber, err := strconv.Atoi(strings.TrimPrefix(ticketKey, "#")); err == nil {
+			issueNumbers = append(issueNumbers, issueNumber)
+		}
+	}
+
+	var tickets []serializers.Task
+	for start := 0; start < len(issueNumbers); start += gitHubPageSize {
+		end := start + gitHubPageSize
+		if end > len(issueNumbers) {
+			end = len(issueNumbers)
+		}
+		issues, err := c.getIssues(issueNumbers[start:end])
+		if err != nil {
+			utils.LogToSentry(err)
+			return nil
+		}
+		for _, issue := range issues {
+			tickets = append(tickets, *c.serializeTicket(issue))
+		}
+	}
+	return tickets
+}
+
+// GetTask ...
+func (c *GitHubConnection) GetTask(ticketKey string) (*serializers.Task, error) {
+	issueNumber, err := strconv.Atoi(strings.TrimPrefix(ticketKey, "#"))
+	// Since the issue numbers are always number in GitHub, if a value is not, it can't be a GitHub issue
+	if err != nil {
+		return nil, nil
+	}
+
+	var issue gitHubIssue
+	resp, err := c.get(c.repoPath(fmt.Sprintf("issues/%d", issueNumber)), nil, &issue)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		utils.LogToSentry(fmt.Errorf("%s: %s", ticketKey, err))
+		return nil, err
+	}
+
+	// The issues API returns the pull requests as well, which are not considered as tasks
+	if issue.PullRequest != nil {
+		return nil, nil
+	}
+
+	return c.serializeTicket(issue), nil
+}
+
+// GetSprint ...
+func (c *GitHubConnection) GetSprint(sprintID string) *serializers.Sprint {
+	if c.config.UsesIterations() {
+		return c.getIterationSprint(sprintID)
+	}
+
+	milestoneNumber, err := strconv.Atoi(sprintID)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+
+	var milestone gitHubMilestone
+	if _, err = c.get(c.repoPath(fmt.Sprintf("milestones/%d", milestoneNumber)), nil, &milestone); err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+
+	fromDate, err := c.getMilestoneStartDate(milestone)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+
+	return &serializers.Sprint{
+		ID:       sprintID,
+		BoardID:  "",
+		Name:     milestone.Title,
+		FromDate: fromDate,
+		ToDate:   milestone.DueOn,
+	}
+}
+
+// getIterationSprint returns the sprint of the given iteration of the GitHub project, if any
+func (c *GitHubConnection) getIterationSprint(iterationID string) *serializers.Sprint {
+	iterations, err := c.getIterations()
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+	for _, iteration := range iterations {
+		if iteration.ID != iterationID {
+			continue
+		}
+		startDate, err := time.Parse(gitHubDateLayout, iteration.StartDate)
+		if err != nil {
+			utils.LogToSentry(err)
+			return nil
+		}
+		// The duration is in days, including the start date
+		endDate := startDate.AddDate(0, 0, iteration.Duration-1)
+		return &serializers.Sprint{
+			ID:       iterationID,
+			BoardID:  "",
+			Name:     iteration.Title,
+			FromDate: &startDate,
+			ToDate:   &endDate,
+		}
+	}
+	return nil
+}
+
+// getMilestoneStartDate returns the start date of the milestone, which GitHub milestones do not have. The milestone
+// is taken to start the day after the latest due date of the milestones due before it, or on its creation for the
+// first milestone.
+func (c *GitHubConnection) getMilestoneStartDate(milestone gitHubMilestone) (*time.Time, error) {
+	if milestone.DueOn == nil {
+		return milestone.CreatedAt, nil
+	}
+
+	query := url.Values{}
+	query.Set("state", "all")
+	var previousDueOn *time.Time
+	err := c.list(c.repoPath("milestones"), query, func(page []byte) error {
+		var milestones []gitHubMilestone
+		if err := json.Unmarshal(page, &milestones); err != nil {
+			return err
+		}
+		for _, other := range milestones {
+			if other.DueOn != nil && other.DueOn.Before(*milestone.DueOn) &&
+				(previousDueOn == nil || other.DueOn.After(*previousDueOn)) {
+				previousDueOn = other.DueOn
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if previousDueOn == nil {
+		return milestone.CreatedAt, nil
+	}
+	dueDate := previousDueOn.UTC()
+	startDate := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day()+1, 0, 0, 0, 0, time.UTC)
+	return &startDate, nil
+}
+
+// GetSprintTaskList ...
+func (c *GitHubConnection) GetSprintTaskList(sprint serializers.Sprint) ([]serializers.Task, error) {
+	if sprint.ID == "" {
+		return nil, nil
+	}
+	if c.config.UsesIterations() {
+		tasks, err := c.getIterationTaskList(sprint)
+		if err != nil {
+			utils.LogToSentry(err)
+		}
+		return tasks, err
+	}
+	if _, err := strconv.Atoi(sprint.ID); err != nil {
+		return nil, fmt.Errorf("milestone number %s is not a number", sprint.ID)
+	}
+
+	query := url.Values{}
+	query.Set("milestone", sprint.ID)
+	query.Set("state", "all")
+	if sprint.UpdatedSince != nil {
+		query.Set("since", sprint.UpdatedSince.UTC().Format(time.RFC3339))
+	}
+
+	issues, err := c.listIssues(query)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+
+	var tickets []serializers.Task
+	for _, issue := range issues {
+		if issue.PullRequest != nil {
+			continue
+		}
+		tickets = append(tickets, *c.serializeTicket(issue))
+	}
+	return tickets, nil
+}
+
+// getIterationTaskList fetches the issues of the repository in the given iteration of the GitHub project. The
+// project items can not be filtered by the iteration, so all the items of the project are fetched.
+func (c *GitHubConnection) getIterationTaskList(sprint serializers.Sprint) ([]serializers.Task, error) {
+	repository := strings.ToLower(fmt.Sprintf("%s/%s", c.config.Owner, c.config.Repository))
+	variables, err := c.getProjectVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	var tickets []serializers.Task
+	for {
+		var data struct {
+			RepositoryOwner struct {
+				ProjectV2 *struct {
+					Items struct {
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+						Nodes []gitHubProjectItem `json:"nodes"`
+					} `json:"items"`
+				} `json:"projectV2"`
+			} `json:"repositoryOwner"`
+		}
+		if err := c.graphQL(gitHubProjectItemsQuery, variables, &data); err != nil {
+			return nil, err
+		}
+		project := data.RepositoryOwner.ProjectV2
+		if project == nil {
+			return nil, fmt.Errorf("project %s was not found", c.config.ProjectNumber)
+		}
+
+		for _, item := range project.Items.Nodes {
+			// The draft issues and the pull requests have no issue content
+			if item.Content == nil || item.Content.DatabaseID == 0 || item.FieldValueByName == nil ||
+				item.FieldValueByName.IterationID != sprint.ID ||
+				strings.ToLower(item.Content.Repository.NameWithOwner) != repository {
+				continue
+			}
+			// An issue moved into the iteration has only the project item updated
+			if sprint.UpdatedSince != nil && isGitHubTimeBefore(item.UpdatedAt, *sprint.UpdatedSince) &&
+				isGitHubTimeBefore(item.Content.UpdatedAt, *sprint.UpdatedSince) {
+				continue
+			}
+			tickets = append(tickets, *c.serializeTicket(item.Content.toIssue()))
+		}
+
+		if !project.Items.PageInfo.HasNextPage {
+			return tickets, nil
+		}
+		variables["after"] = project.Items.PageInfo.EndCursor
+	}
+}
+
+// getIterations fetches the current and the completed iterations of the iteration field of the GitHub project
+func (c *GitHubConnection) getIterations() ([]gitHubIteration, error) {
+	variables, err := c.getProjectVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		RepositoryOwner struct {
+			ProjectV2 *struct {
+				Field *struct {
+					Configuration *struct {
+						Iterations          []gitHubIteration `json:"iterations"`
+						CompletedIterations []gitHubIteration `json:"completedIterations"`
+					} `json:"configuration"`
+				} `json:"field"`
+			} `json:"projectV2"`
+		} `json:"repositoryOwner"`
+	}
+	if err = c.graphQL(gitHubIterationsQuery, variables, &data); err != nil {
+		return nil, err
+	}
+	project := data.RepositoryOwner.ProjectV2
+	if project == nil {
+		return nil, fmt.Errorf("project %s was not found", c.config.ProjectNumber)
+	}
+	if project.Field == nil || project.Field.Configuration == nil {
+		return nil, fmt.Errorf("iteration field %s was not found", c.config.GetIterationField())
+	}
+	return append(project.Field.Configuration.Iterations, project.Field.Configuration.CompletedIterations...), nil
+}
+
+// getProjectVariables returns the GraphQL variables identifying the iteration field of the GitHub project
+func (c *GitHubConnection) getProjectVariables() (map[string]interface{}, error) {
+	projectNumber, err := strconv.Atoi(c.config.ProjectNumber)
+	if err != nil {
+		return nil, fmt.Errorf("project number %s is not a number", c.config.ProjectNumber)
+	}
+	return map[string]interface{}{
+		"owner":  c.config.Owner,
+		"number": projectNumber,
+		"field":  c.config.GetIterationField(),
+	}, nil
+}
+
+// getIssues fetches the given issues of the repository in a single GraphQL request, the numbers which are not issues
+// of the repository, eg. the pull requests, are skipped
+func (c *GitHubConnection) getIssues(issueNumbers []int) ([]gitHubIssue, error) {
+	var issueQueries []string
+	for index, issueNumber := range issueNumbers {
+		issueQueries = append(issueQueries,
+			fmt.Sprintf("issue%d: issue(number: %d) { %s }", index, issueNumber, gitHubIssueFields))
+	}
+	query := fmt.Sprintf("query($owner: String!, $name: String!) { repository(owner: $owner, name: $name) { %s } }",
+		strings.Join(issueQueries, " "))
+
+	var data struct {
+		Repository map[string]*gitHubGraphQLIssue `json:"repository"`
+	}
+	variables := map[string]interface{}{"owner": c.config.Owner, "name": c.config.Repository}
+	if err := c.graphQL(query, variables, &data); err != nil {
+		return nil, err
+	}
+
+	var issues []gitHubIssue
+	for index := range issueNumbers {
+		if issue := data.Repository[fmt.Sprintf("issue%d", index)]; issue != nil {
+			issues = append(issues, issue.toIssue())
+		}
+	}
+	return issues, nil
+}
+
+// ValidateConfig validates if the provided API Token, Owner and Repository are correct, along with the GitHub
+// project for the iteration sprint source
+func (c *GitHubConnection) ValidateConfig() error {
+	configErr := &tasktracker.ConfigError{}
+	if c.config.Owner == "" || c.config.Repository == "" {
+		configErr.Add(tasktracker.InvalidConfigDiagnostic, "Repository", "owner and repository are required")
+		return configErr
+	}
+	switch c.config.SprintSource {
+	case "", GitHubSprintSourceMilestone, GitHubSprintSourceIteration:
+	default:
+		configErr.Add(tasktracker.InvalidConfigDiagnostic, "SprintSource",
+			"sprint source should either be 'milestone' or 'iteration'")
+		return configErr
+	}
+	if c.config.UsesIterations() {
+		if _, err := strconv.Atoi(c.config.ProjectNumber); err != nil {
+			configErr.Add(tasktracker.InvalidConfigDiagnostic, "ProjectNumber",
+				"project number is required for the 'iteration' sprint source")
+			return configErr
+		}
+	}
+
+	resp, err := c.get(c.repoPath(""), nil, nil)
+	if err != nil {
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		configErr.AddResponseDiagnostic(statusCode, err, tasktracker.UnknownProjectDiagnostic, "Repository")
+		return configErr
+	}
+	if c.config.UsesIterations() {
+		if _, err = c.getIterations(); err != nil {
+			utils.LogToSentry(err)
+			configErr.Add(tasktracker.UnknownProjectDiagnostic, "ProjectNumber",
+				"the project or its iteration field was not found")
+		}
+	}
+	return configErr.OrNil()
+}
+
+// repoPath returns the API path of the given resource in the configured repository
+func (c *GitHubConnection) repoPath(resource string) string {
+	repoPath := fmt.Sprintf("repos/%s/%s", url.PathEscape(c.config.Owner), url.PathEscape(c.config.Repository))
+	if resource == "" {
+		return repoPath
+	}
+	return repoPath + "/" + resource
+}
+
+// listIssues fetches all the pages of the issues matching the given query
+func (c *GitHubConnection) listIssues(query url.Values) ([]gitHubIssue, error) {
+	var issues []gitHubIssue
+	err := c.list(c.repoPath("issues"), query, func(page []byte) error {
+		var pageIssues []gitHubIssue
+		err := json.Unmarshal(page, &pageIssues)
+		issues = append(issues, pageIssues...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// list calls the paginated GitHub API for the given path and passes the raw body of each page to the handler
+func (c *GitHubConnection) list(path string, query url.Values, handlePage func(page []byte) error) error {
+	query.Set("per_page", strconv.Itoa(gitHubPageSize))
+	requestURL := fmt.Sprintf("%s/%s?%s", c.config.GetBaseURL(), path, query.Encode())
+	for requestURL != "" {
+		var body json.RawMessage
+		resp, err := c.do("GET", requestURL, nil, &body)
+		if err != nil {
+			return err
+		}
+		if err = handlePage(body); err != nil {
+			return err
+		}
+		requestURL = getGitHubNextPageURL(resp)
+	}
+	return nil
+}
+
+// graphQL calls the GitHub GraphQL API with the given query and decodes the data of the response into v. The errors
+// of the missing resources are ignored, their data being null.
+func (c *GitHubConnection) graphQL(query string, variables map[string]interface{}, v interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Data   json.RawMessage      `json:"data"`
+		Errors []gitHubGraphQLError `json:"errors"`
+	}
+	if _, err = c.do("POST", c.config.GetGraphQLURL(), bytes.NewReader(body), &response); err != nil {
+		return err
+	}
+	for _, graphQLError := range response.Errors {
+		if graphQLError.Type != gitHubNotFoundError {
+			return fmt.Errorf("github: %s", graphQLError.Message)
+		}
+	}
+	if len(response.Data) == 0 {
+		return errors.New("github: no data in the response")
+	}
+	return json.Unmarshal(response.Data, v)
+}
+
+// get calls the GitHub API for the given path and decodes the response body into v (if provided)
+func (c *GitHubConnection) get(path string, query url.Values, v interface{}) (*http.Response, error) {
+	requestURL := fmt.Sprintf("%s/%s", c.config.GetBaseURL(), path)
+	if len(query) != 0 {
+		requestURL = requestURL + "?" + query.Encode()
+	}
+	return c.do("GET", requestURL, nil, v)
+}
+
+func (c *GitHubConnection) do(method string, requestURL string, body io.Reader, v interface{}) (*http.Response, error) {
+	req, err := http.NewRequest(method, requestURL, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Authorization", "token "+c.config.Credentials.APIToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var errorResponse struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&errorResponse)
+		return resp, fmt.Errorf("github: %s (%d)", errorResponse.Message, resp.StatusCode)
+	}
+
+	if v != nil {
+		if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+// isGitHubTimeBefore tells whether the given time is missing or before the other time
+func isGitHubTimeBefore(updatedAt *time.Time, other time.Time) bool {
+	return updatedAt == nil || updatedAt.Before(other)
+}
+
+// getGitHubNextPageURL parses the Link header of the response to get the url of the next page, if any
+func getGitHubNextPageURL(resp *http.Response) string {
+	matches := gitHubNextPageRegex.FindStringSubmatch(resp.Header.Get("Link"))
+	if len(matches) < 2 {
+		return ""
+	}
+	return matches[1]
+}
+
+func (c *GitHubConnection) serializeTicket(issue gitHubIssue) *serializers.Task {
+	issueNumber := strconv.Itoa(issue.Number)
+	task := &serializers.Task{
+		Key:             issueNumber,
+		TrackerUniqueID: strconv.FormatInt(issue.ID, 10),
+		ProjectID:       fmt.Sprintf("%s/%s", c.config.Owner, c.config.Repository),
+		Summary:         issue.Title,
+		Description:     issue.Body,
+		Type:            c.getIssueType(issue),
+		Status:          c.getIssueStatus(issue),
+		Estimate:        c.getIssueEstimate(issue),
+		Priority:        "",
+	}
+
+	var assignees []string
+	for _, assignee := range issue.Assignees {
+		assignees = append(assignees, assignee.Login)
+	}
+	task.Assignee = strings.Join(assignees, ", ")
+
+	return task
+}
+
+// getIssueType returns the first label of the issue which is mapped to any of the task types,
+// since GitHub issues do not have a type of their own
+func (c *GitHubConnection) getIssueType(issue gitHubIssue) string {
+	issueType := getMappedLabel(issue.labelNames(), c.config.FeatureTypes, c.config.TaskTypes, c.config.BugTypes)
+	if issueType == "" {
+		return "Issue"
+	}
+	return issueType
+}
+
+// getIssueStatus returns the label of the issue which is mapped to the done status if any, otherwise the issue state
+func (c *GitHubConnection) getIssueStatus(issue gitHubIssue) string {
+	if status := getMappedLabel(issue.labelNames(), c.config.DoneStatus); status != "" {
+		return status
+	}
+	return issue.State
+}
+
+// getIssueEstimate reads the estimate from the label having the configured estimate label prefix
+func (c *GitHubConnection) getIssueEstimate(issue gitHubIssue) *float64 {
+	prefix := strings.ToLower(strings.TrimSpace(c.config.EstimateLabelPrefix))
+	if prefix == "" {
+		return nil
+	}
+	for _, label := range issue.Labels {
+		labelName := strings.ToLower(strings.TrimSpace(label.Name))
+		if !strings.HasPrefix(labelName, prefix) {
+			continue
+		}
+		estimate, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimPrefix(labelName, prefix)), 64)
+		if err == nil {
+			return &estimate
+		}
+	}
+	return nil
+}
@@ -0,0 +1,341 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	"github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+)
+
+const gitHubTestToken = "secret-token"
+
+const gitHubTestIssue = `{"id": 1007, "number": 7, "title": "Login page", "state": "closed",
+	"labels": [{"name": "Story"}, {"name": "Done"}, {"name": "estimate: 3"}], "assignees": [{"login": "jane"}]}`
+
+const gitHubTestGraphQLIssue = `{"databaseId": 1007, "number": 7, "title": "Login page", "state": "CLOSED",
+	"updatedAt": "2018-06-12T10:00:00Z", "repository": {"nameWithOwner": "iReflect/reflect-app"},
+	"labels": {"nodes": [{"name": "Story"}, {"name": "Done"}]}, "assignees": {"nodes": [{"login": "jane"}]}}`
+
+const gitHubTestOldGraphQLIssue = `{"databaseId": 1008, "number": 8, "title": "Broken link", "state": "OPEN",
+	"updatedAt": "2018-06-05T10:00:00Z", "repository": {"nameWithOwner": "iReflect/reflect-app"},
+	"labels": {"nodes": [{"name": "Bug"}]}, "assignees": {"nodes": []}}`
+
+// gitHubTestServer serves the GitHub REST and GraphQL APIs and counts the GraphQL requests
+type gitHubTestServer struct {
+	*httptest.Server
+	graphQLRequests int
+}
+
+func newGitHubTestServer(t *testing.T) *gitHubTestServer {
+	server := &gitHubTestServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/ireflect/reflect-app", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 42, "full_name": "iReflect/reflect-app"}`)
+	})
+	mux.HandleFunc("/api/v3/repos/ireflect/reflect-app/issues/7", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, gitHubTestIssue)
+	})
+	mux.HandleFunc("/api/v3/repos/ireflect/reflect-app/milestones/5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 905, "number": 5, "title": "Sprint 5", "created_at": "2018-05-20T10:00:00Z",
+			"due_on": "2018-06-15T07:00:00Z"}`)
+	})
+	mux.HandleFunc("/api/v3/repos/ireflect/reflect-app/milestones", func(w http.ResponseWriter, r *http.Request) {
+		// Serve the milestones in two pages to exercise the pagination, the previous milestone is on the first one
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=2>; rel="next"`, r.Host, r.URL.Path))
+			fmt.Fprint(w, `[{"number": 4, "due_on": "2018-06-01T07:00:00Z"}, {"number": 7, "due_on": null}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"number": 3, "due_on": "2018-05-18T07:00:00Z"}, {"number": 6, "due_on": "2018-06-29T07:00:00Z"},
+			{"number": 5, "due_on": "2018-06-15T07:00:00Z"}]`)
+	})
+	mux.HandleFunc("/api/v3/repos/ireflect/reflect-app/issues", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("milestone") != "5" || r.URL.Query().Get("state") != "all" {
+			t.Errorf("unexpected issue filters %q", r.URL.RawQuery)
+		}
+		// Serve the issues in two pages to exercise the pagination, only the second issue is updated recently
+		if r.URL.Query().Get("page") == "" && r.URL.Query().Get("since") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?milestone=5&state=all&page=2>; rel="next"`,
+				r.Host, r.URL.Path))
+			fmt.Fprint(w, `[`+gitHubTestIssue+`, {"id": 1009, "number": 9, "state": "open", "pull_request": {}}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id": 1008, "number": 8, "title": "Broken link", "state": "open",
+			"labels": [{"name": "Bug"}]}]`)
+	})
+	mux.HandleFunc("/api/graphql", func(w http.ResponseWriter, r *http.Request) {
+		server.graphQLRequests++
+		var request struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if r.Method != "POST" || json.NewDecoder(r.Body).Decode(&request) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// Only the project 3 exists, with the iteration field "Sprint"
+		if strings.Contains(request.Query, "projectV2") &&
+			(request.Variables["number"] != float64(3) || request.Variables["field"] != "Sprint") {
+			fmt.Fprint(w, `{"data": {"repositoryOwner": {"projectV2": null}},
+				"errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a ProjectV2"}]}`)
+			return
+		}
+
+		switch {
+		case strings.Contains(request.Query, "ProjectV2IterationField"):
+			fmt.Fprint(w, `{"data": {"repositoryOwner": {"projectV2": {"field": {"configuration": {
+				"iterations": [{"id": "it-2", "title": "Sprint 2", "startDate": "2018-06-04", "duration": 12}],
+				"completedIterations": [{"id": "it-1", "title": "Sprint 1", "startDate": "2018-05-21", "duration": 14}]
+			}}}}}}`)
+		case strings.Contains(request.Query, "items("):
+			// Serve the project items in two pages, along with the items of the other iterations and repositories
+			if request.Variables["after"] == nil {
+				fmt.Fprint(w, `{"data": {"repositoryOwner": {"projectV2": {"items": {
+					"pageInfo": {"hasNextPage": true, "endCursor": "cursor-1"},
+					"nodes": [
+						{"updatedAt": "2018-06-12T10:00:00Z", "fieldValueByName": {"iterationId": "it-2"},
+							"content": `+gitHubTestGraphQLIssue+`},
+						{"updatedAt": "2018-06-12T10:00:00Z", "fieldValueByName": {"iterationId": "it-1"},
+							"content": {"databaseId": 1010, "number": 10, "state": "OPEN",
+								"repository": {"nameWithOwner": "iReflect/reflect-app"}}}
+					]}}}}}`)
+				return
+			}
+			fmt.Fprint(w, `{"data": {"repositoryOwner": {"projectV2": {"items": {
+				"pageInfo": {"hasNextPage": false, "endCursor": "cursor-2"},
+				"nodes": [
+					{"updatedAt": "2018-06-12T10:00:00Z", "fieldValueByName": {"iterationId": "it-2"},
+						"content": {"databaseId": 2011, "number": 11, "state": "OPEN",
+							"repository": {"nameWithOwner": "iReflect/other-app"}}},
+					{"updatedAt": "2018-06-12T10:00:00Z", "fieldValueByName": {"iterationId": "it-2"}, "content": {}},
+					{"updatedAt": "2018-06-05T10:00:00Z", "fieldValueByName": {"iterationId": "it-2"},
+						"content": `+gitHubTestOldGraphQLIssue+`},
+					{"updatedAt": "2018-06-12T10:00:00Z", "fieldValueByName": null,
+						"content": {"databaseId": 1012, "number": 12, "state": "OPEN",
+							"repository": {"nameWithOwner": "iReflect/reflect-app"}}}
+				]}}}}}`)
+		default:
+			// Only the issues 7 and 8 exist, the others resolve to null along with a NOT_FOUND error
+			if !strings.Contains(request.Query, "issue0: issue(number: 7)") ||
+				!strings.Contains(request.Query, "issue1: issue(number: 8)") ||
+				!strings.Contains(request.Query, "issue2: issue(number: 9)") {
+				t.Errorf("unexpected issues query %q", request.Query)
+			}
+			fmt.Fprint(w, `{"data": {"repository": {"issue0": `+gitHubTestGraphQLIssue+`,
+				"issue1": `+gitHubTestOldGraphQLIssue+`, "issue2": null}},
+				"errors": [{"type": "NOT_FOUND", "path": ["repository", "issue2"],
+					"message": "Could not resolve to an Issue with the number of 9."}]}`)
+		}
+	})
+
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token "+gitHubTestToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message": "Bad credentials"}`)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	return server
+}
+
+func newGitHubTestConnection(baseURL string, token string, config map[string]interface{}) tasktracker.Connection {
+	gitHubConfig := map[string]interface{}{
+		"credentials":         map[string]interface{}{"type": "apiToken", "apiToken": token},
+		"BaseURL":             baseURL + "/api/v3/",
+		"Owner":               "ireflect",
+		"Repository":          "reflect-app",
+		"EstimateLabelPrefix": "estimate:",
+		"FeatureTypes":        "Story",
+		"BugTypes":            "Bug",
+		"DoneStatus":          "Done",
+	}
+	for field, value := range config {
+		gitHubConfig[field] = value
+	}
+	return (&GitHubTaskProvider{}).New(gitHubConfig)
+}
+
+func TestGitHubGetTask(t *testing.T) {
+	server := newGitHubTestServer(t)
+	defer server.Close()
+
+	connection := newGitHubTestConnection(server.URL, gitHubTestToken, nil)
+	task, err := connection.GetTask("#7")
+	if err != nil {
+		t.Fatalf("Error in fetching the task - %s", err)
+	}
+	if task == nil {
+		t.Fatalf("Task should be found")
+	}
+	if task.Key != "7" || task.TrackerUniqueID != "1007" || task.Type != "Story" || task.Status != "Done" ||
+		task.Assignee != "jane" || task.Estimate == nil || *task.Estimate != 3 {
+		t.Fatalf("Unexpected task - %+v", task)
+	}
+
+	if task, err = connection.GetTask("42"); err != nil || task != nil {
+		t.Fatalf("Missing task should neither be found nor fail - %+v, %v", task, err)
+	}
+}
+
+func TestGitHubGetTaskList(t *testing.T) {
+	server := newGitHubTestServer(t)
+	defer server.Close()
+
+	connection := newGitHubTestConnection(server.URL, gitHubTestToken, nil)
+	tasks := connection.GetTaskList([]string{"7", "#8", "RFT-1", "9"})
+	if server.graphQLRequests != 1 {
+		t.Fatalf("The tasks should be fetched in a single request, got %d requests", server.graphQLRequests)
+	}
+	if len(tasks) != 2 || tasks[0].Key != "7" || tasks[1].Key != "8" {
+		t.Fatalf("Only the existing issues should be returned - %+v", tasks)
+	}
+	if tasks[0].Status != "Done" || tasks[1].Type != "Bug" || tasks[1].Status != "open" {
+		t.Fatalf("Unexpected tasks - %+v", tasks)
+	}
+
+	if tasks = connection.GetTaskList([]string{"RFT-1"}); tasks != nil || server.graphQLRequests != 1 {
+		t.Fatalf("No request should be made without any issue numbers - %+v", tasks)
+	}
+}
+
+func TestGitHubGetSprintTaskList(t *testing.T) {
+	server := newGitHubTestServer(t)
+	defer server.Close()
+
+	connection := newGitHubTestConnection(server.URL, gitHubTestToken, nil)
+	sprint := connection.GetSprint("5")
+	if sprint == nil {
+		t.Fatalf("Sprint should be found")
+	}
+	// The sprint starts the day after the due date of the previous milestone
+	if sprint.Name != "Sprint 5" || sprint.FromDate == nil || sprint.FromDate.Format(gitHubDateLayout) != "2018-06-02" ||
+		sprint.ToDate == nil || sprint.ToDate.Format(gitHubDateLayout) != "2018-06-15" {
+		t.Fatalf("Unexpected sprint - %+v", sprint)
+	}
+
+	tasks, err := connection.GetSprintTaskList(*sprint)
+	if err != nil {
+		t.Fatalf("GetSprintTaskList() returned error %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].Key != "7" || tasks[1].Key != "8" {
+		t.Fatalf("Both the pages should be fetched without the pull requests - %+v", tasks)
+	}
+
+	updatedSince := time.Date(2018, 6, 10, 0, 0, 0, 0, time.UTC)
+	sprint.UpdatedSince = &updatedSince
+	if tasks, err = connection.GetSprintTaskList(*sprint); err != nil || len(tasks) != 1 || tasks[0].Key != "8" {
+		t.Fatalf("Only the updated tasks should be fetched - %+v, %v", tasks, err)
+	}
+
+	if tasks, err = connection.GetSprintTaskList(serializers.Sprint{}); err != nil || tasks != nil {
+		t.Fatalf("No tasks should be returned for an empty sprint - %+v, %v", tasks, err)
+	}
+
+	// The failure of the fetch is reported instead of returning no tasks
+	unauthorized := newGitHubTestConnection(server.URL, "wrong-token", nil)
+	if tasks, err = unauthorized.GetSprintTaskList(*sprint); err == nil {
+		t.Fatalf("The fetch with invalid credentials should fail - %+v", tasks)
+	}
+}
+
+func TestGitHubGetIterationSprintTaskList(t *testing.T) {
+	server := newGitHubTestServer(t)
+	defer server.Close()
+
+	iterationConfig := map[string]interface{}{
+		"SprintSource":   GitHubSprintSourceIteration,
+		"ProjectNumber":  "3",
+		"IterationField": "Sprint",
+	}
+	connection := newGitHubTestConnection(server.URL, gitHubTestToken, iterationConfig)
+	sprint := connection.GetSprint("it-2")
+	if sprint == nil {
+		t.Fatalf("Sprint should be found")
+	}
+	if sprint.Name != "Sprint 2" || sprint.FromDate == nil || sprint.FromDate.Format(gitHubDateLayout) != "2018-06-04" ||
+		sprint.ToDate == nil || sprint.ToDate.Format(gitHubDateLayout) != "2018-06-15" {
+		t.Fatalf("Unexpected sprint - %+v", sprint)
+	}
+	if completed := connection.GetSprint("it-1"); completed == nil || completed.Name != "Sprint 1" {
+		t.Fatalf("Completed iteration should be found - %+v", completed)
+	}
+	if missing := connection.GetSprint("it-9"); missing != nil {
+		t.Fatalf("Missing iteration should not be found - %+v", missing)
+	}
+
+	tasks, err := connection.GetSprintTaskList(*sprint)
+	if err != nil {
+		t.Fatalf("GetSprintTaskList() returned error %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].Key != "7" || tasks[1].Key != "8" {
+		t.Fatalf("Only the issues of the repository in the iteration should be fetched - %+v", tasks)
+	}
+	if tasks[0].Status != "Done" || tasks[1].Status != "open" {
+		t.Fatalf("Unexpected tasks - %+v", tasks)
+	}
+
+	updatedSince := time.Date(2018, 6, 10, 0, 0, 0, 0, time.UTC)
+	sprint.UpdatedSince = &updatedSince
+	if tasks, err = connection.GetSprintTaskList(*sprint); err != nil || len(tasks) != 1 || tasks[0].Key != "7" {
+		t.Fatalf("Only the updated tasks should be fetched - %+v, %v", tasks, err)
+	}
+
+	iterationConfig["ProjectNumber"] = "4"
+	missingProject := newGitHubTestConnection(server.URL, gitHubTestToken, iterationConfig)
+	if tasks, err = missingProject.GetSprintTaskList(*sprint); err == nil {
+		t.Fatalf("The fetch from a missing project should fail - %+v", tasks)
+	}
+}
+
+func TestGitHubValidateConfig(t *testing.T) {
+	server := newGitHubTestServer(t)
+	defer server.Close()
+
+	if err := newGitHubTestConnection(server.URL, gitHubTestToken, nil).ValidateConfig(); err != nil {
+		t.Fatalf("Valid config should pass the validation - %s", err)
+	}
+
+	err := newGitHubTestConnection(server.URL, "wrong-token", nil).ValidateConfig()
+	diagnostics := tasktracker.GetConfigDiagnostics(err)
+	if len(diagnostics) != 1 || diagnostics[0].Code != tasktracker.AuthFailedDiagnostic {
+		t.Errorf("Invalid token should be diagnosed as an auth failure - %+v", diagnostics)
+	}
+
+	testCases := []struct {
+		name   string
+		config map[string]interface{}
+		code   string
+		field  string
+	}{
+		{"unknown sprint source", map[string]interface{}{"SprintSource": "board"}, tasktracker.InvalidConfigDiagnostic,
+			"SprintSource"},
+		{"missing project number", map[string]interface{}{"SprintSource": GitHubSprintSourceIteration},
+			tasktracker.InvalidConfigDiagnostic, "ProjectNumber"},
+		{"unknown project", map[string]interface{}{"SprintSource": GitHubSprintSourceIteration, "ProjectNumber": "4"},
+			tasktracker.UnknownProjectDiagnostic, "ProjectNumber"},
+	}
+	for _, testCase := range testCases {
+		err = newGitHubTestConnection(server.URL, gitHubTestToken, testCase.config).ValidateConfig()
+		diagnostics = tasktracker.GetConfigDiagnostics(err)
+		if len(diagnostics) != 1 || diagnostics[0].Code != testCase.code || diagnostics[0].Field != testCase.field {
+			t.Errorf("%s: unexpected diagnostics - %+v", testCase.name, diagnostics)
+		}
+	}
+
+	iterationConfig := map[string]interface{}{
+		"SprintSource":   GitHubSprintSourceIteration,
+		"ProjectNumber":  "3",
+		"IterationField": "Sprint",
+	}
+	if err = newGitHubTestConnection(server.URL, gitHubTestToken, iterationConfig).ValidateConfig(); err != nil {
+		t.Fatalf("Valid iteration config should pass the validation - %s", err)
+	}
+}
@@ -0,0 +1,487 @@
+package providers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	"github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// GitLabTaskProvider ...
+type GitLabTaskProvider struct {
+}
+
+// GitLabConnection ...
+type GitLabConnection struct {
+	config GitLabConfig
+	client *http.Client
+}
+
+// GitLabConfig ...
+type GitLabConfig struct {
+	Credentials  tasktracker.Credentials `json:"Credentials"`
+	BaseURL      string                  `json:"BaseURL"`
+	ProjectID    string                  `json:"ProjectID"`
+	SprintSource string                  `json:"SprintSource"`
+	FeatureTypes string                  `json:"FeatureTypes"`
+	TaskTypes    string                  `json:"TaskTypes"`
+	BugTypes     string                  `json:"BugTypes"`
+	DoneStatus   string                  `json:"DoneStatus"`
+}
+
+// GetBaseURL returns the base url of the GitLab instance, defaulting to gitlab.com
+func (config GitLabConfig) GetBaseURL() string {
+	if config.BaseURL == "" {
+		return gitLabDefaultBaseURL
+	}
+	// Just to make sure there are no trailing slashes in the base url, even if provided by the user.
+	return strings.Trim(config.BaseURL, "/")
+}
+
+// UsesIterations tells whether the sprints are backed by the GitLab iterations instead of the milestones
+func (config GitLabConfig) UsesIterations() bool {
+	return config.SprintSource == GitLabSprintSourceIteration
+}
+
+// TaskProviderGitLab ...
+const (
+	TaskProviderGitLab = "gitlab"
+)
+
+// GitLab sprint sources
+const (
+	GitLabSprintSourceMilestone = "milestone"
+	GitLabSprintSourceIteration = "iteration"
+)
+
+const gitLabDefaultBaseURL = "https://gitlab.com"
+
+// gitLabPageSize is the maximum page size supported by the GitLab REST API
+const gitLabPageSize = 100
+
+// gitLabDateLayout is the layout of the start/due dates of the milestones and iterations
+const gitLabDateLayout = "2006-01-02"
+
+type gitLabUser struct {
+	Username string `json:"username"`
+}
+
+type gitLabIssue struct {
+	ID          int64        `json:"id"`
+	IID         int          `json:"iid"`
+	ProjectID   int64        `json:"project_id"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	State       string       `json:"state"`
+	IssueType   string       `json:"issue_type"`
+	Labels      []string     `json:"labels"`
+	Assignees   []gitLabUser `json:"assignees"`
+	Weight      *float64     `json:"weight"`
+}
+
+// gitLabTimebox holds the fields common to the GitLab milestones and iterations
+type gitLabTimebox struct {
+	ID        int64  `json:"id"`
+	IID       int    `json:"iid"`
+	Title     string `json:"title"`
+	StartDate string `json:"start_date"`
+	DueDate   string `json:"due_date"`
+}
+
+func init() {
+	provider := &GitLabTaskProvider{}
+	tasktracker.RegisterTaskProvider(TaskProviderGitLab, provider)
+}
+
+// New ...
+func (p *GitLabTaskProvider) New(config interface{}) tasktracker.Connection {
+	var gitLabConfig GitLabConfig
+	gitLabConfig, err := getGitLabConfigObject(config)
+
+	if err != nil {
+		return nil
+	}
+
+	switch gitLabConfig.Credentials.Type {
+	case "apiToken":
+	default:
+		return nil
+	}
+	return &GitLabConnection{config: gitLabConfig, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// getGitLabConfigObject ...
+func getGitLabConfigObject(config interface{}) (GitLabConfig, error) {
+	var c GitLabConfig
+
+	switch config.(type) {
+	case []byte:
+		c = GitLabConfig{}
+		err := json.Unmarshal(config.([]byte), &c)
+		if err != nil {
+			return c, err
+		}
+	case map[string]interface{}:
+		c = GitLabConfig{}
+
+		jsonConfig, err := json.Marshal(config)
+		if err != nil {
+			return c, err
+		}
+
+		err = json.Unmarshal(jsonConfig, &c)
+		if err != nil {
+			return c, err
+		}
+	case GitLabConfig:
+		c = config.(GitLabConfig)
+	default:
+		return c, errors.New("invalid type")
+	}
+	return c, nil
+}
+
+// ConfigTemplate ...
+func (p *GitLabTaskProvider) ConfigTemplate() (configMap map[string]interface{}) {
+	configMap = map[string]interface{}{
+		"Type":               TaskProviderGitLab,
+		"DisplayTitle":       "GitLab",
+		"SupportedAuthTypes": []string{"apiToken"},
+		"Fields": []map[string]interface{}{
+			{
+				"FieldName":        "BaseURL",
+				"FieldDisplayName": "Base URL of the GitLab instance (Leave blank for gitlab.com). eg. 'https://gitlab.example.com'",
+				"Type":             "string",
+				"Required":         false,
+			},
+			{
+				"FieldName":        "ProjectID",
+				"FieldDisplayName": "ID or path of the project. eg. '42' or 'ireflect/reflect-app'",
+				"Type":             "string",
+				"Required":         true,
+			},
+			{
+				"FieldName":        "SprintSource",
+				"FieldDisplayName": "Sprints are backed by, 'milestone' (default) or 'iteration'",
+				"Type":             "string",
+				"Required":         false,
+				"Hint": "<i>Use the milestone IID or the iteration ID (as shown in the GitLab URLs) " +
+					"as the sprint ID. Estimates are read from the issue weight.</i>",
+			},
+		},
+	}
+	return configMap
+}
+
+// GetTaskUrl ...
+func (c *GitLabConnection) GetTaskUrl(ticketKey string) string {
+	projectPath := c.config.ProjectID
+	// The web UI needs the project path, fallback to the project ID based issue url otherwise
+	if _, err := strconv.Atoi(projectPath); err == nil {
+		return fmt.Sprintf("%v/projects/%v/issues/%v", c.config.GetBaseURL(), projectPath,
+			strings.TrimPrefix(ticketKey, "#"))
+	}
+	return fmt.Sprintf("%v/%v/-/issues/%v", c.config.GetBaseURL(), strings.Trim(projectPath, "/"),
+		strings.TrimPrefix(ticketKey, "#"))
+}
+
+// GetTaskList ...
+func (c *GitLabConnection) GetTaskList(ticketKeys []string) []serializers.Task {
+	var iids []string
+	for _, ticketKey := range ticketKeys {
+		// Since the issue IIDs are always number in GitLab, if a value is not, it can't be a GitLab issue
+		if iid, err := strconv.Atoi(strings.TrimPrefix(ticketKey, "#")); err == nil {
+			iids = append(iids, strconv.Itoa(iid))
+		}
+	}
+	if len(iids) == 0 {
+		return nil
+	}
+
+	query := url.Values{}
+	query["iids[]"] = iids
+	query.Set("scope", "all")
+
+	issues, err := c.listIssues(query)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+	return c.serializeTickets(issues)
+}
+
+// GetTask ...
+func (c *GitLabConnection) GetTask(ticketKey string) (*serializers.Task, error) {
+	iid, err := strconv.Atoi(strings.TrimPrefix(ticketKey, "#"))
+	if err != nil {
+		return nil, nil
+	}
+
+	var issue gitLabIssue
+	resp, err := c.get(c.projectPath(fmt.Sprintf("issues/%d", iid)), nil, &issue)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		utils.LogToSentry(fmt.Errorf("%s: %s", ticketKey, err))
+		return nil, err
+	}
+	return c.serializeTicket(issue), nil
+}
+
+// GetSprint ...
+func (c *GitLabConnection) GetSprint(sprintID string) *serializers.Sprint {
+	timebox, err := c.getTimebox(sprintID)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+	if timebox == nil {
+		return nil
+	}
+
+	return &serializers.Sprint{
+		ID:       sprintID,
+		BoardID:  "",
+		Name:     timebox.Title,
+		FromDate: parseGitLabDate(timebox.StartDate),
+		ToDate:   parseGitLabDate(timebox.DueDate),
+	}
+}
+
+// GetSprintTaskList ...
+func (c *GitLabConnection) GetSprintTaskList(sprint serializers.Sprint) ([]serializers.Task, error) {
+	if sprint.ID == "" {
+		return nil, nil
+	}
+
+	query := url.Values{}
+	query.Set("scope", "all")
+	if sprint.UpdatedSince != nil {
+		query.Set("updated_after", sprint.UpdatedSince.UTC().Format(time.RFC3339))
+	}
+	if c.config.UsesIterations() {
+		query.Set("iteration_id", sprint.ID)
+	} else {
+		// The issues can only be filtered by the milestone title
+		timebox, err := c.getTimebox(sprint.ID)
+		if err != nil {
+			utils.LogToSentry(err)
+			return nil, err
+		}
+		if timebox == nil {
+			return nil, fmt.Errorf("milestone %s was not found", sprint.ID)
+		}
+		query.Set("milestone", timebox.Title)
+	}
+
+	issues, err := c.listIssues(query)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+	return c.serializeTickets(issues), nil
+}
+
+// ValidateConfig validates if the provided API Token and Project are correct
+func (c *GitLabConnection) ValidateConfig() error {
+	configErr := &tasktracker.ConfigError{}
+	if c.config.ProjectID == "" {
+		configErr.Add(tasktracker.InvalidConfigDiagnostic, "ProjectID", "project is required")
+		return configErr
+	}
+	switch c.config.SprintSource {
+	case "", GitLabSprintSourceMilestone, GitLabSprintSourceIteration:
+	default:
+		configErr.Add(tasktracker.InvalidConfigDiagnostic, "SprintSource",
+			"sprint source should either be 'milestone' or 'iteration'")
+		return configErr
+	}
+	resp, err := c.get(c.projectPath(""), nil, nil)
+	if err != nil {
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		configErr.AddResponseDiagnostic(statusCode, err, tasktracker.UnknownProjectDiagnostic, "ProjectID")
+	}
+	return configErr.OrNil()
+}
+
+// getTimebox fetches the milestone (by IID) or the iteration (by ID) backing the given sprint
+func (c *GitLabConnection) getTimebox(sprintID string) (*gitLabTimebox, error) {
+	if _, err := strconv.Atoi(sprintID); err != nil {
+		return nil, err
+	}
+
+	var timeboxes []gitLabTimebox
+	query := url.Values{}
+	if c.config.UsesIterations() {
+		query.Set("include_ancestors", "true")
+		if err := c.list(c.projectPath("iterations"), query, func(page []byte) error {
+			var pageTimeboxes []gitLabTimebox
+			err := json.Unmarshal(page, &pageTimeboxes)
+			timeboxes = append(timeboxes, pageTimeboxes...)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+		for _, timebox := range timeboxes {
+			if strconv.FormatInt(timebox.ID, 10) == sprintID {
+				return &timebox, nil
+			}
+		}
+		return nil, nil
+	}
+
+	query.Set("iids[]", sprintID)
+	if _, err := c.get(c.projectPath("milestones"), query, &timeboxes); err != nil {
+		return nil, err
+	}
+	if len(timeboxes) == 0 {
+		return nil, nil
+	}
+	return &timeboxes[0], nil
+}
+
+// projectPath returns the API path of the given resource in the configured project
+func (c *GitLabConnection) projectPath(resource string) string {
+	projectPath := "projects/" + url.PathEscape(strings.Trim(c.config.ProjectID, "/"))
+	if resource == "" {
+		return projectPath
+	}
+	return projectPath + "/" + resource
+}
+
+// listIssues fetches all the pages of the issues matching the given query
+func (c *GitLabConnection) listIssues(query url.Values) ([]gitLabIssue, error) {
+	var issues []gitLabIssue
+	err := c.list(c.projectPath("issues"), query, func(page []byte) error {
+		var pageIssues []gitLabIssue
+		err := json.Unmarshal(page, &pageIssues)
+		issues = append(issues, pageIssues...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// list calls the paginated GitLab API for the given path and passes the raw body of each page to the handler
+func (c *GitLabConnection) list(path string, query url.Values, handlePage func(page []byte) error) error {
+	query.Set("per_page", strconv.Itoa(gitLabPageSize))
+	page := "1"
+	for page != "" {
+		query.Set("page", page)
+		var body json.RawMessage
+		resp, err := c.get(path, query, &body)
+		if err != nil {
+			return err
+		}
+		if err = handlePage(body); err != nil {
+			return err
+		}
+		page = resp.Header.Get("X-Next-Page")
+	}
+	return nil
+}
+
+// get calls the GitLab API for the given path and decodes the response body into v (if provided)
+func (c *GitLabConnection) get(path string, query url.Values, v interface{}) (*http.Response, error) {
+	requestURL := fmt.Sprintf("%s/api/v4/%s", c.config.GetBaseURL(), path)
+	if len(query) != 0 {
+		requestURL = requestURL + "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest("GET", requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.config.Credentials.APIToken)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var errorResponse struct {
+			Message interface{} `json:"message"`
+			Error   string      `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&errorResponse)
+		if errorResponse.Message == nil {
+			errorResponse.Message = errorResponse.Error
+		}
+		return resp, fmt.Errorf("gitlab: %v (%d)", errorResponse.Message, resp.StatusCode)
+	}
+
+	if v != nil {
+		if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
+
+func parseGitLabDate(date string) *time.Time {
+	if date == "" {
+		return nil
+	}
+	parsedDate, err := time.Parse(gitLabDateLayout, date)
+	if err != nil {
+		return nil
+	}
+	return &parsedDate
+}
+
+func (c *GitLabConnection) serializeTickets(issues []gitLabIssue) []serializers.Task {
+	var tickets []serializers.Task
+	for _, issue := range issues {
+		tickets = append(tickets, *c.serializeTicket(issue))
+	}
+	return tickets
+}
+
+func (c *GitLabConnection) serializeTicket(issue gitLabIssue) *serializers.Task {
+	task := &serializers.Task{
+		Key:             strconv.Itoa(issue.IID),
+		TrackerUniqueID: strconv.FormatInt(issue.ID, 10),
+		ProjectID:       strconv.FormatInt(issue.ProjectID, 10),
+		Summary:         issue.Title,
+		Description:     issue.Description,
+		Type:            issue.IssueType,
+		Status:          issue.State,
+		Estimate:        issue.Weight,
+		Priority:        "",
+	}
+
+	// Labels take precedence over the issue type and state, since GitLab boards are usually driven by labels
+	if issueType := getMappedLabel(issue.Labels, c.config.FeatureTypes, c.config.TaskTypes, c.config.BugTypes); issueType != "" {
+		task.Type = issueType
+	}
+	if task.Type == "" {
+		task.Type = "issue"
+	}
+	if status := getMappedLabel(issue.Labels, c.config.DoneStatus); status != "" {
+		task.Status = status
+	}
+
+	var assignees []string
+	for _, assignee := range issue.Assignees {
+		assignees = append(assignees, assignee.Username)
+	}
+	task.Assignee = strings.Join(assignees, ", ")
+
+	return task
+}
@@ -0,0 +1,155 @@
+package providers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	"github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+)
+
+const gitLabTestToken = "secret-token"
+
+func newGitLabTestServer(t *testing.T) *httptest.Server {
+	// The project path is URL encoded, so route on the escaped path instead of using a http.ServeMux
+	handlers := map[string]http.HandlerFunc{}
+	handlers["/api/v4/projects/ireflect%2Freflect-app"] = func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 42, "path_with_namespace": "ireflect/reflect-app"}`)
+	}
+	handlers["/api/v4/projects/ireflect%2Freflect-app/issues/7"] = func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1007, "iid": 7, "project_id": 42, "title": "Login page", "state": "closed",
+			"issue_type": "issue", "labels": ["Story", "Done"], "assignees": [{"username": "jane"}], "weight": 3}`)
+	}
+	handlers["/api/v4/projects/ireflect%2Freflect-app/milestones"] = func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("iids[]") != "5" {
+			fmt.Fprint(w, `[]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id": 905, "iid": 5, "title": "Sprint 5", "start_date": "2018-06-04", "due_date": "2018-06-15"}]`)
+	}
+	handlers["/api/v4/projects/ireflect%2Freflect-app/issues"] = func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("milestone") != "Sprint 5" {
+			t.Errorf("unexpected milestone filter %q", r.URL.Query().Get("milestone"))
+		}
+		// Serve the issues in two pages to exercise the pagination, only the second issue is updated recently
+		if r.URL.Query().Get("page") == "1" && r.URL.Query().Get("updated_after") == "" {
+			w.Header().Set("X-Next-Page", "2")
+			fmt.Fprint(w, `[{"id": 1007, "iid": 7, "project_id": 42, "title": "Login page", "state": "closed",
+				"labels": [], "weight": 3}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id": 1008, "iid": 8, "project_id": 42, "title": "Broken link", "state": "opened",
+			"labels": ["bug"], "weight": null}]`)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != gitLabTestToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message": "401 Unauthorized"}`)
+			return
+		}
+		handler, ok := handlers[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "404 Not Found"}`)
+			return
+		}
+		handler(w, r)
+	}))
+}
+
+func newGitLabTestConnection(baseURL string, token string) tasktracker.Connection {
+	return (&GitLabTaskProvider{}).New(map[string]interface{}{
+		"credentials":  map[string]interface{}{"type": "apiToken", "apiToken": token},
+		"BaseURL":      baseURL + "/",
+		"ProjectID":    "ireflect/reflect-app",
+		"FeatureTypes": "Story",
+		"BugTypes":     "Bug",
+		"DoneStatus":   "Done",
+	})
+}
+
+func TestGitLabGetTask(t *testing.T) {
+	server := newGitLabTestServer(t)
+	defer server.Close()
+
+	connection := newGitLabTestConnection(server.URL, gitLabTestToken)
+	task, err := connection.GetTask("#7")
+	if err != nil {
+		t.Fatalf("Error in fetching the task - %s", err)
+	}
+	if task == nil {
+		t.Fatalf("Task should be found")
+	}
+	if task.Key != "7" || task.TrackerUniqueID != "1007" || task.Type != "Story" || task.Status != "Done" ||
+		task.Assignee != "jane" || task.Estimate == nil || *task.Estimate != 3 {
+		t.Fatalf("Unexpected task - %+v", task)
+	}
+
+	if task, err = connection.GetTask("42"); err != nil || task != nil {
+		t.Fatalf("Missing task should neither be found nor fail - %+v, %v", task, err)
+	}
+}
+
+func TestGitLabGetSprintTaskList(t *testing.T) {
+	server := newGitLabTestServer(t)
+	defer server.Close()
+
+	connection := newGitLabTestConnection(server.URL, gitLabTestToken)
+	sprint := connection.GetSprint("5")
+	if sprint == nil {
+		t.Fatalf("Sprint should be found")
+	}
+	if sprint.Name != "Sprint 5" || sprint.FromDate == nil || sprint.FromDate.Format(gitLabDateLayout) != "2018-06-04" ||
+		sprint.ToDate == nil || sprint.ToDate.Format(gitLabDateLayout) != "2018-06-15" {
+		t.Fatalf("Unexpected sprint - %+v", sprint)
+	}
+
+	tasks, err := connection.GetSprintTaskList(*sprint)
+	if err != nil {
+		t.Fatalf("GetSprintTaskList() returned error %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("Both the pages should be fetched - %+v", tasks)
+	}
+	if tasks[1].Type != "bug" || tasks[1].Status != "opened" || tasks[1].Estimate != nil {
+		t.Fatalf("Unexpected task - %+v", tasks[1])
+	}
+
+	updatedSince := time.Date(2018, 6, 10, 0, 0, 0, 0, time.UTC)
+	sprint.UpdatedSince = &updatedSince
+	if tasks, err = connection.GetSprintTaskList(*sprint); err != nil || len(tasks) != 1 || tasks[0].Key != "8" {
+		t.Fatalf("Only the updated tasks should be fetched - %+v, %v", tasks, err)
+	}
+
+	if tasks, err = connection.GetSprintTaskList(serializers.Sprint{}); err != nil || tasks != nil {
+		t.Fatalf("No tasks should be returned for an empty sprint - %+v, %v", tasks, err)
+	}
+
+	// The failure of the fetch is reported instead of returning no tasks
+	unauthorized := newGitLabTestConnection(server.URL, "wrong-token")
+	if tasks, err = unauthorized.GetSprintTaskList(*sprint); err == nil {
+		t.Fatalf("The fetch with invalid credentials should fail - %+v", tasks)
+	}
+}
+
+func TestGitLabValidateConfig(t *testing.T) {
+	server := newGitLabTestServer(t)
+	defer server.Close()
+
+	if err := newGitLabTestConnection(server.URL, gitLabTestToken).ValidateConfig(); err != nil {
+		t.Fatalf("Valid config should pass the validation - %s", err)
+	}
+
+	err := newGitLabTestConnection(server.URL, "wrong-token").ValidateConfig()
+	if err == nil {
+		t.Fatalf("Invalid token should fail the validation")
+	}
+	diagnostics := tasktracker.GetConfigDiagnostics(err)
+	if len(diagnostics) != 1 || diagnostics[0].Code != tasktracker.AuthFailedDiagnostic {
+		t.Errorf("Invalid token should be diagnosed as an auth failure - %+v", diagnostics)
+	}
+}
@@ -2,8 +2,11 @@ package providers
 
 import (
 	"fmt"
+	"math"
+	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/andygrunwald/go-jira"
 
@@ -25,6 +28,9 @@ const FromDateJQLKeyword = "${fromDate}"
 // ToDateJQLKeyword ...
 const ToDateJQLKeyword = "${toDate}"
 
+// jiraChangelogTimeLayout is the layout of the time of the changelog histories of the JIRA tickets
+const jiraChangelogTimeLayout = "2006-01-02T15:04:05.000-0700"
+
 // JIRATaskProvider ...
 type JIRATaskProvider struct {
 }
@@ -42,6 +48,7 @@ type JIRAConfig struct {
 	BoardIds      string                  `json:"BoardIds"`
 	JQL           string                  `json:"JQL"`
 	EstimateField string                  `json:"EstimateField"`
+	EpicField     string                  `json:"EpicField"`
 }
 
 func (config JIRAConfig) GetBaseURL() string {
@@ -125,12 +132,14 @@ func (p *JIRATaskProvider) ConfigTemplate() (configMap map[string]interface{}) {
 				"FieldDisplayName": "Base URL of the project. eg. 'https://ireflect.atlassian.net'",
 				"Type":             "string",
 				"Required":         true,
+				"Format":           "uri",
 			},
 			{
 				"FieldName":        "BoardIds",
 				"FieldDisplayName": "Board IDs (Comma Separated)",
 				"Type":             "string",
 				"Required":         true,
+				"Pattern":          `^\s*\d+(\s*,\s*\d+)*\s*$`,
 			},
 			{
 				"FieldName": "JQL",
@@ -149,6 +158,12 @@ func (p *JIRATaskProvider) ConfigTemplate() (configMap map[string]interface{}) {
 				"Type":             "string",
 				"Required":         false,
 			},
+			{
+				"FieldName":        "EpicField",
+				"FieldDisplayName": "Epic Link Field, eg. 'customfield_10008' (Leave blank to use the Epic of the issue)",
+				"Type":             "string",
+				"Required":         false,
+			},
 		},
 	}
 	return configMap
@@ -207,26 +222,112 @@ func (c *JIRAConnection) GetSprint(sprintID string) *serializers.Sprint {
 }
 
 // GetSprintTaskList ...
-func (c *JIRAConnection) GetSprintTaskList(sprint serializers.Sprint) []serializers.Task {
+func (c *JIRAConnection) GetSprintTaskList(sprint serializers.Sprint) ([]serializers.Task, error) {
 	var extraJQL string
 	if sprint.ID != "" {
 		extraJQL = fmt.Sprintf("Sprint in (%s)", sprint.ID)
 	}
-	tickets, _ := c.getTicketsFromJQL(extraJQL, false, &sprint)
-	return tickets
+	// The relative time is used since the absolute times are interpreted in the time zone of the JIRA user.
+	// The update filter alone would match all the issues of the instance, so it is only added along with a filter.
+	if sprint.UpdatedSince != nil && (extraJQL != "" || c.config.JQL != "") {
+		updatedJQL := fmt.Sprintf("updated >= -%dm", int(math.Ceil(time.Since(*sprint.UpdatedSince).Minutes())))
+		if extraJQL != "" {
+			extraJQL = updatedJQL + " AND " + extraJQL
+		} else {
+			extraJQL = updatedJQL
+		}
+	}
+	return c.getTicketsFromJQL(extraJQL, false, &sprint)
 }
 
-// ValidateConfig ...
+// ValidateConfig validates the credentials, the boards, the JQL and the custom fields of the config, the problems
+// found are returned as a config error
 func (c *JIRAConnection) ValidateConfig() error {
-	searchOptions := jira.SearchOptions{MaxResults: 1}
+	configErr := &tasktracker.ConfigError{}
+
+	// The other checks are of no use with invalid credentials
+	req, err := c.client.NewRequest("GET", "rest/api/2/myself", nil)
+	if err != nil {
+		configErr.Add(tasktracker.InvalidConfigDiagnostic, "BaseURL", err.Error())
+		return configErr
+	}
+	if resp, err := c.client.Do(req, nil); err != nil {
+		configErr.AddResponseDiagnostic(
+			getJIRAStatusCode(resp), jira.NewJiraError(resp, err), tasktracker.AuthFailedDiagnostic, "credentials")
+		return configErr
+	}
+
+	for _, boardID := range strings.Split(c.config.BoardIds, ",") {
+		boardID = strings.TrimSpace(boardID)
+		if boardID == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(boardID); err != nil {
+			configErr.Add(tasktracker.UnknownBoardDiagnostic, "BoardIds", fmt.Sprintf("board id %s is not a number", boardID))
+			continue
+		}
+		req, err := c.client.NewRequest("GET", fmt.Sprintf("rest/agile/1.0/board/%s", boardID), nil)
+		if err != nil {
+			return err
+		}
+		if resp, err := c.client.Do(req, nil); err != nil {
+			if getJIRAStatusCode(resp) == http.StatusNotFound {
+				configErr.Add(tasktracker.UnknownBoardDiagnostic, "BoardIds", fmt.Sprintf("board %s was not found", boardID))
+			} else {
+				configErr.AddResponseDiagnostic(
+					getJIRAStatusCode(resp), jira.NewJiraError(resp, err), tasktracker.UnknownBoardDiagnostic, "BoardIds")
+			}
+		}
+	}
+
+	if c.config.JQL != "" {
+		// The JQL parameters are replaced with sample values, since the JQL is validated without a sprint
+		now := time.Now()
+		jql := c.sanitizeJQL(&serializers.Sprint{ID: "1", FromDate: &now, ToDate: &now})
+		searchOptions := jira.SearchOptions{MaxResults: 1, ValidateQuery: "warn"}
+		if _, resp, err := c.client.Issue.Search(jql, &searchOptions); err != nil {
+			if getJIRAStatusCode(resp) == http.StatusBadRequest {
+				utils.LogToSentry(jira.NewJiraError(resp, err))
+				configErr.Add(tasktracker.InvalidJQLDiagnostic, "JQL", "the JQL is invalid")
+			} else {
+				configErr.AddResponseDiagnostic(getJIRAStatusCode(resp), err, tasktracker.InvalidJQLDiagnostic, "JQL")
+			}
+		}
+	}
 
-	_, _, err := c.client.Issue.Search("", &searchOptions)
-	return err
+	if c.config.EstimateField != "" || c.config.EpicField != "" {
+		fields, resp, err := c.client.Field.GetList()
+		if err != nil {
+			configErr.AddResponseDiagnostic(getJIRAStatusCode(resp), err, tasktracker.ConnectionFailedDiagnostic, "")
+			return configErr.OrNil()
+		}
+		fieldIDs := make(map[string]bool)
+		for _, field := range fields {
+			fieldIDs[field.ID] = true
+		}
+		if c.config.EstimateField != "" && !fieldIDs[c.config.EstimateField] {
+			configErr.Add(tasktracker.MissingEstimateFieldDiagnostic, "EstimateField",
+				fmt.Sprintf("field %s was not found", c.config.EstimateField))
+		}
+		if c.config.EpicField != "" && !fieldIDs[c.config.EpicField] {
+			configErr.Add(tasktracker.MissingEpicFieldDiagnostic, "EpicField",
+				fmt.Sprintf("field %s was not found", c.config.EpicField))
+		}
+	}
+	return configErr.OrNil()
+}
+
+// getJIRAStatusCode returns the status code of the JIRA response, 0 if there was no response
+func getJIRAStatusCode(resp *jira.Response) int {
+	if resp == nil || resp.Response == nil {
+		return 0
+	}
+	return resp.StatusCode
 }
 
 func (c *JIRAConnection) getTicketsFromJQL(extraJQL string, skipBaseJQL bool, sprint *serializers.Sprint) (ticketsSerialized []serializers.Task, err error) {
 	// Need to pass in validateQuery=warn like this until jira-go supports this natively
-	searchOptions := jira.SearchOptions{MaxResults: 50000, ValidateQuery: "warn"}
+	searchOptions := jira.SearchOptions{MaxResults: 50000, ValidateQuery: "warn", Expand: "changelog"}
 
 	jql := ""
 	if !skipBaseJQL && c.config.JQL != "" {
@@ -245,8 +346,9 @@ func (c *JIRAConnection) getTicketsFromJQL(extraJQL string, skipBaseJQL bool, sp
 	// ToDo: Use pagination
 	tickets, res, err := c.client.Issue.Search(jql, &searchOptions)
 	if err != nil {
-		jiraErr, _ := ioutil.ReadAll(res.Response.Body)
-		utils.LogToSentry(errors.New(string(jiraErr)))
+		// The error is read from the response, which is missing if the request itself failed, eg. on a timeout
+		err = jira.NewJiraError(res, err)
+		utils.LogToSentry(err)
 		return nil, err
 	}
 
@@ -255,7 +357,7 @@ func (c *JIRAConnection) getTicketsFromJQL(extraJQL string, skipBaseJQL bool, sp
 
 func (c *JIRAConnection) getTicket(ticketKey string) (ticketSerialized *serializers.Task, err error) {
 
-	ticket, resp, err := c.client.Issue.Get(ticketKey, nil)
+	ticket, resp, err := c.client.Issue.Get(ticketKey, &jira.GetQueryOptions{Expand: "changelog"})
 	if err != nil {
 
 		if resp.StatusCode == 404 {
@@ -324,10 +426,50 @@ func (c *JIRAConnection) serializeTicket(ticket jira.Issue) *serializers.Task {
 	if ticket.Fields.Priority != nil {
 		serializedTask.Priority = ticket.Fields.Priority.Name
 	}
+	if createdAt := time.Time(ticket.Fields.Created); !createdAt.IsZero() {
+		serializedTask.CreatedAt = &createdAt
+	}
+	serializedTask.StatusChanges = c.serializeStatusChanges(ticket.Changelog)
+
+	if ticket.Fields.Parent != nil {
+		serializedTask.ParentKey = ticket.Fields.Parent.Key
+	}
+	if ticket.Fields.Epic != nil {
+		serializedTask.EpicKey = ticket.Fields.Epic.Key
+		serializedTask.EpicName = ticket.Fields.Epic.Name
+	} else if c.config.EpicField != "" {
+		if epicKey, ok := ticket.Fields.Unknowns[c.config.EpicField].(string); ok {
+			serializedTask.EpicKey = epicKey
+		}
+	}
 
 	return &serializedTask
 }
 
+// serializeStatusChanges returns the status changes in the changelog of a ticket
+func (c *JIRAConnection) serializeStatusChanges(changelog *jira.Changelog) (statusChanges []serializers.StatusChange) {
+	if changelog == nil {
+		return nil
+	}
+	for _, history := range changelog.Histories {
+		changedAt, err := time.Parse(jiraChangelogTimeLayout, history.Created)
+		if err != nil {
+			continue
+		}
+		for _, item := range history.Items {
+			if item.Field != "status" {
+				continue
+			}
+			statusChanges = append(statusChanges, serializers.StatusChange{
+				FromStatus: item.FromString,
+				ToStatus:   item.ToString,
+				ChangedAt:  changedAt,
+			})
+		}
+	}
+	return statusChanges
+}
+
 // sanitizeJQL replaces the parameters in the JQL with their respective values
 func (c *JIRAConnection) sanitizeJQL(sprint *serializers.Sprint) string {
 	if sprint == nil {
@@ -0,0 +1,32 @@
+package providers
+
+import (
+	"strings"
+
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// getMappedLabel returns the first label which is present in any of the given comma separated mappings,
+// the mappings are checked in the given order. Used by the providers which expose types/statuses as labels.
+func getMappedLabel(labels []string, mappings ...string) string {
+	for _, mapping := range mappings {
+		mappedValues := splitMappingValues(mapping)
+		for _, label := range labels {
+			if utils.StringInSlice(strings.ToLower(strings.TrimSpace(label)), mappedValues) {
+				return label
+			}
+		}
+	}
+	return ""
+}
+
+// splitMappingValues splits a comma separated mapping value into a list of lower case values
+func splitMappingValues(mapping string) []string {
+	var values []string
+	for _, value := range strings.Split(strings.ToLower(mapping), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -8,8 +8,10 @@ import (
 	"github.com/iReflect/reflect-app/apps/tasktracker"
 	"github.com/iReflect/reflect-app/apps/tasktracker/serializers"
 	"github.com/iReflect/reflect-app/libs/utils"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // PivotalTaskProvider ...
@@ -31,6 +33,32 @@ type PivotalConfig struct {
 // TaskProviderPivotal ...
 const TaskProviderPivotal = "pivotal"
 
+// pivotalActivityPageSize is the number of the activities fetched per request of the activity of a story
+const pivotalActivityPageSize = 100
+
+// pivotalEpic is an epic of a project
+type pivotalEpic struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Label struct {
+		ID int `json:"id"`
+	} `json:"label"`
+}
+
+// pivotalActivity is an activity of a story, only the changes of the state of the story are read
+type pivotalActivity struct {
+	OccurredAt time.Time `json:"occurred_at"`
+	Changes    []struct {
+		Kind           string `json:"kind"`
+		OriginalValues struct {
+			CurrentState string `json:"current_state"`
+		} `json:"original_values"`
+		NewValues struct {
+			CurrentState string `json:"current_state"`
+		} `json:"new_values"`
+	} `json:"changes"`
+}
+
 func init() {
 	provider := &PivotalTaskProvider{}
 	tasktracker.RegisterTaskProvider(TaskProviderPivotal, provider)
@@ -140,18 +168,39 @@ func (c *PivotalConnection) getUserIDNameMap() map[int]string {
 	return userIDNameMap
 }
 
+// getLabelEpicMap returns the epics of the project mapped by the ids of their labels, a story belongs to an epic if it
+// has the label of the epic
+func (c *PivotalConnection) getLabelEpicMap(projectID int) map[int]pivotalEpic {
+	req, err := c.client.NewRequest("GET", fmt.Sprintf("projects/%d/epics", projectID), nil)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+	var epics []pivotalEpic
+	if _, err = c.client.Do(req, &epics); err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+
+	labelEpicMap := make(map[int]pivotalEpic)
+	for _, epic := range epics {
+		labelEpicMap[epic.Label.ID] = epic
+	}
+	return labelEpicMap
+}
+
 // serializeTickets ...
-func (c *PivotalConnection) serializeTickets(tickets []*pivotal.Story, userIDNameMap map[int]string) (ticketsSerialized []serializers.Task) {
+func (c *PivotalConnection) serializeTickets(tickets []*pivotal.Story, userIDNameMap map[int]string, labelEpicMap map[int]pivotalEpic) (ticketsSerialized []serializers.Task) {
 
 	for _, ticket := range tickets {
-		ticketsSerialized = append(ticketsSerialized, *c.serializeTicket(ticket, userIDNameMap))
+		ticketsSerialized = append(ticketsSerialized, *c.serializeTicket(ticket, userIDNameMap, labelEpicMap))
 	}
 
 	return ticketsSerialized
 }
 
 // serializeTicket ...
-func (c *PivotalConnection) serializeTicket(ticket *pivotal.Story, userIDNameMap map[int]string) *serializers.Task {
+func (c *PivotalConnection) serializeTicket(ticket *pivotal.Story, userIDNameMap map[int]string, labelEpicMap map[int]pivotalEpic) *serializers.Task {
 	ticketID := strconv.Itoa(ticket.Id)
 	task := &serializers.Task{
 		Key:             ticketID,
@@ -175,9 +224,91 @@ func (c *PivotalConnection) serializeTicket(ticket *pivotal.Story, userIDNameMap
 		}
 		task.Assignee = strings.Join(owners, ", ")
 	}
+	task.CreatedAt = ticket.CreatedAt
+
+	for _, labelID := range ticket.LabelIds {
+		if epic, isPresent := labelEpicMap[labelID]; isPresent {
+			task.EpicKey = strconv.Itoa(epic.ID)
+			task.EpicName = epic.Name
+			break
+		}
+	}
 	return task
 }
 
+// getStatusChanges returns the changes of the state of the story from its activity, in the chronological order. Only
+// the activity after the given time is fetched if the time is given, the earlier changes being already recorded.
+func (c *PivotalConnection) getStatusChanges(
+	projectID int,
+	storyID int,
+	occurredAfter *time.Time) ([]serializers.StatusChange, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(pivotalActivityPageSize))
+	if occurredAfter != nil {
+		query.Set("occurred_after", occurredAfter.UTC().Format(time.RFC3339))
+	}
+
+	var activities []pivotalActivity
+	for offset := 0; ; offset += pivotalActivityPageSize {
+		query.Set("offset", strconv.Itoa(offset))
+		req, err := c.client.NewRequest(
+			"GET", fmt.Sprintf("projects/%d/stories/%d/activity?%s", projectID, storyID, query.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+		var page []pivotalActivity
+		resp, err := c.client.Do(req, &page)
+		if err != nil {
+			return nil, err
+		}
+		activities = append(activities, page...)
+
+		// The total is given in the pagination headers, the last page is the one short of the page size otherwise
+		if len(page) < pivotalActivityPageSize {
+			break
+		}
+		if resp != nil {
+			total, err := strconv.Atoi(resp.Header.Get("X-Tracker-Pagination-Total"))
+			if err == nil && offset+len(page) >= total {
+				break
+			}
+		}
+	}
+
+	var statusChanges []serializers.StatusChange
+	// The activities are listed latest first
+	for index := len(activities) - 1; index >= 0; index-- {
+		for _, change := range activities[index].Changes {
+			if change.Kind != "story" || change.NewValues.CurrentState == "" {
+				continue
+			}
+			statusChanges = append(statusChanges, serializers.StatusChange{
+				FromStatus: change.OriginalValues.CurrentState,
+				ToStatus:   change.NewValues.CurrentState,
+				ChangedAt:  activities[index].OccurredAt,
+			})
+		}
+	}
+	return statusChanges, nil
+}
+
+// setStatusChanges sets the status changes of the tasks from their activity after the given time, if any, the tasks
+// whose activity can not be fetched are left without any status changes
+func (c *PivotalConnection) setStatusChanges(projectID int, tasks []serializers.Task, occurredAfter *time.Time) {
+	for index := range tasks {
+		storyID, err := strconv.Atoi(tasks[index].TrackerUniqueID)
+		if err != nil {
+			continue
+		}
+		statusChanges, err := c.getStatusChanges(projectID, storyID, occurredAfter)
+		if err != nil {
+			utils.LogToSentry(err)
+			continue
+		}
+		tasks[index].StatusChanges = statusChanges
+	}
+}
+
 // GetTaskUrl ...
 func (c *PivotalConnection) GetTaskUrl(ticketKey string) string {
 	return fmt.Sprintf("https://www.pivotaltracker.com/story/show/%v", ticketKey)
@@ -185,24 +316,38 @@ func (c *PivotalConnection) GetTaskUrl(ticketKey string) string {
 
 // GetTaskList ...
 func (c *PivotalConnection) GetTaskList(ticketKeys []string) []serializers.Task {
-	if len(ticketKeys) == 0 {
+	projectID, err := strconv.Atoi(c.config.ProjectID)
+	if err != nil {
 		return nil
 	}
 
-	filterQuery := fmt.Sprintf("id:%s", strings.Join(ticketKeys, ","))
-
-	projectID, err := strconv.Atoi(c.config.ProjectID)
+	tasks, err := c.getTaskList(projectID, ticketKeys, nil)
 	if err != nil {
+		utils.LogToSentry(err)
 		return nil
 	}
+	return tasks
+}
+
+// getTaskList fetches the given stories of the project, along with their activity after the given time, if any
+func (c *PivotalConnection) getTaskList(
+	projectID int,
+	ticketKeys []string,
+	activityAfter *time.Time) ([]serializers.Task, error) {
+	if len(ticketKeys) == 0 {
+		return nil, nil
+	}
+
+	filterQuery := fmt.Sprintf("id:%s", strings.Join(ticketKeys, ","))
 
 	stories, err := c.client.Stories.List(projectID, filterQuery)
 	if err != nil {
-		utils.LogToSentry(err)
-		return nil
+		return nil, err
 	}
 
-	return c.serializeTickets(stories, c.getUserIDNameMap())
+	tasks := c.serializeTickets(stories, c.getUserIDNameMap(), c.getLabelEpicMap(projectID))
+	c.setStatusChanges(projectID, tasks, activityAfter)
+	return tasks, nil
 }
 
 // GetTask ...
@@ -224,7 +369,13 @@ func (c *PivotalConnection) GetTask(ticketKey string) (*serializers.Task, error)
 		return nil, err
 	}
 
-	return c.serializeTicket(story, c.getUserIDNameMap()), nil
+	task := c.serializeTicket(story, c.getUserIDNameMap(), c.getLabelEpicMap(projectID))
+	statusChanges, err := c.getStatusChanges(projectID, ticketID, nil)
+	if err != nil {
+		utils.LogToSentry(err)
+	}
+	task.StatusChanges = statusChanges
+	return task, nil
 }
 
 // GetSprint ...
@@ -253,36 +404,57 @@ func (c *PivotalConnection) GetSprint(sprintID string) *serializers.Sprint {
 }
 
 // GetSprintTaskList ...
-func (c *PivotalConnection) GetSprintTaskList(sprint serializers.Sprint) []serializers.Task {
+func (c *PivotalConnection) GetSprintTaskList(sprint serializers.Sprint) ([]serializers.Task, error) {
+	if sprint.ID == "" {
+		return nil, nil
+	}
 	iterationNumber, err := strconv.Atoi(sprint.ID)
 	if err != nil {
-		utils.LogToSentry(err)
-		return nil
+		return nil, fmt.Errorf("iteration number %s is not a number", sprint.ID)
 	}
 	projectID, err := strconv.Atoi(c.config.ProjectID)
 	if err != nil {
-		return nil
+		return nil, errors.New("project id is not a number")
 	}
 	iteration, _, err := c.client.Iterations.Get(projectID, iterationNumber)
 	if err != nil {
 		utils.LogToSentry(err)
-		return nil
+		return nil, err
 	}
 
+	// Only the stories updated since the given time are fetched, along with their activity since then
 	var storyIDs []string
 	for _, story := range iteration.Stories {
+		if sprint.UpdatedSince != nil && story.UpdatedAt != nil && story.UpdatedAt.Before(*sprint.UpdatedSince) {
+			continue
+		}
 		storyIDs = append(storyIDs, strconv.Itoa(story.Id))
 	}
 
-	return c.GetTaskList(storyIDs)
+	tasks, err := c.getTaskList(projectID, storyIDs, sprint.UpdatedSince)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+	return tasks, nil
 }
 
-// ValidateConfig validates if the provided API Token and ProjectID are correct
+// ValidateConfig validates if the provided API Token and ProjectID are correct, the problems found are returned as a
+// config error
 func (c *PivotalConnection) ValidateConfig() error {
+	configErr := &tasktracker.ConfigError{}
 	projectID, err := strconv.Atoi(c.config.ProjectID)
 	if err != nil {
-		return err
+		configErr.Add(tasktracker.UnknownProjectDiagnostic, "ProjectID", "project id is not a number")
+		return configErr
+	}
+	_, resp, err := c.client.Projects.Get(projectID)
+	if err != nil {
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		configErr.AddResponseDiagnostic(statusCode, err, tasktracker.UnknownProjectDiagnostic, "ProjectID")
 	}
-	_, _, err = c.client.Projects.Get(projectID)
-	return err
+	return configErr.OrNil()
 }
@@ -0,0 +1,257 @@
+package tasktracker
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+)
+
+type fakeTaskProvider struct{}
+
+// fakeConnection has the sprints of the "sprints" of its config, which map the sprint IDs to their start days
+type fakeConnection struct {
+	sprints map[string]interface{}
+}
+
+func (fakeTaskProvider) New(config interface{}) Connection {
+	data, _ := config.(map[string]interface{})
+	sprints, _ := data["sprints"].(map[string]interface{})
+	return fakeConnection{sprints: sprints}
+}
+
+func (fakeTaskProvider) ConfigTemplate() map[string]interface{} { return nil }
+
+func (fakeConnection) GetTaskList(ticketKeys []string) []serializers.Task { return nil }
+
+func (fakeConnection) GetTask(ticketKey string) (*serializers.Task, error) { return nil, nil }
+
+func (fakeConnection) GetTaskUrl(ticketKey string) string { return "" }
+
+func (c fakeConnection) GetSprint(sprintID string) *serializers.Sprint {
+	days, ok := c.sprints[sprintID].(float64)
+	if !ok {
+		return nil
+	}
+	fromDate := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(days))
+	toDate := fromDate.AddDate(0, 0, 14)
+	return &serializers.Sprint{ID: sprintID, FromDate: &fromDate, ToDate: &toDate}
+}
+
+func (c fakeConnection) GetSprintTaskList(sprint serializers.Sprint) ([]serializers.Task, error) {
+	if sprint.ID == "" {
+		return nil, nil
+	}
+	if _, ok := c.sprints[sprint.ID]; !ok {
+		return nil, fmt.Errorf("sprint %s was not found", sprint.ID)
+	}
+	return []serializers.Task{{Key: sprint.ID}}, nil
+}
+
+func (fakeConnection) ValidateConfig() error { return nil }
+
+func init() {
+	RegisterTaskProvider("fake", fakeTaskProvider{})
+}
+
+func TestGetConnectionsProviderKeys(t *testing.T) {
+	config := []byte(`[
+		{"type": "fake", "data": {}},
+		{"type": "fake", "key": "fake-board", "data": {}},
+		{"type": "fake", "data": {}}
+	]`)
+
+	connections, err := GetConnections(config)
+	if err != nil {
+		t.Fatalf("GetConnections() returned error %v", err)
+	}
+	// The configs without a key are keyed by their position among the configs of the type
+	expectedKeys := []string{"fake", "fake-board", "fake-3"}
+	for index, connection := range connections {
+		if connection.ProviderKey != expectedKeys[index] {
+			t.Errorf("connection %d has key %q, want %q", index, connection.ProviderKey, expectedKeys[index])
+		}
+		if connection.Type != "fake" {
+			t.Errorf("connection %d has type %q, want %q", index, connection.Type, "fake")
+		}
+	}
+
+	_, err = GetConnections([]byte(`[
+		{"type": "fake", "key": "fake", "data": {}},
+		{"type": "fake", "data": {}}
+	]`))
+	if err != nil {
+		t.Errorf("GetConnections() returned error %v for distinct keys", err)
+	}
+	_, err = GetConnections([]byte(`[
+		{"type": "fake", "data": {}},
+		{"type": "fake", "key": "fake", "data": {}}
+	]`))
+	if err == nil {
+		t.Error("GetConnections() returned no error for a duplicate key")
+	}
+}
+
+func TestAssignProviderKeys(t *testing.T) {
+	newConfig := func(project string, token string) map[string]interface{} {
+		return map[string]interface{}{
+			"type": "fake",
+			"data": map[string]interface{}{
+				"project":     project,
+				"credentials": map[string]interface{}{"type": "apiToken", "apiToken": token},
+			},
+		}
+	}
+
+	configList := []map[string]interface{}{
+		newConfig("first", "token"),
+		{"type": "fake", "key": "fake", "data": map[string]interface{}{}},
+		newConfig("second", "token"),
+		newConfig("first", "token"),
+	}
+	if err := AssignProviderKeys(configList); err != nil {
+		t.Fatalf("AssignProviderKeys() returned error %v", err)
+	}
+
+	keys := make(map[string]bool)
+	for index, taskProviderConfig := range configList {
+		key, _ := taskProviderConfig["key"].(string)
+		if key == "" {
+			t.Fatalf("config %d has no key", index)
+		}
+		if keys[key] {
+			t.Errorf("config %d has the duplicate key %q", index, key)
+		}
+		keys[key] = true
+	}
+	if configList[1]["key"] != "fake" {
+		t.Errorf("the persisted key changed to %q", configList[1]["key"])
+	}
+	if configList[3]["key"] != configList[0]["key"].(string)+"-2" {
+		t.Errorf("the same config got the key %q, want %q", configList[3]["key"], configList[0]["key"].(string)+"-2")
+	}
+
+	// The key does not depend on the credentials or the position of the config
+	reconfigured := []map[string]interface{}{newConfig("second", "new token")}
+	if err := AssignProviderKeys(reconfigured); err != nil {
+		t.Fatalf("AssignProviderKeys() returned error %v", err)
+	}
+	if reconfigured[0]["key"] != configList[2]["key"] {
+		t.Errorf("the reconfigured config got the key %q, want %q", reconfigured[0]["key"], configList[2]["key"])
+	}
+}
+
+func TestGetSprintQueriesEachProviderWithItsSprintID(t *testing.T) {
+	config := []byte(`[
+		{"type": "fake", "key": "first", "data": {"sprints": {"10": 0}}},
+		{"type": "fake", "key": "second", "data": {"sprints": {"20": 7}}}
+	]`)
+
+	sprint, err := GetSprint(config, map[string]string{"first": "10", "second": "20"})
+	if err != nil || sprint == nil {
+		t.Fatalf("GetSprint() returned %v, %v", sprint, err)
+	}
+	// The sprint runs from the earliest start to the latest end of the sprints of the providers
+	expectedFromDate := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	expectedToDate := time.Date(2020, 1, 22, 0, 0, 0, 0, time.UTC)
+	if !sprint.FromDate.Equal(expectedFromDate) || !sprint.ToDate.Equal(expectedToDate) {
+		t.Errorf("GetSprint() returned the dates %v - %v, want %v - %v",
+			sprint.FromDate, sprint.ToDate, expectedFromDate, expectedToDate)
+	}
+
+	// The sprint ID of a provider is not looked up in the other providers
+	if sprint, err = GetSprint(config, map[string]string{"first": "20"}); err != nil || sprint != nil {
+		t.Errorf("GetSprint() returned %v, %v for the sprint of another provider", sprint, err)
+	}
+	if _, err = GetSprint(config, map[string]string{"third": "10"}); err == nil {
+		t.Error("GetSprint() returned no error for an unknown provider key")
+	}
+
+	tasks, err := GetSprintTaskList(config, serializers.Sprint{}, map[string]string{"first": "10", "second": "20"})
+	if err != nil {
+		t.Fatalf("GetSprintTaskList() returned error %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].Key != "10" || tasks[0].ProviderKey != "first" ||
+		tasks[1].Key != "20" || tasks[1].ProviderKey != "second" {
+		t.Errorf("GetSprintTaskList() returned %+v", tasks)
+	}
+}
+
+func TestGetUpdatedSprintTaskListReportsProviderErrors(t *testing.T) {
+	config := []byte(`[
+		{"type": "fake", "key": "first", "data": {"sprints": {"10": 0}}},
+		{"type": "fake", "key": "second", "data": {"sprints": {"20": 7}}}
+	]`)
+
+	tasks, providerErrors, err := GetUpdatedSprintTaskList(
+		config, serializers.Sprint{}, map[string]string{"first": "99", "second": "20"}, nil)
+	if err != nil {
+		t.Fatalf("GetUpdatedSprintTaskList() returned error %v", err)
+	}
+	// The tasks of the other providers are returned along with the error of the failed provider
+	if len(tasks) != 1 || tasks[0].ProviderKey != "second" {
+		t.Errorf("GetUpdatedSprintTaskList() returned %+v", tasks)
+	}
+	if len(providerErrors) != 1 || providerErrors["first"] == nil {
+		t.Errorf("GetUpdatedSprintTaskList() returned the provider errors %v", providerErrors)
+	}
+
+	if _, err = GetSprintTaskList(config, serializers.Sprint{}, map[string]string{"first": "99"}); err == nil {
+		t.Error("GetSprintTaskList() returned no error for a failed provider")
+	}
+}
+
+func TestMappingsAreKeyedByProvider(t *testing.T) {
+	config := []byte(`[
+		{"type": "fake", "data": {"DoneStatus": "Done, Closed", "FeatureTypes": "Story", "TaskTypes": "Task",
+			"BugTypes": "Bug"}},
+		{"type": "fake", "key": "tracker", "data": {"DoneStatus": "Accepted", "FeatureTypes": "Feature",
+			"TaskTypes": "Chore", "BugTypes": "Bug"}}
+	]`)
+
+	statusMap, err := GetStatusMapping(config)
+	if err != nil {
+		t.Fatalf("GetStatusMapping() returned error %v", err)
+	}
+	// A done status of one provider does not mark the tasks of the other provider done
+	expectedStatuses := map[string][]string{"fake": {"done", "closed"}, "tracker": {"accepted"}}
+	if len(statusMap) != len(expectedStatuses) {
+		t.Fatalf("GetStatusMapping() returned %v", statusMap)
+	}
+	for providerKey, expected := range expectedStatuses {
+		if statuses := statusMap[providerKey][DoneStatus]; fmt.Sprint(statuses) != fmt.Sprint(expected) {
+			t.Errorf("%s has the done statuses %v, want %v", providerKey, statuses, expected)
+		}
+	}
+
+	taskTypes, err := GetTaskTypeMappings(config)
+	if err != nil {
+		t.Fatalf("GetTaskTypeMappings() returned error %v", err)
+	}
+	if features := taskTypes["fake"]["FeatureTypes"]; fmt.Sprint(features) != "[story]" {
+		t.Errorf("fake has the feature types %v, want [story]", features)
+	}
+	if features := taskTypes["tracker"]["FeatureTypes"]; fmt.Sprint(features) != "[feature]" {
+		t.Errorf("tracker has the feature types %v, want [feature]", features)
+	}
+}
+
+type unconnectableTaskProvider struct{ fakeTaskProvider }
+
+func (unconnectableTaskProvider) New(config interface{}) Connection { return nil }
+
+func TestValidateConfigsReportsUnusableConfigs(t *testing.T) {
+	RegisterTaskProvider("unconnectable", unconnectableTaskProvider{})
+	defer delete(TaskProviders, "unconnectable")
+
+	for _, providerType := range []string{"unknown", "unconnectable"} {
+		err := ValidateConfigs([]map[string]interface{}{{"type": providerType, "data": map[string]interface{}{}}})
+		if _, isConfigErr := err.(*ConfigError); !isConfigErr {
+			t.Errorf("ValidateConfigs() returned %v for the %s provider, want a config error", err, providerType)
+		}
+	}
+	if err := ValidateConfigs([]map[string]interface{}{{"type": "fake", "data": map[string]interface{}{}}}); err != nil {
+		t.Errorf("ValidateConfigs() returned error %v for a valid config", err)
+	}
+}
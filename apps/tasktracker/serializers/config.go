@@ -0,0 +1,15 @@
+package serializers
+
+// ConfigDiagnostic is a problem found in the config of a task provider, Field is the name of the config field at
+// fault, if any
+type ConfigDiagnostic struct {
+	Code    string
+	Field   string
+	Message string
+}
+
+// ConnectionTestResult is the result of the test of the connection to a task provider
+type ConnectionTestResult struct {
+	IsValid     bool
+	Diagnostics []ConfigDiagnostic
+}
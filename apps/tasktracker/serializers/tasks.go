@@ -8,6 +8,7 @@ import (
 type Task struct {
 	Key             string
 	TrackerUniqueID string
+	ProviderKey     string // Key of the task provider config the task was fetched from
 	ProjectID       string
 	Summary         string
 	Description     string
@@ -16,6 +17,22 @@ type Task struct {
 	Estimate        *float64
 	Assignee        string
 	Status          string
+	// CreatedAt is the time the task was created in the task tracker, if the task provider reports it
+	CreatedAt *time.Time
+	// StatusChanges is the changelog of the status of the task, if the task provider supports it
+	StatusChanges []StatusChange
+	// ParentKey is the key of the parent task of a sub-task
+	ParentKey string
+	// EpicKey and EpicName are of the epic of the task, if the task provider supports the epics
+	EpicKey  string
+	EpicName string
+}
+
+// StatusChange is a change of the status of a task
+type StatusChange struct {
+	FromStatus string
+	ToStatus   string
+	ChangedAt  time.Time
 }
 
 //Sprint ...
@@ -25,6 +42,8 @@ type Sprint struct {
 	Name     string
 	FromDate *time.Time
 	ToDate   *time.Time
+	// UpdatedSince limits the sprint tasks to the ones updated after it, if the task provider supports it
+	UpdatedSince *time.Time
 }
 
 //Board ...
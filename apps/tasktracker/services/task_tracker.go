@@ -1,6 +1,12 @@
 package services
 
-import "github.com/iReflect/reflect-app/apps/tasktracker"
+import (
+	"errors"
+	"net/http"
+
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	taskTrackerSerializers "github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+)
 
 //TaskTrackerService ...
 type TaskTrackerService struct {
@@ -13,3 +19,34 @@ func (service TaskTrackerService) ConfigList() (configList []map[string]interfac
 	}
 	return configList
 }
+
+// ConfigSchemas returns the JSON Schemas of the configs of all the task providers, mapped by the provider names
+func (service TaskTrackerService) ConfigSchemas() map[string]interface{} {
+	schemas := make(map[string]interface{})
+	for name := range tasktracker.TaskProviders {
+		schemas[name] = tasktracker.GetConfigSchema(name)
+	}
+	return schemas
+}
+
+// ConfigSchema returns the JSON Schema of the config of the task provider
+func (service TaskTrackerService) ConfigSchema(name string) (map[string]interface{}, int, error) {
+	schema := tasktracker.GetConfigSchema(name)
+	if schema == nil {
+		return nil, http.StatusNotFound, errors.New("task provider not found")
+	}
+	return schema, http.StatusOK, nil
+}
+
+// TestConnection connects to the task provider with the given config and reports the problems found in the config
+func (service TaskTrackerService) TestConnection(
+	taskProviderConfig map[string]interface{}) (*taskTrackerSerializers.ConnectionTestResult, int, error) {
+	diagnostics, err := tasktracker.TestConnection(taskProviderConfig)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return &taskTrackerSerializers.ConnectionTestResult{
+		IsValid:     len(diagnostics) == 0,
+		Diagnostics: diagnostics,
+	}, http.StatusOK, nil
+}
@@ -0,0 +1,90 @@
+package tasktracker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// webhookSignaturePrefix is the prefix of the HMAC-SHA256 signature in the X-Hub-Signature header
+const webhookSignaturePrefix = "sha256="
+
+type jiraWebhookPayload struct {
+	WebhookEvent string `json:"webhookEvent"`
+	Timestamp    int64  `json:"timestamp"`
+	Issue        *struct {
+		ID  string `json:"id"`
+		Key string `json:"key"`
+	} `json:"issue"`
+}
+
+type pivotalWebhookPayload struct {
+	Kind             string `json:"kind"`
+	GUID             string `json:"guid"`
+	PrimaryResources []struct {
+		Kind string      `json:"kind"`
+		ID   json.Number `json:"id"`
+	} `json:"primary_resources"`
+}
+
+// IsValidWebhookRequest verifies the HMAC-SHA256 signature of the body if the task tracker signs the
+// payloads (eg. JIRA), otherwise the secret is expected as the token in the X-Webhook-Token header (eg. Pivotal).
+// The token is not accepted in the webhook URL since the URLs end up in the logs of the proxies.
+func IsValidWebhookRequest(secret string, signature string, token string, body []byte) bool {
+	// Webhooks are disabled till a secret is generated for the retrospective
+	if secret == "" {
+		return false
+	}
+
+	if signature != "" {
+		if !strings.HasPrefix(signature, webhookSignaturePrefix) {
+			return false
+		}
+		signatureBytes, err := hex.DecodeString(strings.TrimPrefix(signature, webhookSignaturePrefix))
+		if err != nil {
+			return false
+		}
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		return hmac.Equal(signatureBytes, mac.Sum(nil))
+	}
+	return hmac.Equal([]byte(token), []byte(secret))
+}
+
+// ParseJIRAWebhookPayload returns the delivery ID and the key of the issue changed by the JIRA event
+func ParseJIRAWebhookPayload(body []byte) (string, []string, error) {
+	var payload jiraWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", nil, errors.New("invalid jira webhook payload")
+	}
+
+	// Only the issue events are of interest, deleted issues are left as is to keep the sprint history
+	if payload.Issue == nil || payload.Issue.Key == "" || payload.WebhookEvent == "jira:issue_deleted" {
+		return "", nil, nil
+	}
+	deliveryID := fmt.Sprintf("%s:%s:%d", payload.WebhookEvent, payload.Issue.ID, payload.Timestamp)
+	return deliveryID, []string{payload.Issue.Key}, nil
+}
+
+// ParsePivotalWebhookPayload returns the delivery ID and the IDs of the stories changed by the Pivotal activity
+func ParsePivotalWebhookPayload(body []byte) (string, []string, error) {
+	var payload pivotalWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return "", nil, errors.New("invalid pivotal webhook payload")
+	}
+
+	if payload.Kind == "story_delete_activity" {
+		return payload.GUID, nil, nil
+	}
+	var storyIDs []string
+	for _, resource := range payload.PrimaryResources {
+		if resource.Kind == "story" && resource.ID != "" {
+			storyIDs = append(storyIDs, resource.ID.String())
+		}
+	}
+	return payload.GUID, storyIDs, nil
+}
@@ -0,0 +1,124 @@
+package tasktracker
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+func TestIsValidWebhookRequest(t *testing.T) {
+	secret := "webhook-secret"
+	body := []byte(`{"webhookEvent": "jira:issue_updated"}`)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	signature := webhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+
+	testCases := []struct {
+		name      string
+		secret    string
+		signature string
+		token     string
+		body      []byte
+		isValid   bool
+	}{
+		{"valid signature", secret, signature, "", body, true},
+		{"signature of another body", secret, signature, "", []byte(`{}`), false},
+		{"signature without the prefix", secret, signature[len(webhookSignaturePrefix):], "", body, false},
+		{"signature which is not hex", secret, webhookSignaturePrefix + "not-hex", "", body, false},
+		// The token is not checked for the signed requests
+		{"invalid signature with a valid token", secret, webhookSignaturePrefix + "00", secret, body, false},
+		{"valid token", secret, "", secret, body, true},
+		{"invalid token", secret, "", "guess", body, false},
+		{"missing token", secret, "", "", body, false},
+		{"webhooks disabled", "", "", "", body, false},
+	}
+
+	for _, testCase := range testCases {
+		isValid := IsValidWebhookRequest(testCase.secret, testCase.signature, testCase.token, testCase.body)
+		if isValid != testCase.isValid {
+			t.Errorf("%s: the request should be valid - %v, got %v", testCase.name, testCase.isValid, isValid)
+		}
+	}
+}
+
+func TestParseJIRAWebhookPayload(t *testing.T) {
+	testCases := []struct {
+		name       string
+		body       string
+		deliveryID string
+		taskKeys   []string
+	}{
+		{
+			"issue updated",
+			`{"webhookEvent": "jira:issue_updated", "timestamp": 1528000000000, "issue": {"id": "10001", "key": "RFT-1"}}`,
+			"jira:issue_updated:10001:1528000000000",
+			[]string{"RFT-1"},
+		},
+		{"issue deleted", `{"webhookEvent": "jira:issue_deleted", "issue": {"id": "10001", "key": "RFT-1"}}`, "", nil},
+		{"not an issue event", `{"webhookEvent": "sprint_started", "timestamp": 1528000000000}`, "", nil},
+	}
+
+	for _, testCase := range testCases {
+		deliveryID, taskKeys, err := ParseJIRAWebhookPayload([]byte(testCase.body))
+		if err != nil {
+			t.Fatalf("%s: error in parsing the payload - %s", testCase.name, err)
+		}
+		if deliveryID != testCase.deliveryID || !reflect.DeepEqual(taskKeys, testCase.taskKeys) {
+			t.Errorf("%s: expected %q, %v, got %q, %v",
+				testCase.name, testCase.deliveryID, testCase.taskKeys, deliveryID, taskKeys)
+		}
+	}
+
+	if _, _, err := ParseJIRAWebhookPayload([]byte(`{"issue": [`)); err == nil {
+		t.Errorf("Invalid payload should fail")
+	}
+}
+
+func TestParsePivotalWebhookPayload(t *testing.T) {
+	testCases := []struct {
+		name       string
+		body       string
+		deliveryID string
+		taskKeys   []string
+	}{
+		{
+			"story updated",
+			`{"kind": "story_update_activity", "guid": "99_12", "primary_resources": [
+				{"kind": "story", "id": 561},
+				{"kind": "epic", "id": 7},
+				{"kind": "story", "id": 562}
+			]}`,
+			"99_12",
+			[]string{"561", "562"},
+		},
+		{
+			"story deleted",
+			`{"kind": "story_delete_activity", "guid": "99_13", "primary_resources": [{"kind": "story", "id": 561}]}`,
+			"99_13",
+			nil,
+		},
+		{
+			"no stories",
+			`{"kind": "epic_update_activity", "guid": "99_14", "primary_resources": [{"kind": "epic", "id": 7}]}`,
+			"99_14",
+			nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		deliveryID, taskKeys, err := ParsePivotalWebhookPayload([]byte(testCase.body))
+		if err != nil {
+			t.Fatalf("%s: error in parsing the payload - %s", testCase.name, err)
+		}
+		if deliveryID != testCase.deliveryID || !reflect.DeepEqual(taskKeys, testCase.taskKeys) {
+			t.Errorf("%s: expected %q, %v, got %q, %v",
+				testCase.name, testCase.deliveryID, testCase.taskKeys, deliveryID, taskKeys)
+		}
+	}
+
+	if _, _, err := ParsePivotalWebhookPayload([]byte(`not json`)); err == nil {
+		t.Errorf("Invalid payload should fail")
+	}
+}
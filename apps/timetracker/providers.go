@@ -14,7 +14,10 @@ type TimeProvider interface {
 
 // Connection ...
 type Connection interface {
-	GetProjectTimeLogs(project string, startTime time.Time, endTime time.Time) []serializers.TimeLog
+	// GetProjectTimeLogs returns the time logs of the project between the dates of the given times (inclusive), the
+	// dates are taken in the location of the given times. It fails if the time logs could not be fetched, so that
+	// the recorded time is not reset as if nothing was logged.
+	GetProjectTimeLogs(project string, startTime time.Time, endTime time.Time) ([]serializers.TimeLog, error)
 }
 
 var timeProviders = make(map[string]TimeProvider)
@@ -33,7 +36,8 @@ func GetTimeProvider(name string) TimeProvider {
 	return nil
 }
 
-// GetProjectTimeLogs ...
+// GetProjectTimeLogs returns the time logs of the project from all the time providers, it fails if the time logs of
+// any of the time providers could not be fetched
 func GetProjectTimeLogs(config []byte, project string, startTime time.Time, endTime time.Time) (timeLogs []serializers.TimeLog, err error) {
 	connections, err := GetConnections(config)
 	if err != nil {
@@ -41,7 +45,11 @@ func GetProjectTimeLogs(config []byte, project string, startTime time.Time, endT
 	}
 
 	for _, connection := range connections {
-		timeLogs = append(timeLogs, connection.GetProjectTimeLogs(project, startTime, endTime)...)
+		connectionTimeLogs, err := connection.GetProjectTimeLogs(project, startTime, endTime)
+		if err != nil {
+			return nil, err
+		}
+		timeLogs = append(timeLogs, connectionTimeLogs...)
 	}
 	return timeLogs, nil
 }
@@ -68,3 +76,9 @@ func GetConnections(config []byte) (connections []Connection, err error) {
 
 	return connections, nil
 }
+
+// HasConnections checks if the config has any valid time provider configured
+func HasConnections(config []byte) bool {
+	connections, err := GetConnections(config)
+	return err == nil && len(connections) != 0
+}
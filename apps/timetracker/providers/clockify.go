@@ -0,0 +1,162 @@
+package providers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/timetracker"
+	"github.com/iReflect/reflect-app/apps/timetracker/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// ClockifyTimeProvider ...
+type ClockifyTimeProvider struct {
+}
+
+// ClockifyConnection ...
+type ClockifyConnection struct {
+	config ClockifyConfig
+	client *http.Client
+}
+
+// ClockifyConfig ...
+type ClockifyConfig struct {
+	DescriptionConfig
+	APIKey string `json:"apiKey"`
+	// WorkspaceID defaults to the active workspace of the user
+	WorkspaceID string `json:"workspaceId"`
+	BaseURL     string `json:"baseURL"`
+}
+
+// GetBaseURL ...
+func (config ClockifyConfig) GetBaseURL() string {
+	if config.BaseURL == "" {
+		return "https://api.clockify.me/api/v1"
+	}
+	return strings.Trim(config.BaseURL, "/")
+}
+
+type clockifyUser struct {
+	ID              string `json:"id"`
+	ActiveWorkspace string `json:"activeWorkspace"`
+}
+
+type clockifyTimeEntry struct {
+	Description string `json:"description"`
+	Project     *struct {
+		Name string `json:"name"`
+	} `json:"project"`
+	TimeInterval struct {
+		Start *time.Time `json:"start"`
+		End   *time.Time `json:"end"`
+	} `json:"timeInterval"`
+}
+
+// TimeProviderClockify ...
+const (
+	TimeProviderClockify = "clockify"
+)
+
+// clockifyPageSize ...
+const clockifyPageSize = 200
+
+func init() {
+	provider := &ClockifyTimeProvider{}
+	timetracker.RegisterTimeProvider(TimeProviderClockify, provider)
+}
+
+// New ...
+func (m *ClockifyTimeProvider) New(config interface{}) timetracker.Connection {
+	var clockifyConfig ClockifyConfig
+	if err := getDescriptionConfigObject(config, &clockifyConfig); err != nil {
+		return nil
+	}
+	if clockifyConfig.APIKey == "" {
+		return nil
+	}
+	return &ClockifyConnection{config: clockifyConfig, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// GetProjectTimeLogs ...
+func (m *ClockifyConnection) GetProjectTimeLogs(project string, startTime time.Time, endTime time.Time) ([]serializers.TimeLog, error) {
+	entries, err := m.getTimeEntries(startTime, endTime)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+
+	timeLogs, err := m.config.getTimeLogs(entries, project, "Clockify")
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+	return timeLogs, nil
+}
+
+func (m *ClockifyConnection) get(path string, v interface{}) error {
+	req, err := http.NewRequest("GET", m.config.GetBaseURL()+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Api-Key", m.config.APIKey)
+	_, err = getJSON(m.client, req, v)
+	return err
+}
+
+func (m *ClockifyConnection) getTimeEntries(startTime time.Time, endTime time.Time) ([]descriptionTimeEntry, error) {
+	start, end := getTimeLogRange(startTime, endTime)
+
+	// The time entries are fetched per user, so the user (and the workspace) of the API key is needed
+	var user clockifyUser
+	if err := m.get("/user", &user); err != nil {
+		return nil, err
+	}
+	workspaceID := m.config.WorkspaceID
+	if workspaceID == "" {
+		workspaceID = user.ActiveWorkspace
+	}
+
+	query := url.Values{}
+	query.Set("start", start.UTC().Format(time.RFC3339))
+	query.Set("end", end.UTC().Format(time.RFC3339))
+	// hydrated includes the project details in the entries
+	query.Set("hydrated", "true")
+	query.Set("page-size", strconv.Itoa(clockifyPageSize))
+
+	var entries []descriptionTimeEntry
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		var clockifyEntries []clockifyTimeEntry
+		path := fmt.Sprintf("/workspaces/%s/user/%s/time-entries?%s",
+			url.PathEscape(workspaceID), url.PathEscape(user.ID), query.Encode())
+		if err := m.get(path, &clockifyEntries); err != nil {
+			return nil, err
+		}
+
+		for _, clockifyEntry := range clockifyEntries {
+			// Skip the entries which are still running
+			if clockifyEntry.TimeInterval.Start == nil || clockifyEntry.TimeInterval.End == nil {
+				continue
+			}
+			var projectName string
+			if clockifyEntry.Project != nil {
+				projectName = clockifyEntry.Project.Name
+			}
+			entries = append(entries, descriptionTimeEntry{
+				Project:     projectName,
+				Description: clockifyEntry.Description,
+				Minutes:     clockifyEntry.TimeInterval.End.Sub(*clockifyEntry.TimeInterval.Start).Minutes(),
+				Date:        getEntryDate(*clockifyEntry.TimeInterval.Start, startTime.Location()),
+			})
+		}
+
+		if len(clockifyEntries) < clockifyPageSize {
+			break
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,154 @@
+package providers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/timetracker/serializers"
+)
+
+// DefaultTaskKeyRegex matches the JIRA style task keys, eg. 'REFLECT-123'
+const DefaultTaskKeyRegex = `[A-Za-z][A-Za-z0-9]+-[0-9]+`
+
+// DescriptionConfig is the config common to the time providers which log the time against free text
+// entries, the task keys are extracted from the entry descriptions using the TaskKeyRegex
+type DescriptionConfig struct {
+	// Project is the name of the project in the time tracker, defaults to the project of the retrospective
+	Project string `json:"project"`
+	// TaskKeyRegex is used to extract the task key from the entry description. If the regex has
+	// a capturing group the first group is used as the task key, otherwise the whole match is used.
+	TaskKeyRegex string `json:"taskKeyRegex"`
+}
+
+// descriptionTimeEntry is a time entry of a description based time provider
+type descriptionTimeEntry struct {
+	Project     string
+	Description string
+	Minutes     float64
+	Date        time.Time
+}
+
+// getTaskKeyRegex ...
+func (c DescriptionConfig) getTaskKeyRegex() (*regexp.Regexp, error) {
+	if c.TaskKeyRegex == "" {
+		return regexp.MustCompile(DefaultTaskKeyRegex), nil
+	}
+	return regexp.Compile(c.TaskKeyRegex)
+}
+
+// GetTaskKey extracts the task key from the description of a time entry
+func (c DescriptionConfig) GetTaskKey(description string) (string, error) {
+	taskKeyRegex, err := c.getTaskKeyRegex()
+	if err != nil {
+		return "", err
+	}
+	matches := taskKeyRegex.FindStringSubmatch(description)
+	switch {
+	case len(matches) == 0:
+		return "", nil
+	case len(matches) > 1:
+		return strings.TrimSpace(matches[1]), nil
+	default:
+		return strings.TrimSpace(matches[0]), nil
+	}
+}
+
+// getTimeLogs filters the entries of the project and aggregates the time spent on each task per day, the minutes are
+// rounded after they are summed. The entries without a task key in their description are ignored.
+func (c DescriptionConfig) getTimeLogs(entries []descriptionTimeEntry, project string, logger string) ([]serializers.TimeLog, error) {
+	if c.Project != "" {
+		project = c.Project
+	}
+
+	type taskDay struct {
+		taskKey string
+		date    time.Time
+	}
+	var taskDays []taskDay
+	taskDayMinutes := make(map[taskDay]float64)
+	for _, entry := range entries {
+		if !strings.EqualFold(strings.TrimSpace(entry.Project), strings.TrimSpace(project)) {
+			continue
+		}
+		taskKey, err := c.GetTaskKey(entry.Description)
+		if err != nil {
+			return nil, err
+		}
+		if taskKey == "" {
+			continue
+		}
+		key := taskDay{taskKey: taskKey, date: entry.Date}
+		if _, exists := taskDayMinutes[key]; !exists {
+			taskDays = append(taskDays, key)
+		}
+		taskDayMinutes[key] += entry.Minutes
+	}
+
+	var timeLogs []serializers.TimeLog
+	for _, key := range taskDays {
+		timeLogs = append(timeLogs, serializers.TimeLog{
+			Project: project,
+			TaskKey: key.taskKey,
+			Logger:  logger,
+			Minutes: uint(math.Round(taskDayMinutes[key])),
+			Date:    key.date,
+		})
+	}
+	return timeLogs, nil
+}
+
+// getTimeLogRange returns the range of time to fetch the entries for, the dates are inclusive
+// and interpreted in the location of the start time
+func getTimeLogRange(startTime time.Time, endTime time.Time) (time.Time, time.Time) {
+	location := startTime.Location()
+	endTime = endTime.In(location)
+	start := time.Date(startTime.Year(), startTime.Month(), startTime.Day(), 0, 0, 0, 0, location)
+	end := time.Date(endTime.Year(), endTime.Month(), endTime.Day(), 0, 0, 0, 0, location).AddDate(0, 0, 1)
+	return start, end
+}
+
+// getEntryDate returns the date of an entry started at the given time, as per the given location
+func getEntryDate(startedAt time.Time, location *time.Location) time.Time {
+	startedAt = startedAt.In(location)
+	return time.Date(startedAt.Year(), startedAt.Month(), startedAt.Day(), 0, 0, 0, 0, location)
+}
+
+// getJSON sends the request and decodes the JSON response into v
+func getJSON(client *http.Client, req *http.Request, v interface{}) (*http.Response, error) {
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, fmt.Errorf("%s: unexpected status %d", req.URL.Host, resp.StatusCode)
+	}
+	if v == nil {
+		return resp, nil
+	}
+	return resp, json.NewDecoder(resp.Body).Decode(v)
+}
+
+// getDescriptionConfigObject decodes the config of a description based time provider into c
+func getDescriptionConfigObject(config interface{}, c interface{}) error {
+	switch config.(type) {
+	case []byte:
+		return json.Unmarshal(config.([]byte), c)
+	case map[string]interface{}:
+		jsonConfig, err := json.Marshal(config)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(jsonConfig, c)
+	default:
+		return errors.New("invalid type")
+	}
+}
@@ -0,0 +1,72 @@
+package providers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetTaskKey(t *testing.T) {
+	testCases := []struct {
+		regex       string
+		description string
+		taskKey     string
+	}{
+		{"", "REFLECT-12 fix the login page", "REFLECT-12"},
+		{"", "code review", ""},
+		{`#([0-9]+)`, "Broken link (#42)", "42"},
+		{`\[(.+?)\]`, "[OPS-7] deploy", "OPS-7"},
+	}
+
+	for _, testCase := range testCases {
+		taskKey, err := DescriptionConfig{TaskKeyRegex: testCase.regex}.GetTaskKey(testCase.description)
+		if err != nil {
+			t.Fatalf("Error in getting the task key - %s", err)
+		}
+		if taskKey != testCase.taskKey {
+			t.Fatalf("Task key should be %q for %q, got %q", testCase.taskKey, testCase.description, taskKey)
+		}
+	}
+
+	if _, err := (DescriptionConfig{TaskKeyRegex: "("}).GetTaskKey("REFLECT-12"); err == nil {
+		t.Fatalf("Invalid regex should return an error")
+	}
+}
+
+func TestGetTimeLogs(t *testing.T) {
+	entries := []descriptionTimeEntry{
+		{Project: "Reflect", Description: "REFLECT-1 login page", Minutes: 30},
+		{Project: "reflect ", Description: "REFLECT-1 review comments", Minutes: 45.5},
+		{Project: "Reflect", Description: "standup", Minutes: 15},
+		{Project: "Other", Description: "REFLECT-2 wrong project", Minutes: 60},
+	}
+
+	timeLogs, err := DescriptionConfig{}.getTimeLogs(entries, "Reflect", "Test")
+	if err != nil {
+		t.Fatalf("Error in getting the time logs - %s", err)
+	}
+	if len(timeLogs) != 1 || timeLogs[0].TaskKey != "REFLECT-1" || timeLogs[0].Minutes != 76 {
+		t.Fatalf("Time of the project tasks should be aggregated - %+v", timeLogs)
+	}
+
+	timeLogs, err = DescriptionConfig{Project: "Other"}.getTimeLogs(entries, "Reflect", "Test")
+	if err != nil {
+		t.Fatalf("Error in getting the time logs - %s", err)
+	}
+	if len(timeLogs) != 1 || timeLogs[0].TaskKey != "REFLECT-2" || timeLogs[0].Project != "Other" {
+		t.Fatalf("Configured project should override the retrospective project - %+v", timeLogs)
+	}
+
+	firstDay := time.Date(2018, 6, 4, 0, 0, 0, 0, time.UTC)
+	datedEntries := []descriptionTimeEntry{
+		{Project: "Reflect", Description: "REFLECT-1 login page", Minutes: 30, Date: firstDay},
+		{Project: "Reflect", Description: "REFLECT-1 login page", Minutes: 60, Date: firstDay.AddDate(0, 0, 1)},
+		{Project: "Reflect", Description: "REFLECT-1 review comments", Minutes: 15, Date: firstDay},
+	}
+	timeLogs, err = DescriptionConfig{}.getTimeLogs(datedEntries, "Reflect", "Test")
+	if err != nil {
+		t.Fatalf("Error in getting the time logs - %s", err)
+	}
+	if len(timeLogs) != 2 || !timeLogs[0].Date.Equal(firstDay) || timeLogs[0].Minutes != 45 || timeLogs[1].Minutes != 60 {
+		t.Fatalf("Time of the tasks should be aggregated per day - %+v", timeLogs)
+	}
+}
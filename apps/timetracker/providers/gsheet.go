@@ -28,11 +28,13 @@ type GsheetConfig struct {
 	Email string `json:"email"`
 }
 
-// TimeResult ...
+// TimeResult is a row of the time logs returned by the script, the script returns a row per task per day with the
+// date in the 'YYYY-MM-DD' format. The rows without the date (returned by the older scripts) are taken as undated.
 type TimeResult struct {
 	Project string  `json:"Project"`
 	TaskID  string  `json:"TaskID"`
 	Hours   float64 `json:"Hours"`
+	Date    string  `json:"Date"`
 }
 
 // TimeProviderGSheet ...
@@ -89,19 +91,15 @@ func getConfigObject(config interface{}) (GsheetConfig, error) {
 }
 
 // GetProjectTimeLogs ...
-func (m *GsheetConnection) GetProjectTimeLogs(project string, startTime time.Time, endTime time.Time) []serializers.TimeLog {
+func (m *GsheetConnection) GetProjectTimeLogs(project string, startTime time.Time, endTime time.Time) ([]serializers.TimeLog, error) {
 
 	var timeLogs []serializers.TimeLog
 
 	timeTrackerConfig := config.GetConfig().TimeTracker
 	appExecutor := google.AppScriptExecutor{ScriptID: timeTrackerConfig.ScriptID, CredentialsFile: timeTrackerConfig.GoogleCredentials}
 
-	location, err := time.LoadLocation(timeTrackerConfig.TimeZone)
-	if err != nil {
-		log.Println("Invalid Timezone: ", err)
-		utils.LogToSentry(err)
-		return timeLogs
-	}
+	// The dates are taken in the location of the start time, which is the time zone of the member
+	location := startTime.Location()
 	responseBytes, err := appExecutor.Run(
 		timeTrackerConfig.FnGetTimeLog,
 		m.config.Email,
@@ -110,7 +108,7 @@ func (m *GsheetConnection) GetProjectTimeLogs(project string, startTime time.Tim
 	if err != nil {
 		log.Println("App Executor Failed: ", err)
 		utils.LogToSentry(err)
-		return timeLogs
+		return nil, err
 	}
 
 	type Response struct {
@@ -123,21 +121,30 @@ func (m *GsheetConnection) GetProjectTimeLogs(project string, startTime time.Tim
 	if err := json.Unmarshal(responseBytes, &trackerData); err != nil {
 		log.Println("Respoonse decoding error: ", err)
 		utils.LogToSentry(err)
-		return timeLogs
+		return nil, err
 	}
 
 	log.Println("Result : ", trackerData.Result)
 
 	for _, logData := range trackerData.Result {
 		if logData.TaskID != "" {
+			var date time.Time
+			if logData.Date != "" {
+				date, err = time.ParseInLocation(constants.CustomDateFormat, logData.Date, location)
+				if err != nil {
+					log.Println("Invalid time log date: ", err)
+					utils.LogToSentry(err)
+				}
+			}
 			timeLogs = append(timeLogs, serializers.TimeLog{
 				Project: logData.Project,
 				TaskKey: logData.TaskID,
 				Logger:  "GSheets",
 				Minutes: uint(logData.Hours * 60), //uint(logData["Hours"].(float64) * 60),
+				Date:    date,
 			})
 		}
 	}
 
-	return timeLogs
+	return timeLogs, nil
 }
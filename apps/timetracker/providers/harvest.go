@@ -0,0 +1,153 @@
+package providers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/timetracker"
+	"github.com/iReflect/reflect-app/apps/timetracker/serializers"
+	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// HarvestTimeProvider ...
+type HarvestTimeProvider struct {
+}
+
+// HarvestConnection ...
+type HarvestConnection struct {
+	config HarvestConfig
+	client *http.Client
+}
+
+// HarvestConfig ...
+type HarvestConfig struct {
+	DescriptionConfig
+	AccessToken string `json:"accessToken"`
+	AccountID   string `json:"accountId"`
+	BaseURL     string `json:"baseURL"`
+}
+
+// GetBaseURL ...
+func (config HarvestConfig) GetBaseURL() string {
+	if config.BaseURL == "" {
+		return "https://api.harvestapp.com/v2"
+	}
+	return strings.Trim(config.BaseURL, "/")
+}
+
+type harvestTimeEntry struct {
+	Notes string  `json:"notes"`
+	Hours float64 `json:"hours"`
+	// SpentDate is in the 'YYYY-MM-DD' format
+	SpentDate string `json:"spent_date"`
+	Project   struct {
+		Name string `json:"name"`
+	} `json:"project"`
+}
+
+type harvestTimeEntries struct {
+	TimeEntries []harvestTimeEntry `json:"time_entries"`
+	Links       struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+// TimeProviderHarvest ...
+const (
+	TimeProviderHarvest = "harvest"
+)
+
+func init() {
+	provider := &HarvestTimeProvider{}
+	timetracker.RegisterTimeProvider(TimeProviderHarvest, provider)
+}
+
+// New ...
+func (m *HarvestTimeProvider) New(config interface{}) timetracker.Connection {
+	var harvestConfig HarvestConfig
+	if err := getDescriptionConfigObject(config, &harvestConfig); err != nil {
+		return nil
+	}
+	if harvestConfig.AccessToken == "" || harvestConfig.AccountID == "" {
+		return nil
+	}
+	return &HarvestConnection{config: harvestConfig, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// GetProjectTimeLogs ...
+func (m *HarvestConnection) GetProjectTimeLogs(project string, startTime time.Time, endTime time.Time) ([]serializers.TimeLog, error) {
+	entries, err := m.getTimeEntries(startTime, endTime)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+
+	timeLogs, err := m.config.getTimeLogs(entries, project, "Harvest")
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+	return timeLogs, nil
+}
+
+func (m *HarvestConnection) get(requestURL string, v interface{}) error {
+	req, err := http.NewRequest("GET", requestURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+m.config.AccessToken)
+	req.Header.Set("Harvest-Account-Id", m.config.AccountID)
+	req.Header.Set("User-Agent", "iReflect")
+	_, err = getJSON(m.client, req, v)
+	return err
+}
+
+func (m *HarvestConnection) getTimeEntries(startTime time.Time, endTime time.Time) ([]descriptionTimeEntry, error) {
+	start, end := getTimeLogRange(startTime, endTime)
+
+	// The time entries are fetched for the user of the access token
+	var user struct {
+		ID int64 `json:"id"`
+	}
+	if err := m.get(m.config.GetBaseURL()+"/users/me", &user); err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("user_id", strconv.FormatInt(user.ID, 10))
+	// Harvest dates are inclusive, while the end of the range is the start of the next day
+	query.Set("from", start.Format(constants.CustomDateFormat))
+	query.Set("to", end.AddDate(0, 0, -1).Format(constants.CustomDateFormat))
+
+	var entries []descriptionTimeEntry
+	requestURL := m.config.GetBaseURL() + "/time_entries?" + query.Encode()
+	for requestURL != "" {
+		var page harvestTimeEntries
+		if err := m.get(requestURL, &page); err != nil {
+			return nil, err
+		}
+		for _, harvestEntry := range page.TimeEntries {
+			// The entries with an invalid date are skipped, instead of being counted on an unknown day
+			spentDate, err := time.ParseInLocation(constants.CustomDateFormat, harvestEntry.SpentDate,
+				startTime.Location())
+			if err != nil {
+				utils.LogToSentry(fmt.Errorf("harvest time entry has an invalid spent date %q: %s",
+					harvestEntry.SpentDate, err))
+				continue
+			}
+			entries = append(entries, descriptionTimeEntry{
+				Project:     harvestEntry.Project.Name,
+				Description: harvestEntry.Notes,
+				Minutes:     harvestEntry.Hours * 60,
+				Date:        spentDate,
+			})
+		}
+		requestURL = page.Links.Next
+	}
+	return entries, nil
+}
@@ -0,0 +1,71 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const harvestTestToken = "secret-token"
+
+func newHarvestTestServer(entries []harvestTimeEntry) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+harvestTestToken {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/users/me":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": 1})
+		case "/time_entries":
+			json.NewEncoder(w).Encode(harvestTimeEntries{TimeEntries: entries})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestHarvestGetProjectTimeLogs(t *testing.T) {
+	newEntry := func(notes string, hours float64, spentDate string) harvestTimeEntry {
+		entry := harvestTimeEntry{Notes: notes, Hours: hours, SpentDate: spentDate}
+		entry.Project.Name = "Reflect"
+		return entry
+	}
+	// 0.01 hours are 0.6 minutes, which are counted only once summed up
+	entries := []harvestTimeEntry{
+		newEntry("REFLECT-1 login page", 0.5, "2018-06-04"),
+		newEntry("REFLECT-1 review", 0.01, "2018-06-04"),
+		newEntry("REFLECT-1 fixes", 0.01, "2018-06-04"),
+		newEntry("REFLECT-2 broken link", 0.01, "2018-06-05"),
+		newEntry("REFLECT-2 invalid date", 1, "05/06/2018"),
+	}
+	server := newHarvestTestServer(entries)
+	defer server.Close()
+
+	connection := (&HarvestTimeProvider{}).New(map[string]interface{}{
+		"accessToken": harvestTestToken, "accountId": "1", "baseURL": server.URL})
+	firstDay := time.Date(2018, 6, 4, 0, 0, 0, 0, time.UTC)
+	timeLogs, err := connection.GetProjectTimeLogs("Reflect", firstDay, firstDay.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("Error in getting the time logs - %s", err)
+	}
+
+	minutes := make(map[string]uint)
+	for _, timeLog := range timeLogs {
+		minutes[timeLog.TaskKey+" "+timeLog.Date.Format("2006-01-02")] += timeLog.Minutes
+	}
+	expectedMinutes := map[string]uint{
+		"REFLECT-1 2018-06-04": 31,
+		"REFLECT-2 2018-06-05": 1,
+	}
+	if len(minutes) != len(expectedMinutes) {
+		t.Fatalf("Expected the time logs %v, got %v", expectedMinutes, minutes)
+	}
+	for key, expected := range expectedMinutes {
+		if minutes[key] != expected {
+			t.Errorf("Expected %d minutes for %s, got %d", expected, key, minutes[key])
+		}
+	}
+}
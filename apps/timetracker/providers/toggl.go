@@ -0,0 +1,151 @@
+package providers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/timetracker"
+	"github.com/iReflect/reflect-app/apps/timetracker/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// TogglTimeProvider ...
+type TogglTimeProvider struct {
+}
+
+// TogglConnection ...
+type TogglConnection struct {
+	config TogglConfig
+	client *http.Client
+}
+
+// TogglConfig ...
+type TogglConfig struct {
+	DescriptionConfig
+	APIToken string `json:"apiToken"`
+	BaseURL  string `json:"baseURL"`
+}
+
+// GetBaseURL ...
+func (config TogglConfig) GetBaseURL() string {
+	if config.BaseURL == "" {
+		return "https://api.track.toggl.com/api/v9"
+	}
+	return strings.Trim(config.BaseURL, "/")
+}
+
+// togglTimeEntry ...
+type togglTimeEntry struct {
+	ID          int64  `json:"id"`
+	Description string `json:"description"`
+	// Duration is in seconds, it is negative for the running entries
+	Duration    int64     `json:"duration"`
+	ProjectName string    `json:"project_name"`
+	Start       time.Time `json:"start"`
+}
+
+// TimeProviderToggl ...
+const (
+	TimeProviderToggl = "toggl"
+)
+
+func init() {
+	provider := &TogglTimeProvider{}
+	timetracker.RegisterTimeProvider(TimeProviderToggl, provider)
+}
+
+// New ...
+func (m *TogglTimeProvider) New(config interface{}) timetracker.Connection {
+	var togglConfig TogglConfig
+	if err := getDescriptionConfigObject(config, &togglConfig); err != nil {
+		return nil
+	}
+	if togglConfig.APIToken == "" {
+		return nil
+	}
+	return &TogglConnection{config: togglConfig, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+// GetProjectTimeLogs ...
+func (m *TogglConnection) GetProjectTimeLogs(project string, startTime time.Time, endTime time.Time) ([]serializers.TimeLog, error) {
+	entries, err := m.getTimeEntries(startTime, endTime)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+
+	timeLogs, err := m.config.getTimeLogs(entries, project, "Toggl")
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+	return timeLogs, nil
+}
+
+func (m *TogglConnection) getTimeEntries(startTime time.Time, endTime time.Time) ([]descriptionTimeEntry, error) {
+	start, end := getTimeLogRange(startTime, endTime)
+	togglEntries, err := m.listTimeEntries(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []descriptionTimeEntry
+	for _, togglEntry := range togglEntries {
+		// Skip the entries which are still running
+		if togglEntry.Duration < 0 {
+			continue
+		}
+		entries = append(entries, descriptionTimeEntry{
+			Project:     togglEntry.ProjectName,
+			Description: togglEntry.Description,
+			Minutes:     float64(togglEntry.Duration) / 60,
+			Date:        getEntryDate(togglEntry.Start, startTime.Location()),
+		})
+	}
+	return entries, nil
+}
+
+// listTimeEntries fetches the time entries started in the given range. The time entries API returns the latest
+// entries first and caps their number without any pagination, so the range up to the earliest entry fetched is
+// fetched again until no new entry is returned.
+func (m *TogglConnection) listTimeEntries(start time.Time, end time.Time) ([]togglTimeEntry, error) {
+	var togglEntries []togglTimeEntry
+	fetchedIDs := make(map[int64]bool)
+	for {
+		query := url.Values{}
+		query.Set("start_date", start.Format(time.RFC3339))
+		query.Set("end_date", end.Format(time.RFC3339))
+		// meta includes the project name in the entries
+		query.Set("meta", "true")
+
+		req, err := http.NewRequest("GET", m.config.GetBaseURL()+"/me/time_entries?"+query.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(m.config.APIToken, "api_token")
+
+		var page []togglTimeEntry
+		if _, err = getJSON(m.client, req, &page); err != nil {
+			return nil, err
+		}
+
+		earliestStart := end
+		for _, togglEntry := range page {
+			if fetchedIDs[togglEntry.ID] {
+				continue
+			}
+			fetchedIDs[togglEntry.ID] = true
+			togglEntries = append(togglEntries, togglEntry)
+			if togglEntry.Start.Before(earliestStart) {
+				earliestStart = togglEntry.Start
+			}
+		}
+		if !earliestStart.Before(end) || !earliestStart.After(start) {
+			return togglEntries, nil
+		}
+		// The entries started in the same second as the earliest one are fetched again, in case they were cut off
+		end = earliestStart.Add(time.Second)
+	}
+}
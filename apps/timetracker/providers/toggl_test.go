@@ -0,0 +1,94 @@
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+)
+
+const togglTestToken = "secret-token"
+
+// togglTestPageLimit is the number of the time entries served per request, the API caps it without any pagination
+const togglTestPageLimit = 2
+
+func newTogglTestServer(t *testing.T, entries []togglTimeEntry) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token, _, ok := r.BasicAuth(); !ok || token != togglTestToken {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/me/time_entries" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		start, err := time.Parse(time.RFC3339, r.URL.Query().Get("start_date"))
+		if err != nil {
+			t.Errorf("invalid start date %q", r.URL.Query().Get("start_date"))
+		}
+		end, err := time.Parse(time.RFC3339, r.URL.Query().Get("end_date"))
+		if err != nil {
+			t.Errorf("invalid end date %q", r.URL.Query().Get("end_date"))
+		}
+
+		// The latest entries are served first
+		var page []togglTimeEntry
+		for _, entry := range entries {
+			if !entry.Start.Before(start) && entry.Start.Before(end) {
+				page = append(page, entry)
+			}
+		}
+		sort.Slice(page, func(i, j int) bool { return page[i].Start.After(page[j].Start) })
+		if len(page) > togglTestPageLimit {
+			page = page[:togglTestPageLimit]
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func TestTogglGetProjectTimeLogs(t *testing.T) {
+	firstDay := time.Date(2018, 6, 4, 0, 0, 0, 0, time.UTC)
+	entries := []togglTimeEntry{
+		{ID: 1, Description: "REFLECT-1 login page", Duration: 1800, ProjectName: "Reflect", Start: firstDay.Add(9 * time.Hour)},
+		{ID: 2, Description: "REFLECT-1 review", Duration: 900, ProjectName: "Reflect", Start: firstDay.Add(9 * time.Hour)},
+		{ID: 3, Description: "REFLECT-2 broken link", Duration: 3600, ProjectName: "Reflect", Start: firstDay.Add(30 * time.Hour)},
+		{ID: 4, Description: "REFLECT-1 fixes", Duration: 600, ProjectName: "Reflect", Start: firstDay.Add(50 * time.Hour)},
+		{ID: 5, Description: "REFLECT-3 other project", Duration: 600, ProjectName: "Other", Start: firstDay.Add(51 * time.Hour)},
+		{ID: 6, Description: "REFLECT-2 running", Duration: -1528100000, ProjectName: "Reflect", Start: firstDay.Add(52 * time.Hour)},
+		{ID: 7, Description: "REFLECT-2 after the sprint", Duration: 600, ProjectName: "Reflect", Start: firstDay.Add(80 * time.Hour)},
+	}
+	server := newTogglTestServer(t, entries)
+	defer server.Close()
+
+	connection := (&TogglTimeProvider{}).New(map[string]interface{}{"apiToken": togglTestToken, "baseURL": server.URL})
+	timeLogs, err := connection.GetProjectTimeLogs("Reflect", firstDay, firstDay.AddDate(0, 0, 2))
+	if err != nil {
+		t.Fatalf("Error in getting the time logs - %s", err)
+	}
+
+	minutes := make(map[string]uint)
+	for _, timeLog := range timeLogs {
+		minutes[timeLog.TaskKey+" "+timeLog.Date.Format("2006-01-02")] += timeLog.Minutes
+	}
+	expectedMinutes := map[string]uint{
+		"REFLECT-1 2018-06-04": 45,
+		"REFLECT-2 2018-06-05": 60,
+		"REFLECT-1 2018-06-06": 10,
+	}
+	if len(minutes) != len(expectedMinutes) {
+		t.Fatalf("All the entries beyond the page limit should be fetched - %+v", timeLogs)
+	}
+	for key, expected := range expectedMinutes {
+		if minutes[key] != expected {
+			t.Errorf("%s: expected %d minutes, got %d", key, expected, minutes[key])
+		}
+	}
+
+	// The failure is returned instead of no time logs, so that the logged time is not reset
+	unauthorized := (&TogglTimeProvider{}).New(map[string]interface{}{"apiToken": "wrong-token", "baseURL": server.URL})
+	if timeLogs, err = unauthorized.GetProjectTimeLogs("Reflect", firstDay, firstDay); err == nil {
+		t.Fatalf("The fetch with invalid credentials should fail - %+v", timeLogs)
+	}
+}
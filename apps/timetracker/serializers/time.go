@@ -1,9 +1,30 @@
 package serializers
 
+import "time"
+
 //TimeLog ...
 type TimeLog struct {
 	Project string
 	TaskKey string
 	Logger  string
 	Minutes uint
+	// Date is the day on which the time was logged, it is zero if the time tracker does not report the days
+	Date time.Time
+}
+
+// UploadedTimeLog is a row of an uploaded time log file
+type UploadedTimeLog struct {
+	Row     int
+	TaskKey string
+	Logger  string // Email of the member who logged the time
+	Minutes uint
+	Date    time.Time
+}
+
+// TimeLogRowError is the validation error of a row of an uploaded time log file
+type TimeLogRowError struct {
+	Row     int
+	TaskKey string
+	Logger  string
+	Error   string
 }
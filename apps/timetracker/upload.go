@@ -0,0 +1,157 @@
+package timetracker
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/timetracker/serializers"
+	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// Columns of an uploaded time log file
+const (
+	UploadColumnTaskKey = "taskkey"
+	UploadColumnLogger  = "logger"
+	UploadColumnMinutes = "minutes"
+	UploadColumnDate    = "date"
+)
+
+// UploadColumns ...
+var UploadColumns = []string{UploadColumnTaskKey, UploadColumnLogger, UploadColumnMinutes, UploadColumnDate}
+
+// ParseTimeLogFile parses an uploaded CSV or JSON time log file, the format is decided by the file extension.
+// The rows which could not be parsed are returned as the row errors, while the error is returned if the file
+// itself is invalid.
+//
+// A CSV file should have a header row with the 'TaskKey, Logger, Minutes, Date' columns (in any order), while a
+// JSON file should have a list of objects with the same keys. The dates are in the 'YYYY-MM-DD' format.
+func ParseTimeLogFile(fileName string, file io.Reader) ([]serializers.UploadedTimeLog, []serializers.TimeLogRowError, error) {
+	var rows []map[string]string
+	var err error
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		rows, err = readCSVTimeLogRows(file)
+	case ".json":
+		rows, err = readJSONTimeLogRows(file)
+	default:
+		return nil, nil, errors.New("only csv and json files are supported")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var timeLogs []serializers.UploadedTimeLog
+	var rowErrors []serializers.TimeLogRowError
+	for index, row := range rows {
+		// The rows are numbered from 1, excluding the header row of the CSV files
+		timeLog, err := parseTimeLogRow(index+1, row)
+		if err != nil {
+			rowErrors = append(rowErrors, serializers.TimeLogRowError{
+				Row:     index + 1,
+				TaskKey: row[UploadColumnTaskKey],
+				Logger:  row[UploadColumnLogger],
+				Error:   err.Error(),
+			})
+			continue
+		}
+		timeLogs = append(timeLogs, timeLog)
+	}
+	return timeLogs, rowErrors, nil
+}
+
+func parseTimeLogRow(rowNumber int, row map[string]string) (timeLog serializers.UploadedTimeLog, err error) {
+	timeLog.Row = rowNumber
+	timeLog.TaskKey = strings.TrimSpace(row[UploadColumnTaskKey])
+	timeLog.Logger = strings.ToLower(strings.TrimSpace(row[UploadColumnLogger]))
+
+	if timeLog.TaskKey == "" {
+		return timeLog, errors.New("task key is required")
+	}
+	if timeLog.Logger == "" {
+		return timeLog, errors.New("logger is required")
+	}
+
+	minutes, err := strconv.ParseFloat(strings.TrimSpace(row[UploadColumnMinutes]), 64)
+	if err != nil || minutes < 0 {
+		return timeLog, fmt.Errorf("invalid minutes %q", row[UploadColumnMinutes])
+	}
+	timeLog.Minutes = uint(minutes)
+
+	timeLog.Date, err = time.Parse(constants.CustomDateFormat, strings.TrimSpace(row[UploadColumnDate]))
+	if err != nil {
+		return timeLog, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", row[UploadColumnDate])
+	}
+	return timeLog, nil
+}
+
+func readCSVTimeLogRows(file io.Reader) ([]map[string]string, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv file: %s", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("csv file should have a header row")
+	}
+
+	header := records[0]
+	for index, column := range header {
+		header[index] = normalizeUploadColumn(column)
+	}
+	for _, column := range UploadColumns {
+		if !utils.StringInSlice(column, header) {
+			return nil, fmt.Errorf("csv file should have the %s column", column)
+		}
+	}
+
+	var rows []map[string]string
+	for _, record := range records[1:] {
+		row := make(map[string]string)
+		for index, value := range record {
+			row[header[index]] = value
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func readJSONTimeLogRows(file io.Reader) ([]map[string]string, error) {
+	content, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []map[string]interface{}
+	if err = json.Unmarshal(content, &records); err != nil {
+		return nil, errors.New("json file should have a list of time logs")
+	}
+
+	var rows []map[string]string
+	for _, record := range records {
+		row := make(map[string]string)
+		for key, value := range record {
+			if value != nil {
+				row[normalizeUploadColumn(key)] = fmt.Sprint(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// normalizeUploadColumn makes the column names case and separator insensitive, eg. 'Task Key' and 'task_key'
+func normalizeUploadColumn(column string) string {
+	return strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(column)))
+}
@@ -0,0 +1,49 @@
+package timetracker
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTimeLogFileCSV(t *testing.T) {
+	file := "Task Key,Logger,Minutes,Date\n" +
+		"REFLECT-1, Jane@Example.com ,90,2018-06-04\n" +
+		"REFLECT-2,jane@example.com,abc,2018-06-05\n" +
+		",jane@example.com,30,2018-06-05\n"
+
+	timeLogs, rowErrors, err := ParseTimeLogFile("logs.CSV", strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Error in parsing the file - %s", err)
+	}
+	if len(timeLogs) != 1 || timeLogs[0].TaskKey != "REFLECT-1" || timeLogs[0].Logger != "jane@example.com" ||
+		timeLogs[0].Minutes != 90 || timeLogs[0].Date.Format("2006-01-02") != "2018-06-04" {
+		t.Fatalf("Unexpected time logs - %+v", timeLogs)
+	}
+	if len(rowErrors) != 2 || rowErrors[0].Row != 2 || rowErrors[1].Row != 3 {
+		t.Fatalf("Unexpected row errors - %+v", rowErrors)
+	}
+
+	if _, _, err = ParseTimeLogFile("logs.csv", strings.NewReader("TaskKey,Minutes\n")); err == nil {
+		t.Fatalf("File without the required columns should fail")
+	}
+}
+
+func TestParseTimeLogFileJSON(t *testing.T) {
+	file := `[{"taskKey": "REFLECT-1", "logger": "jane@example.com", "minutes": 45, "date": "2018-06-04"},
+		{"taskKey": "REFLECT-2", "logger": "jane@example.com", "minutes": 45, "date": "04/06/2018"}]`
+
+	timeLogs, rowErrors, err := ParseTimeLogFile("logs.json", strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Error in parsing the file - %s", err)
+	}
+	if len(timeLogs) != 1 || timeLogs[0].Minutes != 45 {
+		t.Fatalf("Unexpected time logs - %+v", timeLogs)
+	}
+	if len(rowErrors) != 1 || rowErrors[0].TaskKey != "REFLECT-2" {
+		t.Fatalf("Unexpected row errors - %+v", rowErrors)
+	}
+
+	if _, _, err = ParseTimeLogFile("logs.xlsx", strings.NewReader(file)); err == nil {
+		t.Fatalf("Unsupported file types should fail")
+	}
+}
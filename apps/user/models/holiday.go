@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Holiday represents a holiday of a team, the holidays are excluded from the working days of the sprints of the
+// retrospectives of the team
+type Holiday struct {
+	gorm.Model
+	Team   Team
+	TeamID uint      `gorm:"not null; index:idx_holiday_team_date"`
+	Date   time.Time `gorm:"type:date; not null; index:idx_holiday_team_date"`
+	Name   string    `gorm:"type:varchar(255); not null; default:''"`
+}
+
+// GetTeamHolidayDates returns the dates of the holidays of the team between the given dates (inclusive)
+func GetTeamHolidayDates(db *gorm.DB, teamID uint, startDate time.Time, endDate time.Time) ([]time.Time, error) {
+	var dates []time.Time
+	err := db.Model(&Holiday{}).
+		Where("holidays.deleted_at IS NULL").
+		Where("team_id = ?", teamID).
+		Where("date BETWEEN ? AND ?", startDate.Format("2006-01-02"), endDate.Format("2006-01-02")).
+		Order("date").
+		Pluck("DISTINCT date", &dates).Error
+	return dates, err
+}
@@ -3,6 +3,8 @@ package models
 import (
 	"github.com/jinzhu/gorm"
 	"github.com/qor/admin"
+
+	"github.com/iReflect/reflect-app/libs/utils"
 )
 
 // Team represent a team/project comprising a set of user
@@ -11,7 +13,24 @@ type Team struct {
 	Name        string `gorm:"type:varchar(64);not null"`
 	Description string `gorm:"type:text"`
 	Active      bool   `gorm:"default:true; not null"`
-	Users       []User
+	// WorkWeek is the comma separated list of the working weekdays of the team (eg. 'sun,mon,tue,wed,thu')
+	WorkWeek string `gorm:"type:varchar(50); not null; default:'mon,tue,wed,thu,fri'"`
+	Users    []User
+}
+
+// GetWorkWeek returns the work week of the team, the default work week is returned if the team has an invalid one
+func (team Team) GetWorkWeek() utils.WorkWeek {
+	workWeek, err := utils.ParseWorkWeek(team.WorkWeek)
+	if err != nil {
+		workWeek, _ = utils.ParseWorkWeek(utils.DefaultWorkWeek)
+	}
+	return workWeek
+}
+
+// BeforeSave ...
+func (team *Team) BeforeSave(db *gorm.DB) (err error) {
+	_, err = utils.ParseWorkWeek(team.WorkWeek)
+	return err
 }
 
 // RegisterTeamToAdmin ...
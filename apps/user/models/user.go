@@ -1,8 +1,10 @@
 package models
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/qor/admin"
@@ -24,6 +26,9 @@ type User struct {
 	IsAdmin            bool         `gorm:"default:false; not null"`
 	Teams              []Team
 	Profiles           []UserProfile
+	// TimeZone is the time zone in which the time logs of the user are tracked, the time tracker time zone is used
+	// if it is not set
+	TimeZone string `gorm:"type:varchar(50); not null; default:''"`
 }
 
 // Stringify ...
@@ -31,6 +36,27 @@ func (user User) Stringify() string {
 	return fmt.Sprintf("%v %v", user.FirstName, user.LastName)
 }
 
+// GetTimeZoneLocation returns the location of the time zone of the user, or the given default location if the
+// user has no time zone
+func (user User) GetTimeZoneLocation(defaultLocation *time.Location) *time.Location {
+	if user.TimeZone == "" {
+		return defaultLocation
+	}
+	location, err := time.LoadLocation(user.TimeZone)
+	if err != nil {
+		return defaultLocation
+	}
+	return location
+}
+
+// BeforeSave ...
+func (user *User) BeforeSave(db *gorm.DB) (err error) {
+	if _, err = time.LoadLocation(user.TimeZone); err != nil {
+		return errors.New("invalid time zone")
+	}
+	return nil
+}
+
 // DisplayName ...
 func (user User) DisplayName() string {
 	return user.FirstName + " " + user.LastName
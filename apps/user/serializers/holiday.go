@@ -0,0 +1,33 @@
+package serializers
+
+import (
+	"time"
+)
+
+// Holiday ...
+type Holiday struct {
+	ID     uint
+	TeamID uint
+	Date   time.Time
+	Name   string
+}
+
+// HolidaysSerializer ...
+type HolidaysSerializer struct {
+	Holidays []Holiday
+}
+
+// HolidayCreateSerializer ...
+type HolidayCreateSerializer struct {
+	// Date is in the 'YYYY-MM-DD' format
+	Date string `json:"date" binding:"required"`
+	Name string `json:"name" binding:"required"`
+}
+
+// HolidayImportSerializer is the result of the import of the holidays from an iCalendar file, the holidays on the
+// dates which already are the holidays of the team are skipped
+type HolidayImportSerializer struct {
+	Imported int
+	Skipped  int
+	Holidays []Holiday
+}
@@ -15,6 +15,7 @@ type User struct {
 	FirstName string
 	LastName  string
 	Active    bool
+	TimeZone  string
 }
 
 // Team ...
@@ -23,6 +24,7 @@ type Team struct {
 	Name        string
 	Description string
 	Active      bool
+	WorkWeek    string
 }
 
 // TeamsSerializer ...
@@ -53,3 +55,15 @@ type Recover struct {
 	OTP      string `json:"otp"`
 	Password string `json:"password"`
 }
+
+// UserTimeZoneSerializer ...
+type UserTimeZoneSerializer struct {
+	// TimeZone is an IANA time zone name (eg. 'Europe/Berlin'), an empty time zone resets it to the default
+	TimeZone string `json:"timeZone"`
+}
+
+// TeamWorkWeekSerializer ...
+type TeamWorkWeekSerializer struct {
+	// WorkWeek is the comma separated list of the working weekdays (eg. 'sun,mon,tue,wed,thu')
+	WorkWeek string `json:"workWeek" binding:"required"`
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
+	userSerializers "github.com/iReflect/reflect-app/apps/user/serializers"
+	"github.com/iReflect/reflect-app/libs/ical"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// HolidayList returns the holidays of the team, only of the given year if any
+func (service TeamService) HolidayList(teamID string, userID uint, isAdmin bool, year string) (
+	holidays *userSerializers.HolidaysSerializer, status int, err error) {
+	db := service.DB
+	holidays = new(userSerializers.HolidaysSerializer)
+
+	if _, status, err = service.getAccessibleTeam(teamID, userID, isAdmin); err != nil {
+		return nil, status, err
+	}
+
+	filterQuery := db.Model(&userModels.Holiday{}).
+		Where("holidays.deleted_at IS NULL").
+		Where("team_id = ?", teamID).
+		Order("date, id")
+	if year != "" {
+		intYear, err := strconv.Atoi(year)
+		if err != nil {
+			return nil, http.StatusBadRequest, errors.New("invalid year")
+		}
+		filterQuery = filterQuery.Where("EXTRACT(YEAR FROM date) = ?", intYear)
+	}
+
+	if err = filterQuery.Scan(&holidays.Holidays).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get holidays")
+	}
+	return holidays, http.StatusOK, nil
+}
+
+// AddHoliday adds a holiday to the team
+func (service TeamService) AddHoliday(teamID string, userID uint, isAdmin bool,
+	holidayData userSerializers.HolidayCreateSerializer) (*userSerializers.Holiday, int, error) {
+	db := service.DB
+
+	team, status, err := service.getAccessibleTeam(teamID, userID, isAdmin)
+	if err != nil {
+		return nil, status, err
+	}
+
+	date, err := time.Parse("2006-01-02", holidayData.Date)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("date should be in the YYYY-MM-DD format")
+	}
+
+	holidayDates, err := service.getHolidayDates(teamID)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to add holiday")
+	}
+	if holidayDates[date.Format("2006-01-02")] {
+		return nil, http.StatusBadRequest, errors.New("team already has a holiday on the date")
+	}
+
+	holiday := userModels.Holiday{TeamID: team.ID, Date: date, Name: holidayData.Name}
+	if err = db.Create(&holiday).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to add holiday")
+	}
+
+	return &userSerializers.Holiday{
+		ID:     holiday.ID,
+		TeamID: holiday.TeamID,
+		Date:   holiday.Date,
+		Name:   holiday.Name,
+	}, http.StatusCreated, nil
+}
+
+// RemoveHoliday removes a holiday of the team
+func (service TeamService) RemoveHoliday(teamID string, holidayID string, userID uint, isAdmin bool) (int, error) {
+	db := service.DB
+
+	if _, status, err := service.getAccessibleTeam(teamID, userID, isAdmin); err != nil {
+		return status, err
+	}
+
+	result := db.Where("holidays.deleted_at IS NULL").
+		Where("team_id = ?", teamID).
+		Where("id = ?", holidayID).
+		Delete(&userModels.Holiday{})
+	if result.Error != nil {
+		utils.LogToSentry(result.Error)
+		return http.StatusInternalServerError, errors.New("failed to remove holiday")
+	}
+	if result.RowsAffected == 0 {
+		return http.StatusNotFound, errors.New("holiday not found")
+	}
+	return http.StatusNoContent, nil
+}
+
+// ImportHolidays adds the events of the uploaded iCalendar (.ics) file as the holidays of the team, an event spanning
+// multiple days is added as a holiday on each of the days
+func (service TeamService) ImportHolidays(teamID string, userID uint, isAdmin bool, file io.Reader) (
+	*userSerializers.HolidayImportSerializer, int, error) {
+	db := service.DB
+
+	team, status, err := service.getAccessibleTeam(teamID, userID, isAdmin)
+	if err != nil {
+		return nil, status, err
+	}
+
+	events, err := ical.ParseEvents(file)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("invalid holiday calendar: %s", err)
+	}
+
+	holidayDates, err := service.getHolidayDates(teamID)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to import holidays")
+	}
+
+	report := &userSerializers.HolidayImportSerializer{Holidays: []userSerializers.Holiday{}}
+
+	tx := db.Begin()
+	for _, event := range events {
+		for _, date := range event.Dates() {
+			if holidayDates[date.Format("2006-01-02")] {
+				report.Skipped++
+				continue
+			}
+			holiday := userModels.Holiday{TeamID: team.ID, Date: date, Name: event.Summary}
+			if err = tx.Create(&holiday).Error; err != nil {
+				tx.Rollback()
+				utils.LogToSentry(err)
+				return nil, http.StatusInternalServerError, errors.New("failed to import holidays")
+			}
+			holidayDates[date.Format("2006-01-02")] = true
+			report.Imported++
+			report.Holidays = append(report.Holidays, userSerializers.Holiday{
+				ID:     holiday.ID,
+				TeamID: holiday.TeamID,
+				Date:   holiday.Date,
+				Name:   holiday.Name,
+			})
+		}
+	}
+	if err = tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to import holidays")
+	}
+
+	return report, http.StatusOK, nil
+}
+
+// getAccessibleTeam returns the team if the user is an active member of the team or an admin
+func (service TeamService) getAccessibleTeam(teamID string, userID uint, isAdmin bool) (
+	team userModels.Team, status int, err error) {
+	db := service.DB
+
+	err = db.Model(&userModels.Team{}).
+		Where("teams.deleted_at IS NULL").
+		Where("id = ?", teamID).
+		Find(&team).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return team, http.StatusNotFound, errors.New("team not found")
+		}
+		utils.LogToSentry(err)
+		return team, http.StatusInternalServerError, errors.New("failed to get team")
+	}
+
+	if !isAdmin && !utils.UIntInSlice(userID, service.getTeamMemberIDs(teamID, true)) {
+		return team, http.StatusForbidden, errors.New("must be a member of the team")
+	}
+	return team, http.StatusOK, nil
+}
+
+// getHolidayDates returns the set of the dates (in the 'YYYY-MM-DD' format) of the holidays of the team
+func (service TeamService) getHolidayDates(teamID string) (map[string]bool, error) {
+	db := service.DB
+	var dates []time.Time
+
+	err := db.Model(&userModels.Holiday{}).
+		Where("holidays.deleted_at IS NULL").
+		Where("team_id = ?", teamID).
+		Pluck("date", &dates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	holidayDates := make(map[string]bool)
+	for _, date := range dates {
+		holidayDates[date.Format("2006-01-02")] = true
+	}
+	return holidayDates, nil
+}
@@ -83,3 +83,33 @@ func (service TeamService) getTeamMemberIDs(teamID string, onlyActive bool) []ui
 
 	return memberIds
 }
+
+// UpdateWorkWeek updates the work week of the team
+func (service TeamService) UpdateWorkWeek(teamID string, userID uint, isAdmin bool,
+	workWeekData userSerializers.TeamWorkWeekSerializer) (*userSerializers.Team, int, error) {
+	db := service.DB
+
+	team, status, err := service.getAccessibleTeam(teamID, userID, isAdmin)
+	if err != nil {
+		return nil, status, err
+	}
+
+	workWeek, err := utils.ParseWorkWeek(workWeekData.WorkWeek)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	team.WorkWeek = workWeek.String()
+	if err = db.Model(&team).UpdateColumn("work_week", team.WorkWeek).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to update work week")
+	}
+
+	return &userSerializers.Team{
+		ID:          team.ID,
+		Name:        team.Name,
+		Description: team.Description,
+		Active:      team.Active,
+		WorkWeek:    team.WorkWeek,
+	}, http.StatusOK, nil
+}
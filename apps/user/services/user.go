@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
+	userSerializers "github.com/iReflect/reflect-app/apps/user/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// UserService ...
+type UserService struct {
+	DB *gorm.DB
+}
+
+// UpdateTimeZone updates the time zone of the user
+func (service UserService) UpdateTimeZone(userID uint,
+	timeZoneData userSerializers.UserTimeZoneSerializer) (*userSerializers.User, int, error) {
+	db := service.DB
+	var user userModels.User
+
+	if _, err := time.LoadLocation(timeZoneData.TimeZone); err != nil {
+		return nil, http.StatusBadRequest, errors.New("invalid time zone")
+	}
+
+	err := db.Model(&userModels.User{}).
+		Where("users.deleted_at IS NULL").
+		Where("id = ?", userID).
+		Find(&user).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("user not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to update time zone")
+	}
+
+	if err = db.Model(&user).UpdateColumn("time_zone", timeZoneData.TimeZone).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to update time zone")
+	}
+
+	return &userSerializers.User{
+		ID:        user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Active:    user.Active,
+		TimeZone:  timeZoneData.TimeZone,
+	}, http.StatusOK, nil
+}
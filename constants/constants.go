@@ -56,6 +56,10 @@ const (
 	UpdatedSprintTask                  = "UpdatedSprintTask"
 	MarkDoneSprintTask                 = "MarkDoneSprintTask"
 	MarkUndoneSprintTask               = "MarkUndoneSprintTask"
+	ImportedTimeLogs                   = "ImportedTimeLogs"
+	UpdatedSyncSchedule                = "UpdatedSyncSchedule"
+	RetriedSprintSync                  = "RetriedSprintSync"
+	UpdatedPointAllocation             = "UpdatedPointAllocation"
 )
 
 // ActionTypeMap is types of Action of Trail model used in adding trails.
@@ -83,6 +87,10 @@ var ActionTypeMap = map[ActionType]string{
 	UpdatedSprintTask:       "Updated the task in sprint",
 	MarkDoneSprintTask:      "Marked done a task in sprint",
 	MarkUndoneSprintTask:    "Marked undone a task in sprint",
+	ImportedTimeLogs:        "Imported time logs in sprint",
+	UpdatedSyncSchedule:     "Updated the sync schedule of retrospective",
+	RetriedSprintSync:       "Retried the sprint sync",
+	UpdatedPointAllocation:  "Updated the point allocation strategy of retrospective",
 }
 
 // constants for error messages
@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	feedbackServices "github.com/iReflect/reflect-app/apps/feedback/services"
+	retrospectiveServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+)
+
+// FeedbackFormController ...
+type FeedbackFormController struct {
+	FeedbackFormService feedbackServices.FeedbackFormService
+	PermissionService   retrospectiveServices.PermissionService
+}
+
+// Routes for Feedback Forms, the feedback forms are managed only by the admins
+func (ctrl FeedbackFormController) Routes(r *gin.RouterGroup) {
+	r.Use(ctrl.adminOnly)
+	r.GET("/:feedbackFormID/versions/", ctrl.ListVersions)
+	r.GET("/:feedbackFormID/versions/:version/", ctrl.GetVersion)
+	r.GET("/:feedbackFormID/diff/", ctrl.Diff)
+	r.POST("/:feedbackFormID/publish/", ctrl.Publish)
+	r.POST("/:feedbackFormID/archive/", ctrl.Archive)
+}
+
+func (ctrl FeedbackFormController) adminOnly(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	if !ctrl.PermissionService.IsUserAdmin(userID.(uint)) {
+		c.AbortWithStatus(http.StatusForbidden)
+	}
+}
+
+// ListVersions lists the published versions of the feedback form
+func (ctrl FeedbackFormController) ListVersions(c *gin.Context) {
+	feedbackFormID := c.Param("feedbackFormID")
+
+	versions, status, err := ctrl.FeedbackFormService.ListVersions(feedbackFormID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, versions)
+}
+
+// GetVersion returns the published version of the feedback form with its contents
+func (ctrl FeedbackFormController) GetVersion(c *gin.Context) {
+	feedbackFormID := c.Param("feedbackFormID")
+	version := c.Param("version")
+
+	response, status, err := ctrl.FeedbackFormService.GetVersion(feedbackFormID, version)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// Diff returns the changes between the versions of the feedback form
+func (ctrl FeedbackFormController) Diff(c *gin.Context) {
+	feedbackFormID := c.Param("feedbackFormID")
+	fromVersion := c.Query("from")
+	toVersion := c.Query("to")
+
+	response, status, err := ctrl.FeedbackFormService.Diff(feedbackFormID, fromVersion, toVersion)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// Publish publishes the feedback form as its new version
+func (ctrl FeedbackFormController) Publish(c *gin.Context) {
+	feedbackFormID := c.Param("feedbackFormID")
+
+	response, status, err := ctrl.FeedbackFormService.Publish(feedbackFormID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// Archive archives the feedback form
+func (ctrl FeedbackFormController) Archive(c *gin.Context) {
+	feedbackFormID := c.Param("feedbackFormID")
+
+	status, err := ctrl.FeedbackFormService.Archive(feedbackFormID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, nil)
+}
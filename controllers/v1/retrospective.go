@@ -15,6 +15,8 @@ type RetrospectiveController struct {
 	RetrospectiveService retrospectiveService.RetrospectiveService
 	PermissionService    retrospectiveService.PermissionService
 	TrailService         retrospectiveService.TrailService
+	WebhookService       retrospectiveService.TaskTrackerWebhookService
+	SprintService        retrospectiveService.SprintService
 }
 
 // Routes for Retrospective
@@ -23,7 +25,13 @@ func (ctrl RetrospectiveController) Routes(r *gin.RouterGroup) {
 	r.GET("/:retroID/", ctrl.Get)
 	r.GET("/:retroID/team-members/", ctrl.GetTeamMembers)
 	r.GET("/:retroID/latest-sprint/", ctrl.GetLatestSprint)
+	r.GET("/:retroID/velocity/", ctrl.GetVelocity)
+	r.GET("/:retroID/estimation-accuracy/", ctrl.GetEstimationAccuracy)
+	r.GET("/:retroID/epics/", ctrl.GetEpicRollups)
 	r.POST("/", ctrl.Create)
+	r.POST("/:retroID/webhook-secret/", ctrl.RotateWebhookSecret)
+	r.PUT("/:retroID/sync-schedule/", ctrl.UpdateSyncSchedule)
+	r.PUT("/:retroID/point-allocation-strategy/", ctrl.UpdatePointAllocationStrategy)
 }
 
 // List Retrospectives
@@ -101,6 +109,69 @@ func (ctrl RetrospectiveController) GetLatestSprint(c *gin.Context) {
 	c.JSON(status, sprint)
 }
 
+// GetVelocity returns the velocity of the last completed sprints of the retrospective along with the forecast of
+// the next sprints
+func (ctrl RetrospectiveController) GetVelocity(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessRetro(retroID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	velocity, status, err := ctrl.SprintService.GetVelocity(
+		retroID,
+		c.Query("sprints"),
+		c.Query("forecastSprints"),
+		c.Query("backlog"))
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, velocity)
+}
+
+// GetEstimationAccuracy returns the estimation accuracy of the tasks completed in the last sprints of the
+// retrospective, along with the suggested hours per story point
+func (ctrl RetrospectiveController) GetEstimationAccuracy(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessRetro(retroID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	report, status, err := ctrl.RetrospectiveService.GetEstimationAccuracy(retroID, c.Query("sprints"))
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, report)
+}
+
+// GetEpicRollups returns the progress of the epics of the retrospective across its sprints
+func (ctrl RetrospectiveController) GetEpicRollups(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessRetro(retroID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	report, status, err := ctrl.RetrospectiveService.GetEpicRollups(retroID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, report)
+}
+
 // Create Retrospective
 func (ctrl RetrospectiveController) Create(c *gin.Context) {
 	userID, _ := c.Get("userID")
@@ -124,3 +195,85 @@ func (ctrl RetrospectiveController) Create(c *gin.Context) {
 
 	c.JSON(status, retro)
 }
+
+// RotateWebhookSecret generates a new secret for the task tracker webhooks of the retrospective, only the creator of
+// the retrospective or an admin can rotate the secret
+func (ctrl RetrospectiveController) RotateWebhookSecret(c *gin.Context) {
+	retroID := c.Param("retroID")
+	userID, _ := c.Get("userID")
+
+	if !ctrl.PermissionService.UserCanEditRetro(retroID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.WebhookService.RotateSecret(retroID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// UpdateSyncSchedule updates the schedule for the automatic sync of the active sprints of the retrospective
+func (ctrl RetrospectiveController) UpdateSyncSchedule(c *gin.Context) {
+	retroID := c.Param("retroID")
+	userID, _ := c.Get("userID")
+
+	if !ctrl.PermissionService.UserCanAccessRetro(retroID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	var scheduleData retrospectiveSerializers.RetrospectiveSyncScheduleSerializer
+	if err := c.BindJSON(&scheduleData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	response, status, err := ctrl.RetrospectiveService.UpdateSyncSchedule(retroID, &scheduleData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.UpdatedSyncSchedule,
+		constants.Retrospective,
+		retroID,
+		userID.(uint))
+
+	c.JSON(status, response)
+}
+
+// UpdatePointAllocationStrategy updates the strategy used to split the points of the tasks among the sprint members
+func (ctrl RetrospectiveController) UpdatePointAllocationStrategy(c *gin.Context) {
+	retroID := c.Param("retroID")
+	userID, _ := c.Get("userID")
+
+	if !ctrl.PermissionService.UserCanAccessRetro(retroID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	var strategyData retrospectiveSerializers.RetrospectivePointAllocationSerializer
+	if err := c.BindJSON(&strategyData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	response, status, err := ctrl.RetrospectiveService.UpdatePointAllocationStrategy(retroID, &strategyData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.UpdatedPointAllocation,
+		constants.Retrospective,
+		retroID,
+		userID.(uint))
+
+	c.JSON(status, response)
+}
@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	feedbackSerializers "github.com/iReflect/reflect-app/apps/feedback/serializers"
+	feedbackServices "github.com/iReflect/reflect-app/apps/feedback/services"
+	retrospectiveServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+)
+
+// ReviewCycleController ...
+type ReviewCycleController struct {
+	ReviewCycleService feedbackServices.ReviewCycleService
+	PermissionService  retrospectiveServices.PermissionService
+}
+
+// Routes for Review Cycles
+func (ctrl ReviewCycleController) Routes(r *gin.RouterGroup) {
+	r.GET("/", ctrl.List)
+	r.GET("/:cycleID/nominations/", ctrl.ListNominations)
+	r.POST("/:cycleID/nominations/", ctrl.Nominate)
+	r.PUT("/:cycleID/subjects/:userID/nominations/", ctrl.ReviewNominations)
+	r.GET("/:cycleID/subjects/:userID/results/", ctrl.GetResults)
+}
+
+// List lists the review cycles of the teams of the user
+func (ctrl ReviewCycleController) List(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	response, status, err := ctrl.ReviewCycleService.List(userID.(uint))
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// ListNominations lists the nominations of the review cycle visible to the user
+func (ctrl ReviewCycleController) ListNominations(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	cycleID := c.Param("cycleID")
+	isAdmin := ctrl.PermissionService.IsUserAdmin(userID.(uint))
+
+	response, status, err := ctrl.ReviewCycleService.ListNominations(cycleID, userID.(uint), isAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// Nominate nominates the reviewers of the feedback of the user in the review cycle
+func (ctrl ReviewCycleController) Nominate(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	cycleID := c.Param("cycleID")
+
+	nominationData := feedbackSerializers.NominateReviewersSerializer{}
+	if err := c.BindJSON(&nominationData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	response, status, err := ctrl.ReviewCycleService.Nominate(cycleID, userID.(uint), nominationData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// ReviewNominations approves and rejects the nominations of a member in the review cycle
+func (ctrl ReviewCycleController) ReviewNominations(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	cycleID := c.Param("cycleID")
+	forUserID := c.Param("userID")
+	isAdmin := ctrl.PermissionService.IsUserAdmin(userID.(uint))
+
+	reviewData := feedbackSerializers.ReviewNominationsSerializer{}
+	if err := c.BindJSON(&reviewData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	response, status, err := ctrl.ReviewCycleService.ReviewNominations(cycleID, forUserID, userID.(uint), isAdmin,
+		reviewData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// GetResults returns the aggregated results of the review of a member in the review cycle
+func (ctrl ReviewCycleController) GetResults(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	cycleID := c.Param("cycleID")
+	forUserID := c.Param("userID")
+	isAdmin := ctrl.PermissionService.IsUserAdmin(userID.(uint))
+
+	response, status, err := ctrl.ReviewCycleService.GetResults(cycleID, forUserID, userID.(uint), isAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
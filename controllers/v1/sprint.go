@@ -29,8 +29,16 @@ func (ctrl SprintController) Routes(r *gin.RouterGroup) {
 	r.POST("/:sprintID/activate/", ctrl.ActivateSprint)
 	r.POST("/:sprintID/freeze/", ctrl.FreezeSprint)
 	r.POST("/:sprintID/process/", ctrl.Process)
+	r.GET("/:sprintID/sync-runs/", ctrl.GetSyncRuns)
+	r.POST("/:sprintID/sync-runs/retry/", ctrl.RetrySync)
+	r.POST("/:sprintID/time-logs/import/", ctrl.ImportTimeLogs)
+	r.GET("/:sprintID/time-logs/daily/", ctrl.GetDailyTimeLogs)
 
 	r.GET("/:sprintID/member-summary/", ctrl.GetSprintMemberSummary)
+	r.GET("/:sprintID/burndown/", ctrl.GetBurndown)
+	r.GET("/:sprintID/burnup/", ctrl.GetBurnup)
+	r.GET("/:sprintID/spillover/", ctrl.GetSpilloverSummary)
+	r.GET("/:sprintID/flow-metrics/", ctrl.GetFlowMetrics)
 
 	r.GET("/:sprintID/process_history/", ctrl.GetTrails)
 }
@@ -238,7 +246,13 @@ func (ctrl SprintController) Process(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "sprint not found"})
 	}
 
-	ctrl.SprintService.QueueSprint(uint(sprintIDInt), sprint.Status == retroModels.ActiveSprint)
+	// Only the tasks updated since the last sync are fetched, unless a full resync is requested
+	fullResync := c.Query("fullResync") == "true"
+	ctrl.SprintService.QueueSprint(
+		uint(sprintIDInt),
+		sprint.Status == retroModels.ActiveSprint,
+		fullResync,
+		retroModels.ManualSyncTrigger)
 
 	ctrl.TrailService.Add(
 		constants.TriggeredSprintRefresh,
@@ -249,6 +263,195 @@ func (ctrl SprintController) Process(c *gin.Context) {
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// GetSyncRuns returns the latest sync runs of the sprint along with their errors
+func (ctrl SprintController) GetSyncRuns(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.GetSyncRuns(sprintID, retroID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// GetDailyTimeLogs returns the time logged per day on the tasks of the sprint
+func (ctrl SprintController) GetDailyTimeLogs(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.GetDailyTimeLogs(sprintID, retroID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// GetBurndown returns the day wise burndown data of the sprint
+func (ctrl SprintController) GetBurndown(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.GetSprintBurndown(sprintID, retroID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// GetBurnup returns the day wise burnup data of the sprint
+func (ctrl SprintController) GetBurnup(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.GetSprintBurnup(sprintID, retroID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// GetSpilloverSummary returns the tasks carried over into the sprint and the ones spilled over out of it
+func (ctrl SprintController) GetSpilloverSummary(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.GetSpilloverSummary(sprintID, retroID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// GetFlowMetrics returns the lead time, cycle time and time in status of the tasks of the sprint
+func (ctrl SprintController) GetFlowMetrics(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.GetFlowMetrics(sprintID, retroID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// RetrySync queues the sync of the sprint again
+func (ctrl SprintController) RetrySync(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	status, err := ctrl.SprintService.RetrySync(sprintID, retroID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.RetriedSprintSync,
+		constants.Sprint,
+		sprintID,
+		userID.(uint))
+
+	c.JSON(status, nil)
+}
+
+// ImportTimeLogs imports the time logs of the sprint members from the uploaded CSV/JSON file,
+// with dryRun=true only the validation report is returned
+func (ctrl SprintController) ImportTimeLogs(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "time log file is required"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid time log file"})
+		return
+	}
+	defer file.Close()
+
+	dryRun := c.Query("dryRun") == "true"
+
+	report, status, err := ctrl.SprintService.ImportTimeLogs(retroID, sprintID, userID.(uint), fileHeader.Filename, file, dryRun)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !dryRun && status == http.StatusOK {
+		ctrl.TrailService.Add(
+			constants.ImportedTimeLogs,
+			constants.Sprint,
+			sprintID,
+			userID.(uint))
+	}
+
+	c.JSON(status, report)
+}
+
 // GetSprintMemberSummary returns the sprint member summary list
 func (ctrl SprintController) GetSprintMemberSummary(c *gin.Context) {
 	userID, _ := c.Get("userID")
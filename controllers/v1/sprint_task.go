@@ -25,6 +25,7 @@ func (ctrl SprintTaskController) Routes(r *gin.RouterGroup) {
 	r.PATCH("/:sprintTaskID/", ctrl.Update)
 	r.POST("/:sprintTaskID/done/", ctrl.MarkDone)
 	r.DELETE("/:sprintTaskID/done/", ctrl.MarkUndone)
+	r.GET("/:sprintTaskID/status-history/", ctrl.GetStatusHistory)
 }
 
 // List ...
@@ -70,6 +71,28 @@ func (ctrl SprintTaskController) Get(c *gin.Context) {
 	c.JSON(status, task)
 }
 
+// GetStatusHistory returns the status changes of the task with its lead time, cycle time and time in status
+func (ctrl SprintTaskController) GetStatusHistory(c *gin.Context) {
+	id := c.Param("sprintTaskID")
+	retroID := c.Param("retroID")
+	sprintID := c.Param("sprintID")
+	userID, _ := c.Get("userID")
+
+	if !ctrl.PermissionService.UserCanAccessSprintTask(retroID, sprintID, id, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	history, status, err := ctrl.SprintTaskService.GetStatusHistory(id, retroID, sprintID)
+
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, history)
+}
+
 // Update ...
 func (ctrl SprintTaskController) Update(c *gin.Context) {
 	id := c.Param("sprintTaskID")
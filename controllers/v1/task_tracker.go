@@ -4,20 +4,67 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	retrospectiveServices "github.com/iReflect/reflect-app/apps/retrospective/services"
 	taskTrackerServices "github.com/iReflect/reflect-app/apps/tasktracker/services"
 )
 
 //TaskTrackerController ...
 type TaskTrackerController struct {
 	TaskTrackerService taskTrackerServices.TaskTrackerService
+	PermissionService  retrospectiveServices.PermissionService
 }
 
 //Routes for TaskTracker
 func (ctrl TaskTrackerController) Routes(r *gin.RouterGroup) {
 	r.GET("/config-list/", ctrl.ConfigList)
+	r.GET("/config-schemas/", ctrl.ConfigSchemas)
+	r.GET("/config-schemas/:provider/", ctrl.ConfigSchema)
+	r.POST("/test-connection/", ctrl.TestConnection)
 }
 
 // ConfigList List task tracker config
 func (ctrl TaskTrackerController) ConfigList(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"TaskProviders": ctrl.TaskTrackerService.ConfigList()})
 }
+
+// ConfigSchemas returns the JSON Schemas of the configs of the task providers
+func (ctrl TaskTrackerController) ConfigSchemas(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"TaskProviders": ctrl.TaskTrackerService.ConfigSchemas()})
+}
+
+// ConfigSchema returns the JSON Schema of the config of the task provider
+func (ctrl TaskTrackerController) ConfigSchema(c *gin.Context) {
+	schema, status, err := ctrl.TaskTrackerService.ConfigSchema(c.Param("provider"))
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, schema)
+}
+
+// TestConnection connects to the task provider with the given config and returns the problems found in the config,
+// it is allowed to the admins and to the members of the retrospective, given by the retroID, whose config is edited
+func (ctrl TaskTrackerController) TestConnection(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	retroID := c.Query("retroID")
+	if retroID == "" && !ctrl.PermissionService.IsUserAdmin(userID.(uint)) ||
+		retroID != "" && !ctrl.PermissionService.UserCanAccessRetro(retroID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	var taskProviderConfig map[string]interface{}
+	if err := c.BindJSON(&taskProviderConfig); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	result, status, err := ctrl.TaskTrackerService.TestConnection(taskProviderConfig)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, result)
+}
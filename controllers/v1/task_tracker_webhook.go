@@ -0,0 +1,46 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	retrospectiveServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+)
+
+// TaskTrackerWebhookController ...
+type TaskTrackerWebhookController struct {
+	TaskTrackerWebhookService retrospectiveServices.TaskTrackerWebhookService
+}
+
+// Routes for the task tracker webhooks, these are authenticated using the webhook secret of the retrospective
+func (ctrl TaskTrackerWebhookController) Routes(r *gin.RouterGroup) {
+	r.POST("/:source/:retroID/", ctrl.Receive)
+}
+
+// Receive the task tracker change event and queue the update of the changed tasks
+func (ctrl TaskTrackerWebhookController) Receive(c *gin.Context) {
+	retroID := c.Param("retroID")
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	event := retrospectiveServices.WebhookEvent{
+		Source:      c.Param("source"),
+		ProviderKey: c.Query("provider"),
+		DeliveryID:  c.GetHeader("X-Atlassian-Webhook-Identifier"),
+		Signature:   c.GetHeader("X-Hub-Signature"),
+		Token:       c.GetHeader("X-Webhook-Token"),
+		Body:        body,
+	}
+
+	queuedTasks, status, err := ctrl.TaskTrackerWebhookService.HandleEvent(retroID, event)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(status, gin.H{"queuedTasks": queuedTasks})
+}
@@ -1,8 +1,11 @@
 package v1
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	retrospectiveService "github.com/iReflect/reflect-app/apps/retrospective/services"
+	userSerializers "github.com/iReflect/reflect-app/apps/user/serializers"
 	userServices "github.com/iReflect/reflect-app/apps/user/services"
 )
 
@@ -15,6 +18,11 @@ type TeamController struct {
 // Routes for Team
 func (ctrl TeamController) Routes(r *gin.RouterGroup) {
 	r.GET("/:teamID/members/", ctrl.GetMembers)
+	r.PUT("/:teamID/work-week/", ctrl.UpdateWorkWeek)
+	r.GET("/:teamID/holidays/", ctrl.GetHolidays)
+	r.POST("/:teamID/holidays/", ctrl.AddHoliday)
+	r.POST("/:teamID/holidays/import/", ctrl.ImportHolidays)
+	r.DELETE("/:teamID/holidays/:holidayID/", ctrl.RemoveHoliday)
 	r.GET("/", ctrl.GetTeams)
 }
 
@@ -47,3 +55,104 @@ func (ctrl TeamController) GetMembers(c *gin.Context) {
 
 	c.JSON(status, members)
 }
+
+// UpdateWorkWeek updates the work week of the team
+func (ctrl TeamController) UpdateWorkWeek(c *gin.Context) {
+	id := c.Param("teamID")
+	userID, _ := c.Get("userID")
+	isAdmin := ctrl.PermissionService.IsUserAdmin(userID.(uint))
+
+	var workWeekData userSerializers.TeamWorkWeekSerializer
+	if err := c.BindJSON(&workWeekData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	team, status, err := ctrl.TeamService.UpdateWorkWeek(id, userID.(uint), isAdmin, workWeekData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, team)
+}
+
+// GetHolidays returns the holidays of the team, only of the given year if any
+func (ctrl TeamController) GetHolidays(c *gin.Context) {
+	id := c.Param("teamID")
+	year := c.Query("year")
+	userID, _ := c.Get("userID")
+	isAdmin := ctrl.PermissionService.IsUserAdmin(userID.(uint))
+
+	holidays, status, err := ctrl.TeamService.HolidayList(id, userID.(uint), isAdmin, year)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, holidays)
+}
+
+// AddHoliday adds a holiday to the team
+func (ctrl TeamController) AddHoliday(c *gin.Context) {
+	id := c.Param("teamID")
+	userID, _ := c.Get("userID")
+	isAdmin := ctrl.PermissionService.IsUserAdmin(userID.(uint))
+
+	var holidayData userSerializers.HolidayCreateSerializer
+	if err := c.BindJSON(&holidayData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	holiday, status, err := ctrl.TeamService.AddHoliday(id, userID.(uint), isAdmin, holidayData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, holiday)
+}
+
+// ImportHolidays adds the events of the uploaded iCalendar (.ics) file as the holidays of the team
+func (ctrl TeamController) ImportHolidays(c *gin.Context) {
+	id := c.Param("teamID")
+	userID, _ := c.Get("userID")
+	isAdmin := ctrl.PermissionService.IsUserAdmin(userID.(uint))
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "holiday calendar file is required"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid holiday calendar file"})
+		return
+	}
+	defer file.Close()
+
+	report, status, err := ctrl.TeamService.ImportHolidays(id, userID.(uint), isAdmin, file)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, report)
+}
+
+// RemoveHoliday removes a holiday of the team
+func (ctrl TeamController) RemoveHoliday(c *gin.Context) {
+	id := c.Param("teamID")
+	holidayID := c.Param("holidayID")
+	userID, _ := c.Get("userID")
+	isAdmin := ctrl.PermissionService.IsUserAdmin(userID.(uint))
+
+	status, err := ctrl.TeamService.RemoveHoliday(id, holidayID, userID.(uint), isAdmin)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, nil)
+}
@@ -19,6 +19,11 @@ func (ctrl TeamFeedbackController) Routes(r *gin.RouterGroup) {
 	r.GET("/:id/", ctrl.Get)
 }
 
+// ScoreRoutes for the score history of the feedbacks of the team members
+func (ctrl TeamFeedbackController) ScoreRoutes(r *gin.RouterGroup) {
+	r.GET("/users/:userID/", ctrl.ScoreHistory)
+}
+
 // ToDo: handle errors like in retrospectives/sprints controllers
 
 // Get feedback
@@ -52,3 +57,17 @@ func (ctrl TeamFeedbackController) List(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, response)
 }
+
+// ScoreHistory returns the scores of the submitted feedbacks of a user over time
+func (ctrl TeamFeedbackController) ScoreHistory(c *gin.Context) {
+	forUserID := c.Param("userID")
+	userID, _ := c.Get("userID")
+
+	response, status, err := ctrl.FeedbackService.TeamScoreHistory(forUserID, userID.(uint))
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
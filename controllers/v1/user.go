@@ -4,15 +4,20 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	userSerializers "github.com/iReflect/reflect-app/apps/user/serializers"
+	userServices "github.com/iReflect/reflect-app/apps/user/services"
 )
 
 //UserController ...
 type UserController struct {
+	UserService userServices.UserService
 }
 
 // Routes for User
 func (ctrl UserController) Routes(r *gin.RouterGroup) {
 	r.GET("/current/", ctrl.Current)
+	r.PUT("/current/time-zone/", ctrl.UpdateTimeZone)
 }
 
 // ToDo: handle errors like in retrospectives/sprints controllers
@@ -27,3 +32,22 @@ func (ctrl UserController) Current(c *gin.Context) {
 
 	c.JSON(http.StatusOK, user)
 }
+
+// UpdateTimeZone updates the time zone of the current user
+func (ctrl UserController) UpdateTimeZone(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	var timeZoneData userSerializers.UserTimeZoneSerializer
+	if err := c.BindJSON(&timeZoneData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	user, status, err := ctrl.UserService.UpdateTimeZone(userID.(uint), timeZoneData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, user)
+}
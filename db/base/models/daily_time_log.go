@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// DailyTimeLog ...
+type DailyTimeLog struct {
+	gorm.Model
+	SprintMemberTask   SprintMemberTask
+	SprintMemberTaskID uint      `gorm:"not null; index:idx_daily_time_log_smt_date"`
+	Date               time.Time `gorm:"type:date; not null; index:idx_daily_time_log_smt_date"`
+	Minutes            uint      `gorm:"not null"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/iReflect/reflect-app/db/models/fields"
+)
+
+// FeedbackFormVersion ...
+type FeedbackFormVersion struct {
+	gorm.Model
+	FeedbackForm   FeedbackForm
+	FeedbackFormID uint         `gorm:"not null; unique_index:idx_feedback_form_version"`
+	Version        uint         `gorm:"not null; unique_index:idx_feedback_form_version"`
+	Title          string       `gorm:"type:varchar(255); not null"`
+	Description    string       `gorm:"type:text;"`
+	Contents       fields.JSONB `gorm:"type:jsonb; not null; default:'[]'::jsonb"`
+	PublishedAt    time.Time    `gorm:"not null"`
+}
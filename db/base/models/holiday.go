@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Holiday ...
+type Holiday struct {
+	gorm.Model
+	Team   Team
+	TeamID uint      `gorm:"not null; index:idx_holiday_team_date"`
+	Date   time.Time `gorm:"type:date; not null; index:idx_holiday_team_date"`
+	Name   string    `gorm:"type:varchar(255); not null; default:''"`
+}
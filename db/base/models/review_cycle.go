@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ReviewCycle ...
+type ReviewCycle struct {
+	gorm.Model
+	Title              string `gorm:"type:varchar(255); not null"`
+	Team               Team
+	TeamID             uint `gorm:"not null"`
+	FeedbackForm       FeedbackForm
+	FeedbackFormID     uint      `gorm:"not null"`
+	DurationStart      time.Time `gorm:"not null"`
+	DurationEnd        time.Time `gorm:"not null"`
+	NominationEndAt    time.Time `gorm:"not null"`
+	ExpireAt           time.Time `gorm:"not null"`
+	AnonymityThreshold uint      `gorm:"default:3; not null"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "github.com/jinzhu/gorm"
+
+// ReviewNomination ...
+type ReviewNomination struct {
+	gorm.Model
+	ReviewCycle           ReviewCycle
+	ReviewCycleID         uint `gorm:"not null; unique_index:idx_review_nomination"`
+	ForUserProfile        UserProfile
+	ForUserProfileID      uint `gorm:"not null; unique_index:idx_review_nomination"`
+	ReviewerUserProfile   UserProfile
+	ReviewerUserProfileID uint `gorm:"not null; unique_index:idx_review_nomination"`
+	Relationship          int8 `gorm:"default:0; not null"`
+	Status                int8 `gorm:"default:0; not null"`
+	ReviewedBy            *User
+	ReviewedByID          *uint
+	Feedback              *Feedback
+	FeedbackID            *uint
+}
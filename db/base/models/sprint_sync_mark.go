@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// SprintSyncMark ...
+type SprintSyncMark struct {
+	gorm.Model
+	Sprint      Sprint
+	SprintID    uint      `gorm:"not null; unique_index:idx_sprint_sync_mark_sprint_provider"`
+	ProviderKey string    `gorm:"type:varchar(50); not null; unique_index:idx_sprint_sync_mark_sprint_provider"`
+	SyncedAt    time.Time `gorm:"not null"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/iReflect/reflect-app/db/models/fields"
+)
+
+// SprintSyncRun ...
+type SprintSyncRun struct {
+	gorm.Model
+	Sprint            Sprint
+	SprintID          uint      `gorm:"not null"`
+	Trigger           string    `gorm:"type:varchar(20); not null"`
+	FullResync        bool      `gorm:"not null; default:false"`
+	Status            int8      `gorm:"default:0; not null"`
+	StartedAt         time.Time `gorm:"not null"`
+	EndedAt           *time.Time
+	TasksFetched      uint         `gorm:"not null; default:0"`
+	TasksInserted     uint         `gorm:"not null; default:0"`
+	TasksUpdated      uint         `gorm:"not null; default:0"`
+	TimeLogsProcessed uint         `gorm:"not null; default:0"`
+	Errors            fields.JSONB `gorm:"type:jsonb; not null; default:'[]'::jsonb"`
+}
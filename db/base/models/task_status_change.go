@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// TaskStatusChange ...
+type TaskStatusChange struct {
+	gorm.Model
+	Task            Task
+	TaskID          uint      `gorm:"not null; index"`
+	FromStatus      string    `gorm:"type:varchar(50); not null; default:''"`
+	ToStatus        string    `gorm:"type:varchar(50); not null"`
+	ChangedAt       time.Time `gorm:"not null"`
+	IsFromChangelog bool      `gorm:"not null; default:false"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// UploadedTimeLog ...
+type UploadedTimeLog struct {
+	gorm.Model
+	SprintMember   SprintMember
+	SprintMemberID uint      `gorm:"not null"`
+	TaskKey        string    `gorm:"type:varchar(30); not null"`
+	Minutes        uint      `gorm:"not null"`
+	Date           time.Time `gorm:"type:date; not null"`
+	UploadedBy     User
+	UploadedByID   uint `gorm:"not null"`
+}
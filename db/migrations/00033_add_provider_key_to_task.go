@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"database/sql"
+	"github.com/iReflect/reflect-app/db/base/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(Up00033, Down00033)
+}
+
+// Up00033 ...
+func Up00033(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type task struct {
+		ProviderKey string `gorm:"type:varchar(50); not null; default:''"`
+	}
+
+	if err = gormdb.AutoMigrate(&task{}).Error; err != nil {
+		return err
+	}
+
+	// Till now only the first task provider of a retrospective was used,
+	// so the existing tracker tasks belong to the first task provider
+	return gormdb.Exec(`
+		UPDATE tasks SET provider_key = retrospectives.task_provider_config -> 0 ->> 'type'
+		FROM retrospectives
+		WHERE tasks.retrospective_id = retrospectives.id AND tasks.is_tracker_task = true
+		AND retrospectives.task_provider_config -> 0 ->> 'type' IS NOT NULL
+	`).Error
+}
+
+// Down00033 ...
+func Down00033(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormdb.Model(&models.Task{}).DropColumn("provider_key")
+
+	return nil
+}
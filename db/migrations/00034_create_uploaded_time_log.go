@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00034, Down00034)
+}
+
+// Up00034 ...
+func Up00034(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+	gormDB.CreateTable(&models.UploadedTimeLog{})
+
+	gormDB.Model(&models.UploadedTimeLog{}).AddForeignKey("sprint_member_id", "sprint_members(id)", "RESTRICT", "RESTRICT")
+	gormDB.Model(&models.UploadedTimeLog{}).AddForeignKey("uploaded_by_id", "users(id)", "RESTRICT", "RESTRICT")
+
+	return nil
+}
+
+// Down00034 ...
+func Down00034(tx *sql.Tx) error {
+
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormDB.Model(&models.UploadedTimeLog{}).RemoveForeignKey("uploaded_by_id", "users(id)")
+	gormDB.Model(&models.UploadedTimeLog{}).RemoveForeignKey("sprint_member_id", "sprint_members(id)")
+
+	gormDB.DropTable(&models.UploadedTimeLog{})
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"database/sql"
+	"github.com/iReflect/reflect-app/db/base/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(Up00035, Down00035)
+}
+
+// Up00035 ...
+func Up00035(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type retrospective struct {
+		WebhookSecret string `gorm:"type:varchar(64); not null; default:''"`
+	}
+
+	gormdb.AutoMigrate(&retrospective{})
+
+	return nil
+}
+
+// Down00035 ...
+func Down00035(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormdb.Model(&models.Retrospective{}).DropColumn("webhook_secret")
+
+	return nil
+}
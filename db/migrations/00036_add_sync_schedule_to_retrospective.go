@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"database/sql"
+	"github.com/iReflect/reflect-app/db/base/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(Up00036, Down00036)
+}
+
+// Up00036 ...
+func Up00036(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type retrospective struct {
+		SyncSchedule string `gorm:"type:varchar(100); not null; default:''"`
+	}
+
+	gormdb.AutoMigrate(&retrospective{})
+
+	return nil
+}
+
+// Down00036 ...
+func Down00036(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormdb.Model(&models.Retrospective{}).DropColumn("sync_schedule")
+
+	return nil
+}
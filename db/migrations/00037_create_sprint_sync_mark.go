@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00037, Down00037)
+}
+
+// Up00037 ...
+func Up00037(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+	gormDB.CreateTable(&models.SprintSyncMark{})
+
+	gormDB.Model(&models.SprintSyncMark{}).AddForeignKey("sprint_id", "sprints(id)", "RESTRICT", "RESTRICT")
+
+	return nil
+}
+
+// Down00037 ...
+func Down00037(tx *sql.Tx) error {
+
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormDB.Model(&models.SprintSyncMark{}).RemoveForeignKey("sprint_id", "sprints(id)")
+
+	gormDB.DropTable(&models.SprintSyncMark{})
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00038, Down00038)
+}
+
+// Up00038 ...
+func Up00038(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+	gormDB.CreateTable(&models.SprintSyncRun{})
+
+	gormDB.Model(&models.SprintSyncRun{}).AddForeignKey("sprint_id", "sprints(id)", "RESTRICT", "RESTRICT")
+
+	return nil
+}
+
+// Down00038 ...
+func Down00038(tx *sql.Tx) error {
+
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormDB.Model(&models.SprintSyncRun{}).RemoveForeignKey("sprint_id", "sprints(id)")
+
+	gormDB.DropTable(&models.SprintSyncRun{})
+
+	return nil
+}
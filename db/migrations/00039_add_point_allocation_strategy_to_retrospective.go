@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"database/sql"
+	"github.com/iReflect/reflect-app/db/base/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(Up00039, Down00039)
+}
+
+// Up00039 ...
+func Up00039(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type retrospective struct {
+		PointAllocationStrategy string `gorm:"type:varchar(30); not null; default:'time-proportional'"`
+	}
+
+	gormdb.AutoMigrate(&retrospective{})
+
+	return nil
+}
+
+// Down00039 ...
+func Down00039(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormdb.Model(&models.Retrospective{}).DropColumn("point_allocation_strategy")
+
+	return nil
+}
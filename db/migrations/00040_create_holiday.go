@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00040, Down00040)
+}
+
+// Up00040 ...
+func Up00040(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+	gormDB.CreateTable(&models.Holiday{})
+
+	gormDB.Model(&models.Holiday{}).AddForeignKey("team_id", "teams(id)", "RESTRICT", "RESTRICT")
+
+	return nil
+}
+
+// Down00040 ...
+func Down00040(tx *sql.Tx) error {
+
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormDB.Model(&models.Holiday{}).RemoveForeignKey("team_id", "teams(id)")
+
+	gormDB.DropTable(&models.Holiday{})
+
+	return nil
+}
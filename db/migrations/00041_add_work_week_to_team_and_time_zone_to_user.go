@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"database/sql"
+	"github.com/iReflect/reflect-app/db/base/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(Up00041, Down00041)
+}
+
+// Up00041 ...
+func Up00041(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type team struct {
+		WorkWeek string `gorm:"type:varchar(50); not null; default:'mon,tue,wed,thu,fri'"`
+	}
+
+	type user struct {
+		TimeZone string `gorm:"type:varchar(50); not null; default:''"`
+	}
+
+	gormdb.AutoMigrate(&team{}, &user{})
+
+	return nil
+}
+
+// Down00041 ...
+func Down00041(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormdb.Model(&models.Team{}).DropColumn("work_week")
+	gormdb.Model(&models.User{}).DropColumn("time_zone")
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00042, Down00042)
+}
+
+// Up00042 ...
+func Up00042(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+	gormDB.CreateTable(&models.DailyTimeLog{})
+
+	gormDB.Model(&models.DailyTimeLog{}).AddForeignKey("sprint_member_task_id", "sprint_member_tasks(id)", "RESTRICT", "RESTRICT")
+
+	return nil
+}
+
+// Down00042 ...
+func Down00042(tx *sql.Tx) error {
+
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormDB.Model(&models.DailyTimeLog{}).RemoveForeignKey("sprint_member_task_id", "sprint_member_tasks(id)")
+
+	gormDB.DropTable(&models.DailyTimeLog{})
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00043, Down00043)
+}
+
+// Up00043 ...
+func Up00043(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+	gormDB.CreateTable(&models.TaskStatusChange{})
+
+	gormDB.Model(&models.TaskStatusChange{}).AddForeignKey("task_id", "tasks(id)", "RESTRICT", "RESTRICT")
+
+	return nil
+}
+
+// Down00043 ...
+func Down00043(tx *sql.Tx) error {
+
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormDB.Model(&models.TaskStatusChange{}).RemoveForeignKey("task_id", "tasks(id)")
+
+	gormDB.DropTable(&models.TaskStatusChange{})
+
+	return nil
+}
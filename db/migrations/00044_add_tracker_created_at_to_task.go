@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(Up00044, Down00044)
+}
+
+// Up00044 ...
+func Up00044(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type task struct {
+		TrackerCreatedAt *time.Time
+	}
+
+	gormdb.AutoMigrate(&task{})
+
+	return nil
+}
+
+// Down00044 ...
+func Down00044(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormdb.Model(&models.Task{}).DropColumn("tracker_created_at")
+
+	return nil
+}
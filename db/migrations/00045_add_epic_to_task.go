@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(Up00045, Down00045)
+}
+
+// Up00045 ...
+func Up00045(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type task struct {
+		ParentKey string `gorm:"type:varchar(30); not null; default:''"`
+		EpicKey   string `gorm:"type:varchar(30); not null; default:''; index"`
+		EpicName  string `gorm:"type:varchar(255); not null; default:''"`
+	}
+
+	gormdb.AutoMigrate(&task{})
+
+	return nil
+}
+
+// Down00045 ...
+func Down00045(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormdb.Model(&models.Task{}).DropColumn("parent_key")
+	gormdb.Model(&models.Task{}).DropColumn("epic_key")
+	gormdb.Model(&models.Task{}).DropColumn("epic_name")
+
+	return nil
+}
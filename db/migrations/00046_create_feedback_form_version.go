@@ -0,0 +1,110 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00046, Down00046)
+}
+
+// Up00046 ...
+func Up00046(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+	gormDB.CreateTable(&models.FeedbackFormVersion{})
+	gormDB.Model(&models.FeedbackFormVersion{}).AddForeignKey("feedback_form_id", "feedback_forms(id)", "RESTRICT", "RESTRICT")
+
+	type feedback struct {
+		FeedbackFormVersionID *uint
+	}
+	if err = gormDB.AutoMigrate(&feedback{}).Error; err != nil {
+		return err
+	}
+	gormDB.Model(&models.Feedback{}).AddForeignKey("feedback_form_version_id", "feedback_form_versions(id)", "RESTRICT", "RESTRICT")
+
+	// The archived feedback forms are marked by their status now
+	if err = gormDB.Exec(`UPDATE feedback_forms SET status = 2 WHERE archive = true`).Error; err != nil {
+		return err
+	}
+	gormDB.Model(&models.FeedbackForm{}).DropColumn("archive")
+
+	// The existing feedbacks were issued with the current contents of their feedback forms, so the first version of
+	// the feedback forms in use is the snapshot of their current contents
+	err = gormDB.Exec(`
+		INSERT INTO feedback_form_versions (created_at, updated_at, feedback_form_id, version, title, description,
+		                                    contents, published_at)
+		SELECT NOW(), NOW(), ff.id, 1, ff.title, ff.description, COALESCE((
+			SELECT jsonb_agg(jsonb_build_object(
+				'ID', ffc.id,
+				'Category', jsonb_build_object('ID', c.id, 'Title', c.title, 'Description', COALESCE(c.description, '')),
+				'Skill', jsonb_build_object(
+					'ID', s.id,
+					'Title', s.title,
+					'DisplayTitle', COALESCE(s.display_title, ''),
+					'Description', COALESCE(s.description, ''),
+					'Weight', COALESCE(s.weight, 1),
+					'Questions', COALESCE((
+						SELECT jsonb_agg(jsonb_build_object(
+							'ID', q.id, 'Text', q.text, 'Type', q.type, 'Options', q.options, 'Weight', q.weight
+						) ORDER BY q.id)
+						FROM questions q
+						WHERE q.skill_id = s.id AND q.deleted_at IS NULL
+					), '[]'::jsonb)
+				)
+			) ORDER BY ffc.id)
+			FROM feedback_form_contents ffc
+				JOIN skills s ON s.id = ffc.skill_id
+				JOIN categories c ON c.id = ffc.category_id
+			WHERE ffc.feedback_form_id = ff.id AND ffc.deleted_at IS NULL
+		), '[]'::jsonb), NOW()
+		FROM feedback_forms ff
+		WHERE ff.status != 0 OR EXISTS(SELECT 1 FROM feedbacks f WHERE f.feedback_form_id = ff.id)
+	`).Error
+	if err != nil {
+		return err
+	}
+
+	err = gormDB.Exec(`
+		UPDATE feedbacks SET feedback_form_version_id = feedback_form_versions.id
+		FROM feedback_form_versions
+		WHERE feedback_form_versions.feedback_form_id = feedbacks.feedback_form_id
+	`).Error
+	if err != nil {
+		return err
+	}
+	return gormDB.Exec(`ALTER TABLE feedbacks ALTER COLUMN feedback_form_version_id SET NOT NULL`).Error
+}
+
+// Down00046 ...
+func Down00046(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type feedbackForm struct {
+		Archive bool `gorm:"default:false; not null"`
+	}
+	if err = gormDB.AutoMigrate(&feedbackForm{}).Error; err != nil {
+		return err
+	}
+	if err = gormDB.Exec(`UPDATE feedback_forms SET status = 1, archive = true WHERE status = 2`).Error; err != nil {
+		return err
+	}
+
+	gormDB.Model(&models.Feedback{}).RemoveForeignKey("feedback_form_version_id", "feedback_form_versions(id)")
+	gormDB.Model(&models.Feedback{}).DropColumn("feedback_form_version_id")
+
+	gormDB.Model(&models.FeedbackFormVersion{}).RemoveForeignKey("feedback_form_id", "feedback_forms(id)")
+	gormDB.DropTable(&models.FeedbackFormVersion{})
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00047, Down00047)
+}
+
+// Up00047 ...
+func Up00047(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+	gormDB.CreateTable(&models.ReviewCycle{})
+	gormDB.Model(&models.ReviewCycle{}).AddForeignKey("team_id", "teams(id)", "RESTRICT", "RESTRICT")
+	gormDB.Model(&models.ReviewCycle{}).AddForeignKey("feedback_form_id", "feedback_forms(id)", "RESTRICT", "RESTRICT")
+
+	gormDB.CreateTable(&models.ReviewNomination{})
+	gormDB.Model(&models.ReviewNomination{}).AddForeignKey("review_cycle_id", "review_cycles(id)", "RESTRICT", "RESTRICT")
+	gormDB.Model(&models.ReviewNomination{}).AddForeignKey("for_user_profile_id", "user_profiles(id)", "RESTRICT", "RESTRICT")
+	gormDB.Model(&models.ReviewNomination{}).AddForeignKey("reviewer_user_profile_id", "user_profiles(id)", "RESTRICT", "RESTRICT")
+	gormDB.Model(&models.ReviewNomination{}).AddForeignKey("reviewed_by_id", "users(id)", "RESTRICT", "RESTRICT")
+	gormDB.Model(&models.ReviewNomination{}).AddForeignKey("feedback_id", "feedbacks(id)", "RESTRICT", "RESTRICT")
+
+	return nil
+}
+
+// Down00047 ...
+func Down00047(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormDB.Model(&models.ReviewNomination{}).RemoveForeignKey("review_cycle_id", "review_cycles(id)")
+	gormDB.Model(&models.ReviewNomination{}).RemoveForeignKey("for_user_profile_id", "user_profiles(id)")
+	gormDB.Model(&models.ReviewNomination{}).RemoveForeignKey("reviewer_user_profile_id", "user_profiles(id)")
+	gormDB.Model(&models.ReviewNomination{}).RemoveForeignKey("reviewed_by_id", "users(id)")
+	gormDB.Model(&models.ReviewNomination{}).RemoveForeignKey("feedback_id", "feedbacks(id)")
+	gormDB.DropTable(&models.ReviewNomination{})
+
+	gormDB.Model(&models.ReviewCycle{}).RemoveForeignKey("team_id", "teams(id)")
+	gormDB.Model(&models.ReviewCycle{}).RemoveForeignKey("feedback_form_id", "feedback_forms(id)")
+	gormDB.DropTable(&models.ReviewCycle{})
+
+	return nil
+}
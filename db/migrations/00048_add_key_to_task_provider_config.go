@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(Up00048, Down00048)
+}
+
+// Up00048 ...
+func Up00048(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	// The task provider configs were keyed by their position till now, i.e. the type of the provider for the first
+	// config of a type and suffixed with the count for the subsequent ones. The same keys are persisted in the
+	// configs, so that the existing tasks and sync marks stay with their configs.
+	return gormdb.Exec(`
+		UPDATE retrospectives SET task_provider_config = (
+			SELECT jsonb_agg(
+				CASE WHEN configs.config ->> 'key' IS NOT NULL THEN configs.config
+				ELSE configs.config || jsonb_build_object('key',
+					CASE WHEN configs.type_index = 1 THEN configs.config ->> 'type'
+					ELSE (configs.config ->> 'type') || '-' || configs.type_index END)
+				END ORDER BY configs.config_index)
+			FROM (
+				SELECT config, config_index,
+					ROW_NUMBER() OVER (PARTITION BY config ->> 'type' ORDER BY config_index) AS type_index
+				FROM jsonb_array_elements(retrospectives.task_provider_config)
+					WITH ORDINALITY AS provider_configs(config, config_index)
+			) AS configs
+		)
+		WHERE jsonb_typeof(task_provider_config) = 'array' AND jsonb_array_length(task_provider_config) > 0
+	`).Error
+}
+
+// Down00048 ...
+func Down00048(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	return gormdb.Exec(`
+		UPDATE retrospectives SET task_provider_config = (
+			SELECT jsonb_agg(config - 'key' ORDER BY config_index)
+			FROM jsonb_array_elements(retrospectives.task_provider_config)
+				WITH ORDINALITY AS provider_configs(config, config_index)
+		)
+		WHERE jsonb_typeof(task_provider_config) = 'array' AND jsonb_array_length(task_provider_config) > 0
+	`).Error
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+	"github.com/iReflect/reflect-app/db/models/fields"
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(Up00049, Down00049)
+}
+
+// Up00049 ...
+func Up00049(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type sprint struct {
+		ProviderSprintIDs fields.JSONB `gorm:"type:jsonb; not null; default:'{}'::jsonb"`
+	}
+
+	if err = gormdb.AutoMigrate(&sprint{}).Error; err != nil {
+		return err
+	}
+
+	// The sprint IDs were of the first task provider before the tasks were fetched from all the task providers
+	return gormdb.Exec(`
+		UPDATE sprints SET provider_sprint_ids = jsonb_build_object(
+			retrospectives.task_provider_config -> 0 ->> 'key', sprints.sprint_id)
+		FROM retrospectives
+		WHERE sprints.retrospective_id = retrospectives.id AND sprints.sprint_id <> ''
+		AND retrospectives.task_provider_config -> 0 ->> 'key' IS NOT NULL
+	`).Error
+}
+
+// Down00049 ...
+func Down00049(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormdb.Model(&models.Sprint{}).DropColumn("provider_sprint_ids")
+
+	return nil
+}
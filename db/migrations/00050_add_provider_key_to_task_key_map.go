@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(Up00050, Down00050)
+}
+
+// Up00050 ...
+func Up00050(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type taskKeyMap struct {
+		ProviderKey string `gorm:"type:varchar(50); not null; default:''"`
+	}
+
+	if err = gormdb.AutoMigrate(&taskKeyMap{}).Error; err != nil {
+		return err
+	}
+
+	err = gormdb.Exec(`
+		UPDATE task_key_maps SET provider_key = tasks.provider_key
+		FROM tasks
+		WHERE task_key_maps.task_id = tasks.id
+	`).Error
+	if err != nil {
+		return err
+	}
+
+	if err = gormdb.Model(&taskKeyMap{}).RemoveIndex("unique_task_id_key").Error; err != nil {
+		return err
+	}
+	return gormdb.Model(&taskKeyMap{}).
+		AddUniqueIndex("unique_task_id_key", "provider_key", "key", "task_id").Error
+}
+
+// Down00050 ...
+func Down00050(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormdb.Model(&models.TaskKeyMap{}).RemoveIndex("unique_task_id_key")
+	gormdb.Model(&models.TaskKeyMap{}).DropColumn("provider_key")
+	gormdb.Model(&models.TaskKeyMap{}).AddUniqueIndex("unique_task_id_key", "key", "task_id")
+
+	return nil
+}
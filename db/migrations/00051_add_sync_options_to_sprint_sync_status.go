@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(Up00051, Down00051)
+}
+
+// Up00051 ...
+func Up00051(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type sprintSyncStatus struct {
+		Trigger      string `gorm:"type:varchar(20); not null; default:''"`
+		FullResync   bool   `gorm:"not null; default:false"`
+		AssignPoints bool   `gorm:"not null; default:false"`
+	}
+
+	return gormdb.AutoMigrate(&sprintSyncStatus{}).Error
+}
+
+// Down00051 ...
+func Down00051(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormdb.Model(&models.SprintSyncStatus{}).DropColumn("trigger")
+	gormdb.Model(&models.SprintSyncStatus{}).DropColumn("full_resync")
+	gormdb.Model(&models.SprintSyncStatus{}).DropColumn("assign_points")
+
+	return nil
+}
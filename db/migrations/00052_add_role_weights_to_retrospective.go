@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+	"github.com/iReflect/reflect-app/db/models/fields"
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(Up00052, Down00052)
+}
+
+// Up00052 ...
+func Up00052(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	// The retrospectives without the role weights use the default role weights
+	type retrospective struct {
+		RoleWeights fields.JSONB `gorm:"type:jsonb; not null; default:'{}'::jsonb"`
+	}
+
+	return gormdb.AutoMigrate(&retrospective{}).Error
+}
+
+// Down00052 ...
+func Down00052(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormdb.Model(&models.Retrospective{}).DropColumn("role_weights")
+
+	return nil
+}
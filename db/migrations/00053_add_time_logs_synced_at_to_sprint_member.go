@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(Up00053, Down00053)
+}
+
+// Up00053 ...
+func Up00053(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	// The time logs of all the sprint members are fetched in full in their next sync
+	type sprintMember struct {
+		TimeLogsSyncedAt *time.Time
+	}
+
+	return gormdb.AutoMigrate(&sprintMember{}).Error
+}
+
+// Down00053 ...
+func Down00053(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormdb, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	gormdb.Model(&models.SprintMember{}).DropColumn("time_logs_synced_at")
+
+	return nil
+}
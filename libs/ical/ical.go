@@ -0,0 +1,148 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Event is an event of an iCalendar file, the dates of the event are the dates on which it falls, irrespective of
+// its time of the day
+type Event struct {
+	Summary   string
+	StartDate time.Time
+	// EndDate is the last date of the event (inclusive)
+	EndDate time.Time
+}
+
+// Dates returns all the dates on which the event falls
+func (event Event) Dates() []time.Time {
+	var dates []time.Time
+	for date := event.StartDate; !date.After(event.EndDate); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+const dateLayout = "20060102"
+
+// ParseEvents returns the events of an iCalendar (.ics) file. Only the summary and the dates of the events are read,
+// the recurrence rules are not expanded, so the recurring events are returned only for their first occurrence.
+func ParseEvents(file io.Reader) ([]Event, error) {
+	lines, err := readUnfoldedLines(file)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, errors.New("invalid iCalendar file")
+	}
+
+	var events []Event
+	var event *Event
+	var endValue, endParams string
+	for index, line := range lines {
+		name, params, value := parseContentLine(line)
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event = &Event{}
+			endValue, endParams = "", ""
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if event == nil {
+				return nil, fmt.Errorf("line %d: unexpected end of event", index+1)
+			}
+			if event.StartDate.IsZero() {
+				return nil, fmt.Errorf("line %d: event %q has no start date", index+1, event.Summary)
+			}
+			event.EndDate = event.StartDate
+			if endValue != "" {
+				endDate, err := parseEndDate(endValue, endParams)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %s", index+1, err)
+				}
+				if endDate.After(event.StartDate) {
+					event.EndDate = endDate
+				}
+			}
+			events = append(events, *event)
+			event = nil
+		case event == nil:
+			continue
+		case name == "SUMMARY":
+			event.Summary = unescapeText(value)
+		case name == "DTSTART":
+			event.StartDate, err = parseDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", index+1, err)
+			}
+		case name == "DTEND":
+			endValue, endParams = value, params
+		}
+	}
+	return events, nil
+}
+
+// readUnfoldedLines returns the content lines of the file, joining the lines folded as per RFC 5545
+func readUnfoldedLines(file io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseContentLine splits a content line (eg. 'DTSTART;VALUE=DATE:20181225') into its name, params and value
+func parseContentLine(line string) (name string, params string, value string) {
+	separatorIndex := strings.Index(line, ":")
+	if separatorIndex < 0 {
+		return strings.ToUpper(line), "", ""
+	}
+	name, value = line[:separatorIndex], line[separatorIndex+1:]
+	if paramsIndex := strings.Index(name, ";"); paramsIndex >= 0 {
+		name, params = name[:paramsIndex], name[paramsIndex+1:]
+	}
+	return strings.ToUpper(name), strings.ToUpper(params), strings.TrimSpace(value)
+}
+
+// parseDate returns the date of a DATE or a DATE-TIME value, the time of the day is ignored
+func parseDate(value string) (time.Time, error) {
+	if len(value) < len(dateLayout) {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	date, err := time.Parse(dateLayout, value[:len(dateLayout)])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+// parseEndDate returns the last date of an event from its DTEND value. The end of an event is exclusive, so the
+// previous date is the last date of the event when it ends at the start of a day.
+func parseEndDate(value string, params string) (time.Time, error) {
+	endDate, err := parseDate(value)
+	if err != nil {
+		return endDate, err
+	}
+	isDateValue := strings.Contains(params, "VALUE=DATE") && !strings.Contains(params, "VALUE=DATE-TIME")
+	if isDateValue || len(value) == len(dateLayout) || strings.HasPrefix(value[len(dateLayout):], "T000000") {
+		endDate = endDate.AddDate(0, 0, -1)
+	}
+	return endDate, nil
+}
+
+func unescapeText(value string) string {
+	return strings.NewReplacer(`\\`, `\`, `\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ").Replace(value)
+}
@@ -0,0 +1,65 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseEvents(t *testing.T) {
+	file := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20181225\r\n" +
+		"DTEND;VALUE=DATE:20181226\r\n" +
+		"SUMMARY:Christmas\\, Day\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20181107\r\n" +
+		"DTEND;VALUE=DATE:20181110\r\n" +
+		"SUMMARY:Diwali\r\n" +
+		"  Holidays\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;TZID=Europe/Berlin:20181003T090000\r\n" +
+		"DTEND;TZID=Europe/Berlin:20181003T180000\r\n" +
+		"SUMMARY:German Unity Day\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	events, err := ParseEvents(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Error in parsing the file - %s", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+
+	expectedEvents := []struct {
+		summary string
+		dates   []string
+	}{
+		{"Christmas, Day", []string{"2018-12-25"}},
+		{"Diwali Holidays", []string{"2018-11-07", "2018-11-08", "2018-11-09"}},
+		{"German Unity Day", []string{"2018-10-03"}},
+	}
+	for index, expected := range expectedEvents {
+		event := events[index]
+		if event.Summary != expected.summary {
+			t.Errorf("Expected summary %q, got %q", expected.summary, event.Summary)
+		}
+		var dates []string
+		for _, date := range event.Dates() {
+			dates = append(dates, date.Format("2006-01-02"))
+		}
+		if strings.Join(dates, ",") != strings.Join(expected.dates, ",") {
+			t.Errorf("Expected dates %v of %q, got %v", expected.dates, expected.summary, dates)
+		}
+	}
+
+	if _, err = ParseEvents(strings.NewReader("Date,Name\n")); err == nil {
+		t.Fatalf("File without a calendar should fail")
+	}
+	if _, err = ParseEvents(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:x\nEND:VEVENT\n")); err == nil {
+		t.Fatalf("Event without a start date should fail")
+	}
+}
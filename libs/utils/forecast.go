@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// SimulateThroughput runs a Monte Carlo simulation of the next sprints by sampling the historical throughputs (the
+// points completed per sprint) with replacement. It returns the simulated outcomes of each of the next sprints, an
+// outcome is the total points completed till the sprint.
+func SimulateThroughput(throughputs []float64, sprints int, trials int, random *rand.Rand) [][]float64 {
+	outcomes := make([][]float64, sprints)
+	for sprint := range outcomes {
+		outcomes[sprint] = make([]float64, trials)
+	}
+	if len(throughputs) == 0 {
+		return outcomes
+	}
+
+	for trial := 0; trial < trials; trial++ {
+		var completed float64
+		for sprint := 0; sprint < sprints; sprint++ {
+			completed += throughputs[random.Intn(len(throughputs))]
+			outcomes[sprint][trial] = completed
+		}
+	}
+	return outcomes
+}
+
+// SimulateSprintsToComplete runs a Monte Carlo simulation of the number of the sprints needed to complete the
+// backlog by sampling the historical throughputs with replacement. The trials which do not complete the backlog in
+// maxSprints are counted as needing more than maxSprints, i.e. as math.Inf(1).
+func SimulateSprintsToComplete(
+	throughputs []float64,
+	backlog float64,
+	trials int,
+	maxSprints int,
+	random *rand.Rand) []float64 {
+	outcomes := make([]float64, trials)
+	for trial := range outcomes {
+		outcomes[trial] = math.Inf(1)
+		if len(throughputs) == 0 {
+			continue
+		}
+		var completed float64
+		for sprint := 1; sprint <= maxSprints; sprint++ {
+			completed += throughputs[random.Intn(len(throughputs))]
+			if completed >= backlog {
+				outcomes[trial] = float64(sprint)
+				break
+			}
+		}
+	}
+	return outcomes
+}
+
+// Percentile returns the value below which the given percent of the values fall, using the nearest rank method
+func Percentile(values []float64, percent float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sortedValues := append([]float64(nil), values...)
+	sort.Float64s(sortedValues)
+
+	rank := int(math.Ceil(percent / 100 * float64(len(sortedValues))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sortedValues) {
+		rank = len(sortedValues)
+	}
+	return sortedValues[rank-1]
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []float64{15, 20, 35, 40, 50}
+	expected := map[float64]float64{0: 15, 30: 20, 40: 20, 50: 35, 100: 50}
+	for percent, value := range expected {
+		if percentile := Percentile(values, percent); percentile != value {
+			t.Errorf("Expected %v as the %vth percentile, got %v", value, percent, percentile)
+		}
+	}
+	if values[0] != 15 || values[4] != 50 {
+		t.Errorf("Percentile should not reorder the values - %v", values)
+	}
+	if percentile := Percentile(nil, 50); percentile != 0 {
+		t.Errorf("Expected 0 as the percentile of no values, got %v", percentile)
+	}
+}
+
+func TestSimulateThroughput(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	outcomes := SimulateThroughput([]float64{10, 20}, 3, 100, random)
+	if len(outcomes) != 3 || len(outcomes[0]) != 100 {
+		t.Fatalf("Expected 100 outcomes of 3 sprints, got %d sprints", len(outcomes))
+	}
+	for trial := 0; trial < 100; trial++ {
+		for sprint, sprintOutcomes := range outcomes {
+			minimum, maximum := 10*float64(sprint+1), 20*float64(sprint+1)
+			if sprintOutcomes[trial] < minimum || sprintOutcomes[trial] > maximum {
+				t.Fatalf("Outcome %v of sprint %d is out of range", sprintOutcomes[trial], sprint+1)
+			}
+		}
+	}
+}
+
+func TestSimulateSprintsToComplete(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, sprints := range SimulateSprintsToComplete([]float64{10}, 25, 10, 20, random) {
+		if sprints != 3 {
+			t.Fatalf("Expected 3 sprints to complete the backlog, got %v", sprints)
+		}
+	}
+	for _, sprints := range SimulateSprintsToComplete([]float64{0}, 25, 10, 20, random) {
+		if !math.IsInf(sprints, 1) {
+			t.Fatalf("Backlog should not be completed without any throughput, got %v", sprints)
+		}
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/iReflect/reflect-app/config"
 	"github.com/sirupsen/logrus"
 	"log"
-	"math"
 	"net/url"
 	"strings"
 	"time"
@@ -51,52 +50,50 @@ func UIntInSlice(element uint, slice []uint) bool {
 	return false
 }
 
-// GetWorkingDaysBetweenTwoDates calculates the working days between two dates,
-// i.e., number of days between two dates excluding weekends
-func GetWorkingDaysBetweenTwoDates(startDate time.Time, endDate time.Time) int {
-	serverConf := config.GetConfig().Server
-	location, err := time.LoadLocation(serverConf.TimeZone)
-	if err != nil {
-		log.Println("Invalid Timezone: ", err)
-		LogToSentry(err)
+// StringInSlice ...
+func StringInSlice(element string, slice []string) bool {
+	for _, sliceElement := range slice {
+		if sliceElement == element {
+			return true
+		}
 	}
+	return false
+}
 
+// GetWorkingDaysBetweenTwoDates calculates the working days between two dates (inclusive) as per the work week,
+// the dates are taken in the server time zone
+func GetWorkingDaysBetweenTwoDates(startDate time.Time, endDate time.Time, workWeek WorkWeek) int {
 	if endDate.Before(startDate) {
 		return 0
 	}
-	workingDays := 0
-	start := startDate
-	end := endDate
-
-	if location != nil {
-		start = startDate.In(location)
-		end = endDate.In(location)
-	}
-	start = GetStartOfDay(start)
-	end = GetStartOfDay(end)
+	start := GetStartOfDay(InServerTimeZone(startDate))
+	end := GetStartOfDay(InServerTimeZone(endDate))
 
-	for start.Weekday() != time.Monday && start.Before(end) {
-		if start.Weekday() != time.Sunday && start.Weekday() != time.Saturday {
+	workingDays := 0
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		if workWeek.IsWorkingDay(date) {
 			workingDays++
 		}
-		start = start.Add(time.Hour * 24)
 	}
+	return workingDays
+}
 
-	for end.Weekday() != time.Sunday && end.After(start) {
-		if end.Weekday() != time.Saturday {
-			workingDays++
-		}
-		end = end.Add(-time.Hour * 24)
-	}
-	duration := end.Sub(start)
-	if duration.Hours() > 24 {
-		weeks := int(math.Ceil(duration.Hours() / (24 * 7)))
-		workingDays += weeks * 5
-	} else {
-		workingDays++
+// InServerTimeZone returns the time in the server time zone
+func InServerTimeZone(t time.Time) time.Time {
+	location, err := time.LoadLocation(config.GetConfig().Server.TimeZone)
+	if err != nil {
+		log.Println("Invalid Timezone: ", err)
+		LogToSentry(err)
+		return t
 	}
+	return t.In(location)
+}
 
-	return workingDays
+// GetServerDateIn returns the start of the date of the time (as per the server time zone) in the given location,
+// so that a sprint date spans the same calendar day in the time zone of every member
+func GetServerDateIn(t time.Time, location *time.Location) time.Time {
+	year, month, day := InServerTimeZone(t).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, location)
 }
 
 // GetStartOfDay ...
@@ -105,13 +102,16 @@ func GetStartOfDay(t time.Time) time.Time {
 	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
 }
 
-// CalculateExpectedSP ...
-func CalculateExpectedSP(startDate time.Time, endDate time.Time, vacations float64, expectationPercent float64, allocationPercent float64, spPerWeek float64) float64 {
-	sprintWorkingDays := GetWorkingDaysBetweenTwoDates(startDate, endDate)
-	workingDays := float64(sprintWorkingDays) - vacations
+// CalculateExpectedSP calculates the expected story points of a member from the working days of the sprint
+// (excluding the holidays) and the number of the working days in a week
+func CalculateExpectedSP(sprintWorkingDays float64, workingDaysPerWeek int, vacations float64, expectationPercent float64, allocationPercent float64, spPerWeek float64) float64 {
+	if workingDaysPerWeek == 0 {
+		return 0
+	}
+	workingDays := sprintWorkingDays - vacations
 	expectationCoefficient := expectationPercent / 100.00
 	allocationCoefficient := allocationPercent / 100.00
-	storyPointPerDay := spPerWeek / 5
+	storyPointPerDay := spPerWeek / float64(workingDaysPerWeek)
 	return workingDays * storyPointPerDay * expectationCoefficient * allocationCoefficient
 }
 
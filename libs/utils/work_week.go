@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultWorkWeek is the work week of the teams which have not configured one
+const DefaultWorkWeek = "mon,tue,wed,thu,fri"
+
+// weekdayNames are the names of the weekdays used in the work week configs, indexed by time.Weekday
+var weekdayNames = [...]string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// WorkWeek is the set of the working weekdays of a team, indexed by time.Weekday
+type WorkWeek [7]bool
+
+// ParseWorkWeek parses a comma separated list of the working weekdays (eg. 'sun,mon,tue,wed,thu'),
+// the default work week is returned for an empty list
+func ParseWorkWeek(workWeek string) (WorkWeek, error) {
+	var parsedWorkWeek WorkWeek
+	if strings.TrimSpace(workWeek) == "" {
+		workWeek = DefaultWorkWeek
+	}
+
+	for _, name := range strings.Split(workWeek, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			// Both the short (eg. 'mon') and the full (eg. 'monday') names are allowed
+			if len(name) >= 3 && strings.HasPrefix(strings.ToLower(weekday.String()), name) {
+				parsedWorkWeek[weekday] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return parsedWorkWeek, fmt.Errorf("invalid weekday %q in the work week", name)
+		}
+	}
+	return parsedWorkWeek, nil
+}
+
+// IsWorkingDay checks whether the date falls on a working weekday
+func (workWeek WorkWeek) IsWorkingDay(date time.Time) bool {
+	return workWeek[date.Weekday()]
+}
+
+// DaysPerWeek returns the number of the working days in a week
+func (workWeek WorkWeek) DaysPerWeek() int {
+	days := 0
+	for _, isWorkingDay := range workWeek {
+		if isWorkingDay {
+			days++
+		}
+	}
+	return days
+}
+
+// String returns the work week as a comma separated list of the working weekdays
+func (workWeek WorkWeek) String() string {
+	var names []string
+	for weekday, isWorkingDay := range workWeek {
+		if isWorkingDay {
+			names = append(names, weekdayNames[weekday])
+		}
+	}
+	return strings.Join(names, ",")
+}
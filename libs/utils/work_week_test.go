@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWorkWeek(t *testing.T) {
+	workWeek, err := ParseWorkWeek("Sun, mon,tuesday,wed,thu")
+	if err != nil {
+		t.Fatalf("Error in parsing the work week - %s", err)
+	}
+	if workWeek.String() != "sun,mon,tue,wed,thu" || workWeek.DaysPerWeek() != 5 {
+		t.Fatalf("Unexpected work week - %s", workWeek)
+	}
+
+	defaultWorkWeek, err := ParseWorkWeek("")
+	if err != nil || defaultWorkWeek.String() != DefaultWorkWeek {
+		t.Fatalf("Expected the default work week, got %s (%v)", defaultWorkWeek, err)
+	}
+
+	for _, invalidWorkWeek := range []string{"mon,fun", "mo", "monkey", "mon,,tue"} {
+		if _, err = ParseWorkWeek(invalidWorkWeek); err == nil {
+			t.Errorf("Work week %q should be invalid", invalidWorkWeek)
+		}
+	}
+}
+
+func TestGetWorkingDaysBetweenTwoDates(t *testing.T) {
+	location, _ := time.LoadLocation("Asia/Kolkata")
+	// Sunday, 2 Sep 2018 to Saturday, 15 Sep 2018
+	start := time.Date(2018, 9, 2, 0, 0, 0, 0, location)
+	end := time.Date(2018, 9, 15, 0, 0, 0, 0, location)
+
+	mondayToFriday, _ := ParseWorkWeek("mon,tue,wed,thu,fri")
+	sundayToThursday, _ := ParseWorkWeek("sun,mon,tue,wed,thu")
+
+	if days := GetWorkingDaysBetweenTwoDates(start, end, mondayToFriday); days != 10 {
+		t.Errorf("Expected 10 working days, got %d", days)
+	}
+	if days := GetWorkingDaysBetweenTwoDates(start, start.AddDate(0, 0, 4), sundayToThursday); days != 5 {
+		t.Errorf("Expected 5 working days, got %d", days)
+	}
+	if days := GetWorkingDaysBetweenTwoDates(end, start, mondayToFriday); days != 0 {
+		t.Errorf("Expected no working days, got %d", days)
+	}
+}
@@ -32,6 +32,7 @@ func (a *Admin) Router() *http.ServeMux {
 	userModels.RegisterUserProfileToAdmin(Admin, admin.Config{Menu: []string{"User Management"}})
 	userModels.RegisterTeamToAdmin(Admin, admin.Config{Menu: []string{"User Management"}})
 	userModels.RegisterUserTeamToAdmin(Admin, admin.Config{Menu: []string{"User Management"}})
+	Admin.AddResource(&userModels.Holiday{}, &admin.Config{Menu: []string{"User Management"}})
 	userModels.RegisterOTPToAdmin(Admin, admin.Config{Menu: []string{"User Management"}})
 
 	// Retrospective Management
@@ -53,12 +54,14 @@ func (a *Admin) Router() *http.ServeMux {
 	feedbackModels.RegisterSkillToAdmin(Admin, admin.Config{Menu: []string{"Feedback Form Management"}})
 	feedbackModels.RegisterQuestionToAdmin(Admin, admin.Config{Menu: []string{"Feedback Form Management"}})
 	feedbackModels.RegisterFeedbackFormToAdmin(Admin, admin.Config{Menu: []string{"Feedback Form Management"}})
+	feedbackModels.RegisterFeedbackFormVersionToAdmin(Admin, admin.Config{Menu: []string{"Feedback Form Management"}})
 	Admin.AddResource(&feedbackModels.FeedbackFormContent{}, &admin.Config{Menu: []string{"Feedback Form Management"}})
 	Admin.AddResource(&feedbackModels.TeamFeedbackForm{}, &admin.Config{Menu: []string{"Feedback Form Management"}})
 
 	// Feedbacks Management
 	feedbackModels.RegisterFeedbackToAdmin(Admin, admin.Config{Menu: []string{"Feedback Management"}})
 	Admin.AddResource(&feedbackModels.QuestionResponse{}, &admin.Config{Menu: []string{"Feedback Management"}})
+	feedbackModels.RegisterReviewCycleToAdmin(Admin, admin.Config{Menu: []string{"Feedback Management"}})
 
 	// Schedule Management
 	Admin.AddResource(&feedbackModels.Schedule{}, &admin.Config{Menu: []string{"Schedule Management"}})
@@ -87,30 +87,49 @@ func (a *App) SetRoutes() {
 
 	teamFeedbackController := apiControllers.TeamFeedbackController{FeedbackService: feedbackService}
 	teamFeedbackController.Routes(v1.Group("team-feedbacks"))
+	teamFeedbackController.ScoreRoutes(v1.Group("feedback-scores"))
 
-	userController := apiControllers.UserController{}
+	userController := apiControllers.UserController{UserService: userServices.UserService{DB: a.DB}}
 	userController.Routes(v1.Group("users"))
 
+	permissionService := retrospectiveServices.PermissionService{DB: a.DB}
+
+	feedbackFormController := apiControllers.FeedbackFormController{
+		FeedbackFormService: feedbackServices.FeedbackFormService{DB: a.DB},
+		PermissionService:   permissionService,
+	}
+	feedbackFormController.Routes(v1.Group("feedback-forms"))
+
+	reviewCycleController := apiControllers.ReviewCycleController{
+		ReviewCycleService: feedbackServices.ReviewCycleService{DB: a.DB},
+		PermissionService:  permissionService,
+	}
+	reviewCycleController.Routes(v1.Group("review-cycles"))
+
 	teamService := userServices.TeamService{DB: a.DB}
 	teamControllerRoute := v1.Group("teams")
-	teamController := apiControllers.TeamController{TeamService: teamService}
+	teamController := apiControllers.TeamController{TeamService: teamService, PermissionService: permissionService}
 	teamController.Routes(teamControllerRoute)
 
 	authController := controllers.UserAuthController{AuthService: authenticationService}
 	authController.Routes(r.Group("/"))
 
-	permissionService := retrospectiveServices.PermissionService{DB: a.DB}
 	trailService := retrospectiveServices.TrailService{DB: a.DB}
 	retrospectiveService := retrospectiveServices.RetrospectiveService{DB: a.DB, TeamService: teamService}
 	retrospectiveRoute := v1.Group("retrospectives")
 
-	retrospectiveController := apiControllers.RetrospectiveController{RetrospectiveService: retrospectiveService, PermissionService: permissionService, TrailService: trailService}
+	webhookService := retrospectiveServices.TaskTrackerWebhookService{DB: a.DB}
+	sprintService := retrospectiveServices.SprintService{DB: a.DB}
+	retrospectiveController := apiControllers.RetrospectiveController{RetrospectiveService: retrospectiveService, PermissionService: permissionService, TrailService: trailService, WebhookService: webhookService, SprintService: sprintService}
 	retrospectiveController.Routes(retrospectiveRoute)
 
+	// The webhooks are called by the task trackers, so these are outside of the cookie authenticated routes
+	webhookController := apiControllers.TaskTrackerWebhookController{TaskTrackerWebhookService: webhookService}
+	webhookController.Routes(r.Group("/api/v1/webhooks"))
+
 	retrospectiveFeedbackService := retrospectiveServices.RetrospectiveFeedbackService{DB: a.DB}
 
 	sprintRoute := retrospectiveRoute.Group(":retroID/sprints")
-	sprintService := retrospectiveServices.SprintService{DB: a.DB}
 	sprintController := apiControllers.SprintController{SprintService: sprintService, PermissionService: permissionService, TrailService: trailService}
 	sprintController.Routes(sprintRoute)
 
@@ -150,7 +169,9 @@ func (a *App) SetRoutes() {
 	taskMemberController.Routes(taskMemberRoute)
 
 	taskTrackerService := taskTrackerServices.TaskTrackerService{}
-	taskTrackerController := apiControllers.TaskTrackerController{TaskTrackerService: taskTrackerService}
+	taskTrackerController := apiControllers.TaskTrackerController{
+		TaskTrackerService: taskTrackerService,
+		PermissionService:  permissionService}
 	taskTrackerController.Routes(v1.Group("task-tracker"))
 }
 
@@ -0,0 +1,32 @@
+package workers
+
+import (
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// MarkOnce marks the key as seen for the given duration, it returns false if the key was already marked.
+// Used to deduplicate the events which may be delivered more than once, eg. webhooks.
+func MarkOnce(key string, expiry time.Duration) (bool, error) {
+	conn := redisPool.Get()
+	defer conn.Close()
+
+	_, err := redis.String(conn.Do("SET", redisNamespace+":once:"+key, 1, "EX", int(expiry.Seconds()), "NX"))
+	if err == redis.ErrNil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Unmark clears the mark of the key, so that the event can be handled again, eg. when its handling failed
+func Unmark(key string) error {
+	conn := redisPool.Get()
+	defer conn.Close()
+
+	_, err := conn.Do("DEL", redisNamespace+":once:"+key)
+	return err
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/gocraft/work"
 	"github.com/gomodule/redigo/redis"
 	"github.com/iReflect/reflect-app/config"
+	"github.com/iReflect/reflect-app/db"
+	"github.com/jinzhu/gorm"
 	"log"
 )
 
@@ -27,6 +29,10 @@ var Enqueuer = work.NewEnqueuer(redisNamespace, redisPool)
 // Config ...
 var Config *config.Config
 
+// DB is the database connection shared by the jobs, it is opened once when the workers start, since a connection
+// opened per job would leave its pool open after the job
+var DB *gorm.DB
+
 // Pool ...
 var Pool *work.WorkerPool
 
@@ -37,9 +43,17 @@ type job struct {
 
 var jobs []job
 
+type periodicJob struct {
+	spec string
+	name string
+}
+
+var periodicJobs []periodicJob
+
 // Initialize ...
 func (w *Workers) Initialize(config *config.Config) {
 	Config = config
+	DB = db.Initialize(config)
 
 	// Make a new pool. Arguments:
 	// Context{} is a struct that will be the context for the request.
@@ -63,6 +77,7 @@ func (w *Workers) Initialize(config *config.Config) {
 func (w *Workers) Shutdown() {
 	// Stop the pool
 	Pool.Stop()
+	DB.Close()
 
 	log.Println("Stopped Workers...")
 }
@@ -78,9 +93,17 @@ func assignJobs() {
 	for _, job := range jobs {
 		Pool.Job(job.name, job.function)
 	}
+	for _, periodicJob := range periodicJobs {
+		Pool.PeriodicallyEnqueue(periodicJob.spec, periodicJob.name)
+	}
 }
 
 // RegisterJob ...
 func RegisterJob(name string, function func(*work.Job) error) {
 	jobs = append(jobs, job{name: name, function: function})
 }
+
+// RegisterPeriodicJob enqueues the registered job as per the cron spec, the first field of which is the seconds
+func RegisterPeriodicJob(spec string, name string) {
+	periodicJobs = append(periodicJobs, periodicJob{spec: spec, name: name})
+}
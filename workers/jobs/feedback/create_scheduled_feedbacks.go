@@ -0,0 +1,29 @@
+package feedback
+
+import (
+	"log"
+
+	"github.com/gocraft/work"
+	"github.com/iReflect/reflect-app/workers"
+
+	feedbackServices "github.com/iReflect/reflect-app/apps/feedback/services"
+)
+
+func init() {
+	workers.RegisterJob("create_scheduled_feedbacks", CreateScheduledFeedbacks)
+	// Every hour, the feedback events are not time critical
+	workers.RegisterPeriodicJob("0 0 * * * *", "create_scheduled_feedbacks")
+}
+
+// CreateScheduledFeedbacks ...
+func CreateScheduledFeedbacks(job *work.Job) error {
+	scheduleService := feedbackServices.ScheduleService{DB: workers.DB}
+
+	if err := scheduleService.CreateDueFeedbacks(); err != nil {
+		log.Println("Job failed: ", job.Name, " with error: ", err)
+		return err
+	}
+
+	log.Println("Completed job: ", job.Name)
+	return nil
+}
@@ -7,13 +7,12 @@ import (
 	"github.com/gocraft/work"
 
 	retroServices "github.com/iReflect/reflect-app/apps/retrospective/services"
-	"github.com/iReflect/reflect-app/db"
 	"github.com/iReflect/reflect-app/workers"
 )
 
 // AssignPointsToSprintTask ...
 func AssignPointsToSprintTask(job *work.Job) error {
-	sprintService := retroServices.SprintService{DB: db.Initialize(workers.Config)}
+	sprintService := retroServices.SprintService{DB: workers.DB}
 
 	sprintID := job.ArgString("sprintID")
 	if sprintID == "" {
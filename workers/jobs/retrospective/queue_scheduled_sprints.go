@@ -0,0 +1,29 @@
+package retrospective
+
+import (
+	"log"
+
+	"github.com/gocraft/work"
+	"github.com/iReflect/reflect-app/workers"
+
+	retroServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+)
+
+func init() {
+	workers.RegisterJob("queue_scheduled_sprints", QueueScheduledSprints)
+	// Every minute, so that the schedules are honoured within a minute of their time
+	workers.RegisterPeriodicJob("0 * * * * *", "queue_scheduled_sprints")
+}
+
+// QueueScheduledSprints ...
+func QueueScheduledSprints(job *work.Job) error {
+	sprintService := retroServices.SprintService{DB: workers.DB}
+
+	if err := sprintService.QueueScheduledSprints(); err != nil {
+		log.Println("Job failed: ", job.Name, " with error: ", err)
+		return err
+	}
+
+	log.Println("Completed job: ", job.Name)
+	return nil
+}
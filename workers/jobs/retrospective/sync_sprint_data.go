@@ -3,10 +3,10 @@ package retrospective
 import (
 	"errors"
 	"github.com/gocraft/work"
-	"github.com/iReflect/reflect-app/db"
 	"github.com/iReflect/reflect-app/workers"
 	"log"
 
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
 	retroServices "github.com/iReflect/reflect-app/apps/retrospective/services"
 )
 
@@ -17,7 +17,7 @@ func init() {
 
 // SyncSprintData ...
 func SyncSprintData(job *work.Job) error {
-	sprintService := retroServices.SprintService{DB: db.Initialize(workers.Config)}
+	sprintService := retroServices.SprintService{DB: workers.DB}
 
 	sprintID := job.ArgString("sprintID")
 	if sprintID == "" {
@@ -25,15 +25,32 @@ func SyncSprintData(job *work.Job) error {
 		return errors.New("sprintID cannot be blank")
 	}
 
-	err := sprintService.SyncSprintData(sprintID)
+	options, err := sprintService.GetQueuedSyncOptions(sprintID)
+	if err != nil {
+		log.Println("Job failed: ", job.Name, " with error: ", err)
+		return err
+	}
+	// The options of the jobs queued before they were recorded on the sync statuses are in the job arguments,
+	// and these jobs are taken as manual
+	if options == nil {
+		options = &retroServices.SyncOptions{
+			AssignPoints: job.ArgBool("assignPoints"),
+			FullResync:   job.ArgBool("fullResync"),
+			Trigger:      retroModels.SyncTrigger(job.ArgString("trigger")),
+		}
+	}
+	if options.Trigger == "" {
+		options.Trigger = retroModels.ManualSyncTrigger
+	}
+
+	err = sprintService.SyncSprintData(sprintID, options.FullResync, options.Trigger)
 
 	if err != nil {
 		log.Println("Job failed: ", job.Name, " with error: ", err)
 		return err
 	}
 
-	assignPoints := job.ArgBool("assignPoints")
-	if assignPoints {
+	if options.AssignPoints {
 		sprintService.AssignPoints(sprintID, nil)
 	}
 
@@ -0,0 +1,37 @@
+package retrospective
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gocraft/work"
+
+	retroServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+	"github.com/iReflect/reflect-app/workers"
+)
+
+func init() {
+	workers.RegisterJob("sync_task_tracker_task", SyncTaskTrackerTask)
+}
+
+// SyncTaskTrackerTask ...
+func SyncTaskTrackerTask(job *work.Job) error {
+	sprintService := retroServices.SprintService{DB: workers.DB}
+
+	retroID := job.ArgString("retroID")
+	providerKey := job.ArgString("providerKey")
+	taskKey := job.ArgString("taskKey")
+	if retroID == "" || providerKey == "" || taskKey == "" {
+		log.Println("Job failed: ", job.Name, " with error: retroID, providerKey and taskKey cannot be blank")
+		return errors.New("retroID, providerKey and taskKey cannot be blank")
+	}
+
+	err := sprintService.SyncTaskTrackerTask(retroID, providerKey, taskKey)
+	if err != nil {
+		log.Println("Job failed: ", job.Name, " with error: ", err)
+		return err
+	}
+
+	log.Println("Completed job: ", job.Name)
+	return nil
+}